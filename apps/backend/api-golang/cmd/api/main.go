@@ -1,9 +1,13 @@
 package main
 
 import (
+	"context"
+	"crypto/rand"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -11,17 +15,30 @@ import (
 	"github.com/bilo-mono/packages/common/calculator"
 	"github.com/bilo-mono/packages/common/logger"
 
+	// Adapters
+	salestaxHolder "api-golang/internal/adapters/salestax/holder"
+	salestaxHTTP "api-golang/internal/adapters/salestax/http"
+
 	// Domain imports
+	customerimpact "api-golang/internal/customer_impact"
 	"api-golang/internal/finance/currency"
+	"api-golang/internal/footprint"
 	"api-golang/internal/funds/salestax"
 	carbonfootprint "api-golang/internal/impact/carbon_footprint"
+	"api-golang/internal/impact/equivalent"
 	"api-golang/internal/impact/fee"
 	"api-golang/internal/impact_partner/impact_partner"
 	"api-golang/internal/impact_partner/impact_project"
+	"api-golang/internal/organisation/apikey"
 	"api-golang/internal/organisation/customer"
 	"api-golang/internal/organisation/organisation"
+	"api-golang/internal/organisation/session"
+	"api-golang/internal/platform/airport"
 	"api-golang/internal/platform/country"
+	"api-golang/internal/platform/mcc"
+	"api-golang/internal/privacy"
 	"api-golang/internal/quote"
+	"api-golang/internal/shared/auth"
 )
 
 type Response struct {
@@ -51,25 +68,105 @@ func main() {
 	// Initialize all domain services
 	// ============================================
 
-	// Organisation domain
-	orgRepo := organisation.NewInMemoryRepository()
-	orgService := organisation.NewService(orgRepo)
-
-	customerRepo := customer.NewInMemoryRepository()
-	customerService := customer.NewService(customerRepo)
-
 	// Platform domain
 	countryRepo := country.NewInMemoryRepository()
 	countryService := country.NewService(countryRepo)
+	countryController := country.NewController(countryService)
+
+	mccRepo := mcc.NewInMemoryRepository()
+	mccService := mcc.NewService(mccRepo)
+	mccController := mcc.NewController(mccService)
 
 	// Finance domain
 	currencyRepo := currency.NewInMemoryRepository()
 	currencyService := currency.NewService(currencyRepo)
 
+	// Organisation domain
+	orgRepo := organisation.NewInMemoryRepository()
+	orgService := organisation.NewService(orgRepo, countryService, currencyService, mccService)
+	if maxDepth := os.Getenv("ORGANISATION_MAX_HIERARCHY_DEPTH"); maxDepth != "" {
+		depth, err := strconv.Atoi(maxDepth)
+		if err != nil || depth < 1 {
+			appLogger.Error("Invalid ORGANISATION_MAX_HIERARCHY_DEPTH", fmt.Errorf("must be a positive integer, got %q", maxDepth))
+			os.Exit(1)
+		}
+		orgService.WithMaxHierarchyDepth(depth)
+	}
+	orgController := organisation.NewController(orgService)
+
+	// API keys authenticate every organisation-scoped endpoint. The published
+	// development keys are only accepted when explicitly enabled.
+	apiKeyRepo := apikey.NewInMemoryRepository()
+	localDevelopment := os.Getenv("API_DEV_KEYS") == "true"
+	if localDevelopment {
+		apiKeyRepo = apikey.NewDevInMemoryRepository()
+		appLogger.Info("API_DEV_KEYS enabled, accepting the local development API keys")
+	}
+	apiKeyService := apikey.NewService(apiKeyRepo, orgService)
+	apiKeyController := apikey.NewController(apiKeyService)
+
+	// Session tokens let browser SDKs call the quote endpoints without an API key.
+	// Only local development may run without a configured secret, using a random
+	// one whose tokens stop working when the server restarts.
+	sessionSecret := []byte(os.Getenv("SESSION_TOKEN_SECRET"))
+	if len(sessionSecret) == 0 {
+		if !localDevelopment {
+			appLogger.Error("Invalid SESSION_TOKEN_SECRET", fmt.Errorf("must be set unless API_DEV_KEYS is enabled"))
+			os.Exit(1)
+		}
+		sessionSecret = make([]byte, 32)
+		if _, err := rand.Read(sessionSecret); err != nil {
+			appLogger.Error("Failed to generate session token secret", err)
+			os.Exit(1)
+		}
+		appLogger.Info("SESSION_TOKEN_SECRET not set, using a random secret for this process")
+	}
+	sessionSigner, err := auth.NewTokenSigner(sessionSecret)
+	if err != nil {
+		appLogger.Error("Invalid SESSION_TOKEN_SECRET", err)
+		os.Exit(1)
+	}
+	sessionService := session.NewService(orgService, sessionSigner)
+	sessionController := session.NewController(sessionService)
+	authMiddleware := auth.NewMiddleware(apiKeyService, sessionSigner)
+
+	// The admin endpoints only accept the operator key, never organisation keys
+	adminKeys, err := auth.NewAdminKeyAuthenticator(os.Getenv("ADMIN_API_KEY"))
+	if err != nil {
+		appLogger.Error("Invalid ADMIN_API_KEY", err)
+		os.Exit(1)
+	}
+	adminMiddleware := auth.NewMiddleware(adminKeys, nil)
+
+	customerRepo := customer.NewInMemoryRepository()
+	customerService := customer.NewService(customerRepo, countryService)
+	customerController := customer.NewController(customerService, orgService)
+
 	// Impact domain - Carbon Footprint
 	carbonFactorRepo := carbonfootprint.NewInMemoryFactorRepository()
+	carbonCategoryRepo := carbonfootprint.NewInMemoryCategoryRepository()
+	natureFactorRepo := carbonfootprint.NewInMemoryNatureFactorRepository()
 	carbonFootprintRepo := carbonfootprint.NewInMemoryFootprintRepository()
-	carbonService := carbonfootprint.NewService(carbonFactorRepo, carbonFootprintRepo)
+	airportService := airport.NewService(airport.NewInMemoryRepository())
+	carbonService := carbonfootprint.NewService(carbonFactorRepo, carbonCategoryRepo, natureFactorRepo, carbonFootprintRepo, airportService)
+	carbonFactorImporter := carbonfootprint.NewFactorImporter(carbonFactorRepo)
+	carbonController := carbonfootprint.NewController(carbonFactorImporter)
+
+	// Impact domain - Equivalents
+	// Use a custom catalogue when configured, otherwise the built-in one
+	equivalentRepo := equivalent.NewInMemoryRepository()
+	if cataloguePath := os.Getenv("EQUIVALENTS_CATALOGUE"); cataloguePath != "" {
+		data, err := os.ReadFile(cataloguePath)
+		if err == nil {
+			equivalentRepo, err = equivalent.NewInMemoryRepositoryFromJSON(data)
+		}
+		if err != nil {
+			appLogger.Error("Failed to load equivalents catalogue", err)
+			os.Exit(1)
+		}
+		appLogger.Infof("Using equivalents catalogue from %s", cataloguePath)
+	}
+	equivalentService := equivalent.NewService(equivalentRepo)
 
 	// Impact domain - Fee
 	feeRepo := fee.NewInMemoryRepository()
@@ -87,8 +184,32 @@ func main() {
 	projectController := impact_project.NewController(projectService)
 
 	// Funds domain - Sales Tax
-	salesTaxRepo := salestax.NewInMemoryRepository()
-	salesTaxService := salestax.NewService(salesTaxRepo)
+	// Load a US zip-level rate table into the local table when configured
+	localSalesTaxRepo := salestax.NewInMemoryRepository()
+	if rateTablePath := os.Getenv("SALES_TAX_RATE_TABLE"); rateTablePath != "" {
+		f, err := os.Open(rateTablePath)
+		if err != nil {
+			appLogger.Error("Failed to open sales tax rate table", err)
+			os.Exit(1)
+		}
+		count, err := salestax.ImportRateTable(context.Background(), localSalesTaxRepo, f)
+		f.Close()
+		if err != nil {
+			appLogger.Error("Failed to import sales tax rate table", err)
+			os.Exit(1)
+		}
+		appLogger.Infof("Imported %d sales tax rates from %s", count, rateTablePath)
+	}
+	// Use the external tax provider when configured, falling back to the local table
+	var salesTaxRepo salestax.Repository = localSalesTaxRepo
+	if taxProviderURL := os.Getenv("TAX_PROVIDER_URL"); taxProviderURL != "" {
+		salesTaxRepo = salestaxHTTP.NewHTTPClientAdapter(taxProviderURL, salesTaxRepo)
+		appLogger.Infof("Using external tax provider at %s", taxProviderURL)
+	}
+	salesTaxExemptionRepo := salestax.NewInMemoryExemptionRepository()
+	salesTaxService := salestax.NewService(salesTaxRepo, salesTaxExemptionRepo, countryService,
+		salestaxHolder.NewAccessAdapter(orgService, customerService))
+	salesTaxController := salestax.NewController(salesTaxService)
 
 	// Quote domain - Orchestrator
 	quoteRepo := quote.NewInMemoryRepository()
@@ -96,8 +217,10 @@ func main() {
 		OrganisationService:  orgService,
 		CustomerService:      customerService,
 		CountryService:       countryService,
+		MCCService:           mccService,
 		CurrencyService:      currencyService,
 		CarbonService:        carbonService,
+		EquivalentService:    equivalentService,
 		FeeService:           feeService,
 		BlendedPriceCalc:     blendedPriceCalc,
 		ImpactPartnerService: partnerService,
@@ -106,6 +229,37 @@ func main() {
 	})
 	quoteController := quote.NewController(quoteOrchestrator)
 
+	// Footprint - Estimates and lookups
+	footprintOrchestrator := footprint.NewOrchestrator(footprint.OrchestratorDeps{
+		Organisations:     orgService,
+		CountryService:    countryService,
+		MCCService:        mccService,
+		CurrencyService:   currencyService,
+		CarbonService:     carbonService,
+		EquivalentService: equivalentService,
+	})
+	footprintController := footprint.NewController(footprintOrchestrator)
+
+	// Privacy - Data subject exports and erasures
+	privacyOrchestrator := privacy.NewOrchestrator(privacy.OrchestratorDeps{
+		Organisations:   orgService,
+		CustomerService: customerService,
+		CarbonService:   carbonService,
+		QuoteService:    quoteOrchestrator,
+		SalesTaxService: salesTaxService,
+		AuditRepo:       privacy.NewInMemoryAuditRepository(),
+	})
+	privacyController := privacy.NewController(privacyOrchestrator)
+
+	// Customer impact - Footprint and compensation totals
+	customerImpactOrchestrator := customerimpact.NewOrchestrator(customerimpact.OrchestratorDeps{
+		Organisations:   orgService,
+		CustomerService: customerService,
+		CarbonService:   carbonService,
+		QuoteService:    quoteOrchestrator,
+	})
+	customerImpactController := customerimpact.NewController(customerImpactOrchestrator)
+
 	appLogger.Info("All domain services initialized successfully")
 
 	// ============================================
@@ -137,15 +291,89 @@ func main() {
 	})
 
 	// Quote routes (NEW)
-	http.HandleFunc("/api/quotes", quoteController.HandleCreateQuote)
+	createQuote := authMiddleware.Require(auth.ScopeQuotesCreate, quoteController.HandleCreateQuote)
+	getQuote := authMiddleware.Require(auth.ScopeQuotesRead, quoteController.HandleGetQuote)
+	acceptQuote := authMiddleware.Require(auth.ScopeQuotesAccept, quoteController.HandleAcceptQuote)
+	listQuotes := authMiddleware.Require(auth.ScopeQuotesRead, quoteController.HandleListQuotes)
+	quotes := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			listQuotes(w, r)
+		} else {
+			createQuote(w, r)
+		}
+	}
+	http.HandleFunc("/api/quotes", quotes)
 	http.HandleFunc("/api/quotes/", func(w http.ResponseWriter, r *http.Request) {
-		if strings.TrimPrefix(r.URL.Path, "/api/quotes/") != "" {
-			quoteController.HandleGetQuote(w, r)
+		path := strings.TrimPrefix(r.URL.Path, "/api/quotes/")
+		switch {
+		case strings.HasSuffix(path, "/accept"):
+			acceptQuote(w, r)
+		case path != "":
+			getQuote(w, r)
+		default:
+			quotes(w, r)
+		}
+	})
+
+	// Browser SDK session routes
+	http.HandleFunc("/api/sessions", authMiddleware.Require(auth.ScopeQuotesCreate, sessionController.HandleCreateSession))
+
+	// Footprint routes
+	http.HandleFunc("/api/footprints/estimate", authMiddleware.Require(auth.ScopeQuotesCreate, footprintController.HandleEstimate))
+	http.HandleFunc("/api/footprints/", authMiddleware.Require(auth.ScopeQuotesRead, footprintController.HandleGetByID))
+
+	// Organisation routes
+	http.HandleFunc("/api/organisations", authMiddleware.Require(auth.ScopeOrganisationsManage, orgController.HandleOrganisations))
+	http.HandleFunc("/api/organisations/", authMiddleware.Require(auth.ScopeOrganisationsManage, orgController.HandleOrganisation))
+
+	// Customer routes
+	http.HandleFunc("/api/customers", authMiddleware.Require(auth.ScopeCustomersManage, customerController.HandleCustomers))
+	customerDetail := authMiddleware.Require(auth.ScopeCustomersManage, customerController.HandleCustomer)
+	customerPrivacy := authMiddleware.Require(auth.ScopeCustomersPrivacy, privacyController.HandleCustomer)
+	customerImpact := authMiddleware.Require(auth.ScopeQuotesRead, customerImpactController.HandleCustomerImpact)
+	http.HandleFunc("/api/customers/", func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimSuffix(r.URL.Path, "/")
+		switch {
+		case strings.HasSuffix(path, "/export") || strings.HasSuffix(path, "/erase") || strings.HasSuffix(path, "/audit"):
+			customerPrivacy(w, r)
+		case strings.HasSuffix(path, "/impact"):
+			customerImpact(w, r)
+		default:
+			customerDetail(w, r)
+		}
+	})
+
+	// API key routes
+	http.HandleFunc("/api/api-keys", authMiddleware.Require(auth.ScopeAPIKeysManage, apiKeyController.HandleAPIKeys))
+	http.HandleFunc("/api/api-keys/", authMiddleware.Require(auth.ScopeAPIKeysManage, apiKeyController.HandleAPIKey))
+
+	// Country routes
+	http.HandleFunc("/api/countries", countryController.HandleGetAll)
+	http.HandleFunc("/api/countries/", func(w http.ResponseWriter, r *http.Request) {
+		if strings.TrimPrefix(r.URL.Path, "/api/countries/") != "" {
+			countryController.HandleGetByCode(w, r)
 		} else {
-			quoteController.HandleCreateQuote(w, r)
+			countryController.HandleGetAll(w, r)
 		}
 	})
 
+	// Merchant category code routes
+	http.HandleFunc("/api/mccs", mccController.HandleGetAll)
+	http.HandleFunc("/api/mccs/", func(w http.ResponseWriter, r *http.Request) {
+		if strings.TrimPrefix(r.URL.Path, "/api/mccs/") != "" {
+			mccController.HandleGetByCode(w, r)
+		} else {
+			mccController.HandleGetAll(w, r)
+		}
+	})
+
+	// Sales tax routes
+	http.HandleFunc("/api/tax-exemptions", authMiddleware.Require(auth.ScopeTaxExemptionsManage, salesTaxController.HandleExemptions))
+
+	// Carbon factor admin routes
+	http.HandleFunc("/api/admin/carbon-factors", adminMiddleware.Require(auth.ScopeCarbonFactorsAdmin, carbonController.HandleListFactors))
+	http.HandleFunc("/api/admin/carbon-factors/import", adminMiddleware.Require(auth.ScopeCarbonFactorsAdmin, carbonController.HandleImportFactors))
+
 	// ============================================
 	// Start server
 	// ============================================
@@ -162,9 +390,54 @@ func main() {
 	fmt.Println("  - GET  http://localhost" + port + "/api/impact-projects")
 	fmt.Println("  - GET  http://localhost" + port + "/api/impact-projects/{id}")
 	fmt.Println("  - GET  http://localhost" + port + "/api/impact-projects?partnerId={id}")
+	fmt.Println("\nOrganisations:")
+	fmt.Println("  - GET  http://localhost" + port + "/api/organisations?parentId={id}&status={status}")
+	fmt.Println("  - POST http://localhost" + port + "/api/organisations")
+	fmt.Println("  - GET  http://localhost" + port + "/api/organisations/{id}")
+	fmt.Println("  - PATCH http://localhost" + port + "/api/organisations/{id}")
+	fmt.Println("  - GET  http://localhost" + port + "/api/organisations/{id}/children")
+	fmt.Println("  - POST http://localhost" + port + "/api/organisations/{id}/deactivate")
+	fmt.Println("  - POST http://localhost" + port + "/api/organisations/{id}/reactivate")
+	fmt.Println("\nCustomers:")
+	fmt.Println("  - GET  http://localhost" + port + "/api/customers?organisationId={id}&country={code}")
+	fmt.Println("  - GET  http://localhost" + port + "/api/customers/{id}")
+	fmt.Println("  - PATCH http://localhost" + port + "/api/customers/{id}")
+	fmt.Println("  - GET  http://localhost" + port + "/api/customers/{id}/export")
+	fmt.Println("  - POST http://localhost" + port + "/api/customers/{id}/erase")
+	fmt.Println("  - GET  http://localhost" + port + "/api/customers/{id}/audit")
+	fmt.Println("  - GET  http://localhost" + port + "/api/customers/{id}/impact?from={date}&to={date}")
+	fmt.Println("\nAPI Keys:")
+	fmt.Println("  - GET  http://localhost" + port + "/api/api-keys?organisationId={id}")
+	fmt.Println("  - POST http://localhost" + port + "/api/api-keys")
+	fmt.Println("  - POST http://localhost" + port + "/api/api-keys/{id}/rotate")
+	fmt.Println("  - POST http://localhost" + port + "/api/api-keys/{id}/revoke")
 	fmt.Println("\nQuotes:")
+	fmt.Println("  - GET  http://localhost" + port + "/api/quotes?status={status}&customerId={id}&currency={code}&product={product}&from={date}&to={date}&cursor={cursor}")
 	fmt.Println("  - POST http://localhost" + port + "/api/quotes")
 	fmt.Println("  - GET  http://localhost" + port + "/api/quotes/{id}")
+	fmt.Println("  - POST http://localhost" + port + "/api/quotes/{id}/accept")
+	fmt.Println("\nBrowser SDK Sessions:")
+	fmt.Println("  - POST http://localhost" + port + "/api/sessions")
+	fmt.Println("\nFootprints:")
+	fmt.Println("  - POST http://localhost" + port + "/api/footprints/estimate")
+	fmt.Println("  - GET  http://localhost" + port + "/api/footprints/{id}")
+	fmt.Println("\nCountries:")
+	fmt.Println("  - GET  http://localhost" + port + "/api/countries")
+	fmt.Println("  - GET  http://localhost" + port + "/api/countries/{code}")
+	fmt.Println("  - GET  http://localhost" + port + "/api/countries/{code}/subdivisions")
+	fmt.Println("\nMerchant Category Codes:")
+	fmt.Println("  - GET  http://localhost" + port + "/api/mccs?group={group}&q={search}")
+	fmt.Println("  - GET  http://localhost" + port + "/api/mccs/{code}")
+	fmt.Println("  - GET  http://localhost" + port + "/api/mccs/groups")
+	fmt.Println("\nSales Tax:")
+	fmt.Println("  - POST http://localhost" + port + "/api/tax-exemptions")
+	fmt.Println("  - GET  http://localhost" + port + "/api/tax-exemptions?holderType={type}&holderId={id}")
+	fmt.Println("\nCarbon Factors (admin):")
+	fmt.Println("  - GET  http://localhost" + port + "/api/admin/carbon-factors")
+	fmt.Println("  - POST http://localhost" + port + "/api/admin/carbon-factors/import?format={csv|json}&dryRun={true|false}")
+	fmt.Println("\nOrganisation, customer, API key, session, quote, footprint and tax exemption endpoints require \"Authorization: Bearer <api key>\".")
+	fmt.Println("Quote create and accept also accept \"Authorization: Bearer <session token>\".")
+	fmt.Println("Admin endpoints require \"Authorization: Bearer <ADMIN_API_KEY>\".")
 	fmt.Println()
 
 	appLogger.Infof("Server listening on http://localhost%s", port)
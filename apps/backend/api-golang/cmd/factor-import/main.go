@@ -0,0 +1,121 @@
+// Command factor-import loads a carbon factor dataset (CSV or JSON) into the
+// API's factor store through the admin endpoint. It runs as a dry run by
+// default and prints the diff; pass -commit to store the factors. The admin
+// endpoint requires the operator key, taken from -admin-key or ADMIN_API_KEY.
+//
+// Usage:
+//
+//	factor-import -file factors-2025.csv [-format csv] [-api http://localhost:8080] [-admin-key key] [-commit]
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	carbonfootprint "api-golang/internal/impact/carbon_footprint"
+)
+
+func main() {
+	file := flag.String("file", "", "path to the factor dataset (required)")
+	format := flag.String("format", "", "dataset format: csv or json (default: from file extension)")
+	apiURL := flag.String("api", "http://localhost:8080", "base URL of the API")
+	adminKey := flag.String("admin-key", os.Getenv("ADMIN_API_KEY"), "operator key for the admin endpoint (default: $ADMIN_API_KEY)")
+	commit := flag.Bool("commit", false, "store the factors instead of only printing the diff")
+	flag.Parse()
+
+	if *file == "" || *adminKey == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *format == "" {
+		*format = strings.TrimPrefix(strings.ToLower(filepath.Ext(*file)), ".")
+	}
+
+	if err := run(*file, *format, *apiURL, *adminKey, *commit); err != nil {
+		fmt.Fprintln(os.Stderr, "factor-import:", err)
+		os.Exit(1)
+	}
+}
+
+func run(file, format, apiURL, adminKey string, commit bool) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	// Parse locally so malformed files fail before reaching the API
+	factors, err := carbonfootprint.ParseFactors(format, f)
+	if err != nil {
+		return fmt.Errorf("parsing %s: %w", file, err)
+	}
+
+	body, err := json.Marshal(factors)
+	if err != nil {
+		return err
+	}
+	url := fmt.Sprintf("%s/api/admin/carbon-factors/import?format=json&dryRun=%t", strings.TrimRight(apiURL, "/"), !commit)
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+adminKey)
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("calling API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return fmt.Errorf("API rejected the admin key (status %d)", resp.StatusCode)
+	}
+
+	var result carbonfootprint.FactorImportResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("decoding API response (status %d): %w", resp.StatusCode, err)
+	}
+
+	printResult(&result)
+
+	if len(result.Issues) > 0 {
+		return fmt.Errorf("dataset rejected with %d issue(s)", len(result.Issues))
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("API returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func printResult(result *carbonfootprint.FactorImportResult) {
+	for _, change := range result.Changes {
+		f := change.Factor
+		switch change.Type {
+		case carbonfootprint.FactorAdded:
+			fmt.Printf("+ %-8s %-4s %-3s %d v%d  %v kg/EUR  (%s)\n", f.ID, f.MCC, f.CountryID, f.EffectiveYear, f.Version, f.Factor, f.Source)
+		case carbonfootprint.FactorUpdated:
+			p := change.Previous
+			fmt.Printf("~ %-8s %-4s %-3s %d v%d  %v -> %v kg/EUR  (%s)\n", f.ID, f.MCC, f.CountryID, f.EffectiveYear, f.Version, p.Factor, f.Factor, f.Source)
+		}
+	}
+	for _, issue := range result.Issues {
+		fmt.Printf("! %-8s %s: %s\n", issue.FactorID, issue.Field, issue.Message)
+	}
+
+	fmt.Printf("\n%d added, %d updated, %d unchanged\n", result.Added, result.Updated, result.Unchanged)
+	switch {
+	case result.Committed:
+		fmt.Println("Factors committed.")
+	case result.DryRun && len(result.Issues) == 0:
+		fmt.Println("Dry run: nothing stored. Re-run with -commit to apply.")
+	}
+}
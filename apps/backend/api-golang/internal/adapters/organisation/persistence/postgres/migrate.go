@@ -0,0 +1,95 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"sort"
+)
+
+// migrations holds the schema of the organisations table, applied in file name order
+//
+//go:embed migrations/*.sql
+var migrations embed.FS
+
+// migrationPrefix namespaces this adapter's versions in schema_migrations,
+// which other adapters may share
+const migrationPrefix = "organisation/"
+
+// Migrate applies the embedded migrations that have not run yet. Each
+// migration runs in its own transaction together with its schema_migrations
+// row, so a failed migration leaves no partial schema behind. A session-level
+// advisory lock keyed on schema_migrations serialises instances that start at
+// the same time, including those migrating other adapters' tables.
+func Migrate(ctx context.Context, db *sql.DB) error {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("acquiring connection: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock(hashtext('schema_migrations'))`); err != nil {
+		return fmt.Errorf("locking schema_migrations: %w", err)
+	}
+	defer conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock(hashtext('schema_migrations'))`)
+
+	if _, err := conn.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version    TEXT PRIMARY KEY,
+			applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+		)
+	`); err != nil {
+		return fmt.Errorf("creating schema_migrations: %w", err)
+	}
+
+	names, err := fs.Glob(migrations, "migrations/*.sql")
+	if err != nil {
+		return fmt.Errorf("listing migrations: %w", err)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		version := migrationPrefix + name[len("migrations/"):]
+		if err := applyMigration(ctx, conn, name, version); err != nil {
+			return fmt.Errorf("applying migration %s: %w", version, err)
+		}
+	}
+	return nil
+}
+
+// applyMigration runs one migration file unless its version is already recorded
+func applyMigration(ctx context.Context, conn *sql.Conn, name, version string) error {
+	script, err := migrations.ReadFile(name)
+	if err != nil {
+		return err
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var applied bool
+	err = tx.QueryRowContext(ctx,
+		`SELECT EXISTS (SELECT 1 FROM schema_migrations WHERE version = $1)`, version,
+	).Scan(&applied)
+	if err != nil {
+		return err
+	}
+	if applied {
+		return nil
+	}
+
+	if _, err := tx.ExecContext(ctx, string(script)); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO schema_migrations (version) VALUES ($1)`, version,
+	); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,23 @@
+-- The organisations table as documented before migrations were embedded.
+-- Existing databases may already have it, so this only creates it for new ones.
+CREATE TABLE IF NOT EXISTS organisations (
+    organisation_id           TEXT PRIMARY KEY,
+    parent_organisation_id    TEXT REFERENCES organisations (organisation_id),
+    organisation_reference    TEXT,
+    trading_name              TEXT NOT NULL,
+    legal_name                TEXT NOT NULL,
+    address                   JSONB NOT NULL,
+    currency_code             CHAR(3) NOT NULL,
+    website                   TEXT,
+    billing                   JSONB,
+    mcc                       CHAR(4),
+    relative_profit_share     NUMERIC NOT NULL DEFAULT 0,
+    proportional_profit_share NUMERIC NOT NULL DEFAULT 0,
+    service_fee_percentage    NUMERIC NOT NULL DEFAULT 0,
+    status                    TEXT NOT NULL,
+    status_message            TEXT,
+    impact_partners           JSONB NOT NULL DEFAULT '[]',
+    calculation_types         JSONB NOT NULL DEFAULT '[]'
+);
+
+CREATE INDEX IF NOT EXISTS organisations_parent_idx ON organisations (parent_organisation_id);
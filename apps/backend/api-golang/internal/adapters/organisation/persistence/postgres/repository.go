@@ -0,0 +1,271 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"api-golang/internal/organisation/organisation"
+	"api-golang/internal/shared/errors"
+)
+
+// PostgresRepository implements organisation.Repository interface
+// This adapter replaces InMemoryRepository when using PostgreSQL.
+//
+// The organisations table is created by the embedded migrations; run Migrate
+// before use. Address, billing, impact partners and calculation types are
+// stored as JSONB.
+type PostgresRepository struct {
+	db *sql.DB
+}
+
+var _ organisation.Repository = (*PostgresRepository)(nil)
+
+// NewPostgresRepository creates a new PostgreSQL repository adapter
+func NewPostgresRepository(db *sql.DB) *PostgresRepository {
+	return &PostgresRepository{
+		db: db,
+	}
+}
+
+const organisationColumns = `
+	organisation_id, parent_organisation_id, organisation_reference, trading_name,
+	legal_name, address, currency_code, website, billing, mcc,
+	relative_profit_share, proportional_profit_share, service_fee_percentage,
+	status, status_message, impact_partners, calculation_types`
+
+// GetByID retrieves an organisation by ID from PostgreSQL
+func (r *PostgresRepository) GetByID(ctx context.Context, id string) (*organisation.Entity, error) {
+	query := `SELECT ` + organisationColumns + ` FROM organisations WHERE organisation_id = $1`
+
+	org, err := scanOrganisation(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, errors.NewNotFoundError("organisation", "organisation not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return org, nil
+}
+
+// GetChildren retrieves all child organisations of a parent from PostgreSQL
+func (r *PostgresRepository) GetChildren(ctx context.Context, parentID string) ([]*organisation.Entity, error) {
+	return r.List(ctx, organisation.ListFilter{ParentOrganisationID: &parentID})
+}
+
+// GetAncestors retrieves the ancestors of an organisation, nearest first, in a
+// single recursive query. The path array stops the walk if the data has a cycle.
+func (r *PostgresRepository) GetAncestors(ctx context.Context, id string, maxDepth int) ([]*organisation.Entity, error) {
+	if _, err := r.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	query := `
+		WITH RECURSIVE ancestors AS (
+			SELECT parent.*, 1 AS depth, ARRAY[child.organisation_id, parent.organisation_id] AS path
+			FROM organisations child
+			JOIN organisations parent ON parent.organisation_id = child.parent_organisation_id
+			WHERE child.organisation_id = $1
+			UNION ALL
+			SELECT parent.*, a.depth + 1, a.path || parent.organisation_id
+			FROM ancestors a
+			JOIN organisations parent ON parent.organisation_id = a.parent_organisation_id
+			WHERE ($2 <= 0 OR a.depth < $2)
+			  AND NOT parent.organisation_id = ANY (a.path)
+		)
+		SELECT ` + organisationColumns + ` FROM ancestors ORDER BY depth
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, id, maxDepth)
+	if err != nil {
+		return nil, err
+	}
+	return scanOrganisations(rows)
+}
+
+// List retrieves organisations matching the filter from PostgreSQL, sorted by trading name
+func (r *PostgresRepository) List(ctx context.Context, filter organisation.ListFilter) ([]*organisation.Entity, error) {
+	var conditions []string
+	var args []interface{}
+	if filter.ParentOrganisationID != nil {
+		if *filter.ParentOrganisationID == "" {
+			conditions = append(conditions, "parent_organisation_id IS NULL")
+		} else {
+			args = append(args, *filter.ParentOrganisationID)
+			conditions = append(conditions, fmt.Sprintf("parent_organisation_id = $%d", len(args)))
+		}
+	}
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+
+	query := `SELECT ` + organisationColumns + ` FROM organisations`
+	if len(conditions) > 0 {
+		query += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+	query += ` ORDER BY trading_name, organisation_id`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return scanOrganisations(rows)
+}
+
+// Create stores a new organisation in PostgreSQL
+func (r *PostgresRepository) Create(ctx context.Context, org *organisation.Entity) error {
+	query := `
+		INSERT INTO organisations (` + organisationColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
+	`
+
+	args, err := organisationArgs(org)
+	if err != nil {
+		return err
+	}
+	_, err = r.db.ExecContext(ctx, query, args...)
+	return err
+}
+
+// Update updates an existing organisation in PostgreSQL
+func (r *PostgresRepository) Update(ctx context.Context, org *organisation.Entity) error {
+	query := `
+		UPDATE organisations
+		SET parent_organisation_id = $2, organisation_reference = $3, trading_name = $4,
+		    legal_name = $5, address = $6, currency_code = $7, website = $8, billing = $9,
+		    mcc = $10, relative_profit_share = $11, proportional_profit_share = $12,
+		    service_fee_percentage = $13, status = $14, status_message = $15,
+		    impact_partners = $16, calculation_types = $17
+		WHERE organisation_id = $1
+	`
+
+	args, err := organisationArgs(org)
+	if err != nil {
+		return err
+	}
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return errors.NewNotFoundError("organisation", "organisation not found")
+	}
+	return nil
+}
+
+// organisationArgs returns the column values of an organisation in organisationColumns order
+func organisationArgs(org *organisation.Entity) ([]interface{}, error) {
+	address, err := json.Marshal(org.Address)
+	if err != nil {
+		return nil, fmt.Errorf("encoding address: %w", err)
+	}
+	var billing []byte
+	if org.Billing != nil {
+		if billing, err = json.Marshal(org.Billing); err != nil {
+			return nil, fmt.Errorf("encoding billing: %w", err)
+		}
+	}
+	impactPartners, err := json.Marshal(nonNil(org.ImpactPartners))
+	if err != nil {
+		return nil, fmt.Errorf("encoding impact partners: %w", err)
+	}
+	calculationTypes, err := json.Marshal(nonNil(org.CalculationTypes))
+	if err != nil {
+		return nil, fmt.Errorf("encoding calculation types: %w", err)
+	}
+
+	return []interface{}{
+		org.OrganisationID,
+		org.ParentOrganisationID,
+		org.OrganisationReference,
+		org.TradingName,
+		org.LegalName,
+		address,
+		org.CurrencyCode,
+		org.Website,
+		billing,
+		org.MCC,
+		org.RelativeProfitShare,
+		org.ProportionalProfitShare,
+		org.ServiceFeePercentage,
+		org.Status.Value,
+		org.Status.Message,
+		impactPartners,
+		calculationTypes,
+	}, nil
+}
+
+// rowScanner is satisfied by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanOrganisation scans a row selected with organisationColumns
+func scanOrganisation(row rowScanner) (*organisation.Entity, error) {
+	var org organisation.Entity
+	var address, billing, impactPartners, calculationTypes []byte
+	err := row.Scan(
+		&org.OrganisationID,
+		&org.ParentOrganisationID,
+		&org.OrganisationReference,
+		&org.TradingName,
+		&org.LegalName,
+		&address,
+		&org.CurrencyCode,
+		&org.Website,
+		&billing,
+		&org.MCC,
+		&org.RelativeProfitShare,
+		&org.ProportionalProfitShare,
+		&org.ServiceFeePercentage,
+		&org.Status.Value,
+		&org.Status.Message,
+		&impactPartners,
+		&calculationTypes,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(address, &org.Address); err != nil {
+		return nil, fmt.Errorf("decoding address of %s: %w", org.OrganisationID, err)
+	}
+	if billing != nil {
+		if err := json.Unmarshal(billing, &org.Billing); err != nil {
+			return nil, fmt.Errorf("decoding billing of %s: %w", org.OrganisationID, err)
+		}
+	}
+	if err := json.Unmarshal(impactPartners, &org.ImpactPartners); err != nil {
+		return nil, fmt.Errorf("decoding impact partners of %s: %w", org.OrganisationID, err)
+	}
+	if err := json.Unmarshal(calculationTypes, &org.CalculationTypes); err != nil {
+		return nil, fmt.Errorf("decoding calculation types of %s: %w", org.OrganisationID, err)
+	}
+	return &org, nil
+}
+
+// scanOrganisations scans and closes a result set selected with organisationColumns
+func scanOrganisations(rows *sql.Rows) ([]*organisation.Entity, error) {
+	defer rows.Close()
+
+	orgs := make([]*organisation.Entity, 0)
+	for rows.Next() {
+		org, err := scanOrganisation(rows)
+		if err != nil {
+			return nil, err
+		}
+		orgs = append(orgs, org)
+	}
+	return orgs, rows.Err()
+}
+
+// nonNil stores empty lists as [] rather than null
+func nonNil[T any](s []T) []T {
+	if s == nil {
+		return []T{}
+	}
+	return s
+}
@@ -0,0 +1,173 @@
+package postgres
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io/fs"
+	"reflect"
+	"strings"
+	"testing"
+
+	"api-golang/internal/organisation/organisation"
+	"api-golang/internal/shared/types"
+)
+
+// fakeRow scans stored column values the way database/sql does for the types
+// the adapter uses: Valuers are stored by value and Scanners read them back
+type fakeRow []interface{}
+
+func (r fakeRow) Scan(dest ...interface{}) error {
+	if len(dest) != len(r) {
+		return fmt.Errorf("expected %d destinations, got %d", len(r), len(dest))
+	}
+	for i, d := range dest {
+		value := r[i]
+		if valuer, ok := value.(driver.Valuer); ok {
+			v, err := valuer.Value()
+			if err != nil {
+				return err
+			}
+			value = v
+		}
+		if scanner, ok := d.(sql.Scanner); ok {
+			if err := scanner.Scan(value); err != nil {
+				return err
+			}
+			continue
+		}
+
+		target := reflect.ValueOf(d).Elem()
+		source := reflect.ValueOf(value)
+		switch {
+		case value == nil:
+			target.Set(reflect.Zero(target.Type()))
+		case source.Type().AssignableTo(target.Type()):
+			target.Set(source)
+		case source.Type().ConvertibleTo(target.Type()):
+			target.Set(source.Convert(target.Type()))
+		default:
+			return fmt.Errorf("column %d: cannot scan %T into %T", i, value, d)
+		}
+	}
+	return nil
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func TestOrganisationRoundTrip(t *testing.T) {
+	full := &organisation.Entity{
+		OrganisationID:        "org-2",
+		ParentOrganisationID:  stringPtr("org-1"),
+		OrganisationReference: stringPtr("acme-ie"),
+		TradingName:           "Acme Ireland",
+		LegalName:             "Acme Ireland Ltd",
+		Address: types.Address{
+			Line1:       "1 Grafton Street",
+			City:        "Dublin",
+			PostalCode:  "D02 X285",
+			CountryCode: "IRL",
+		},
+		CurrencyCode: "EUR",
+		Website:      stringPtr("https://acme.example"),
+		Billing: &types.BillingConfig{
+			CompanyRegistrationNumber: "IE123456",
+			CurrencyCode:              "EUR",
+			Email:                     "billing@acme.example",
+			TaxNumber:                 stringPtr("IE1234567T"),
+		},
+		MCC:                     stringPtr("5411"),
+		RelativeProfitShare:     0.5,
+		ProportionalProfitShare: 0.25,
+		ServiceFeePercentage:    0.1,
+		Status:                  types.OrganisationStatus{Value: organisation.StatusInactive, Message: stringPtr("Offboarded")},
+		ImpactPartners:          []types.OrganisationImpactPartner{{ID: "partner-1", Name: "Partner One"}},
+		CalculationTypes:        []string{organisation.CalculationTypeCarbon, organisation.CalculationTypeNature},
+	}
+	minimal := &organisation.Entity{
+		OrganisationID:   "org-1",
+		TradingName:      "Acme",
+		LegalName:        "Acme Bank plc",
+		Address:          types.Address{CountryCode: "GBR"},
+		CurrencyCode:     "GBP",
+		Status:           types.OrganisationStatus{Value: organisation.StatusActive},
+		ImpactPartners:   []types.OrganisationImpactPartner{},
+		CalculationTypes: []string{},
+	}
+
+	for _, tt := range []struct {
+		name string
+		org  *organisation.Entity
+	}{
+		{name: "All fields set", org: full},
+		{name: "Optional fields unset", org: minimal},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			args, err := organisationArgs(tt.org)
+			if err != nil {
+				t.Fatalf("organisationArgs failed: %v", err)
+			}
+			got, err := scanOrganisation(fakeRow(args))
+			if err != nil {
+				t.Fatalf("scanOrganisation failed: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.org) {
+				t.Errorf("Round trip changed the organisation:\n got %+v\nwant %+v", got, tt.org)
+			}
+		})
+	}
+
+	// Unset lists are stored as [] rather than NULL
+	args, _ := organisationArgs(&organisation.Entity{})
+	for _, i := range []int{15, 16} {
+		if string(args[i].([]byte)) != "[]" {
+			t.Errorf("Expected [] for column %d, got %s", i, args[i])
+		}
+	}
+}
+
+func TestMigrationsCoverOrganisationColumns(t *testing.T) {
+	names, err := fs.Glob(migrations, "migrations/*.sql")
+	if err != nil {
+		t.Fatalf("Listing migrations failed: %v", err)
+	}
+
+	// Columns come from the CREATE TABLE body and from ADD COLUMN clauses
+	defined := map[string]bool{}
+	for _, name := range names {
+		script, err := migrations.ReadFile(name)
+		if err != nil {
+			t.Fatalf("Reading migration failed: %v", err)
+		}
+		inTable := false
+		for _, line := range strings.Split(string(script), "\n") {
+			line = strings.TrimSpace(line)
+			switch {
+			case strings.HasPrefix(line, "CREATE TABLE IF NOT EXISTS organisations"):
+				inTable = true
+			case strings.HasPrefix(line, ");"):
+				inTable = false
+			case inTable && line != "":
+				defined[strings.Fields(line)[0]] = true
+			case strings.HasPrefix(line, "ADD COLUMN IF NOT EXISTS "):
+				defined[strings.Fields(line)[5]] = true
+			}
+		}
+	}
+
+	columns := strings.Split(organisationColumns, ",")
+	args, _ := organisationArgs(&organisation.Entity{})
+	if len(columns) != len(args) {
+		t.Fatalf("Expected %d column values, got %d", len(columns), len(args))
+	}
+	for _, column := range columns {
+		if column = strings.TrimSpace(column); !defined[column] {
+			t.Errorf("Column %s is not created by the migrations", column)
+		}
+	}
+	if len(defined) != len(columns) {
+		t.Errorf("Migration defines %d columns, adapter maps %d", len(defined), len(columns))
+	}
+}
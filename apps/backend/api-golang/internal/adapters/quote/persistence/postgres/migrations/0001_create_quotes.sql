@@ -0,0 +1,15 @@
+-- The quotes table as created before migrations were embedded. Existing
+-- databases already have it, so this only creates it for new ones.
+CREATE TABLE IF NOT EXISTS quotes (
+    id                    TEXT PRIMARY KEY,
+    quote_reference       TEXT NOT NULL,
+    calculation_reference TEXT NOT NULL,
+    organisation_id       TEXT NOT NULL,
+    customer_id           TEXT NOT NULL,
+    currency              CHAR(3) NOT NULL,
+    carbon_credit_total   NUMERIC NOT NULL,
+    status                TEXT NOT NULL,
+    expires_at            TIMESTAMPTZ NOT NULL,
+    updated_at            TIMESTAMPTZ NOT NULL,
+    created_at            TIMESTAMPTZ NOT NULL
+);
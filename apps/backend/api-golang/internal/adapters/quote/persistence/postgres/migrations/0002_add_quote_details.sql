@@ -0,0 +1,30 @@
+-- The remaining quote.Entity fields, with amounts in the quote currency.
+-- Customer address, contribution details and order items are JSON blobs as
+-- stored on quote.Entity. Rows written before these columns existed get
+-- empty or zero values.
+ALTER TABLE quotes
+    ADD COLUMN IF NOT EXISTS customer_address                    JSONB NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS carbon_credit_impact                NUMERIC NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS carbon_credit_impact_sales_tax      NUMERIC NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS impact_tax_rate                     NUMERIC NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS carbon_credit_service_fee           NUMERIC NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS carbon_credit_service_fee_sales_tax NUMERIC NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS service_fee_tax_rate                NUMERIC NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS price_per_tonne_co2e                NUMERIC NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS payment_service_provider_id         TEXT,
+    ADD COLUMN IF NOT EXISTS carbon_credit_processor_fee         NUMERIC,
+    ADD COLUMN IF NOT EXISTS contribution_details                JSONB NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS is_merchant_tax_liable              BOOLEAN NOT NULL DEFAULT false,
+    ADD COLUMN IF NOT EXISTS tax_exemption_certificate_id        TEXT,
+    ADD COLUMN IF NOT EXISTS customer_location_filter            BOOLEAN NOT NULL DEFAULT false,
+    ADD COLUMN IF NOT EXISTS include_partner_detail              BOOLEAN NOT NULL DEFAULT false,
+    ADD COLUMN IF NOT EXISTS include_project_detail              BOOLEAN NOT NULL DEFAULT false,
+    ADD COLUMN IF NOT EXISTS ekko_product                        TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS service_fee_share                   NUMERIC NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS order_items                         JSONB;
+
+-- Newest-first listings of an organisation's quotes, paged on (created_at, id)
+CREATE INDEX IF NOT EXISTS quotes_organisation_created_idx ON quotes (organisation_id, created_at DESC, id DESC);
+
+-- Customer histories for impact summaries and data requests
+CREATE INDEX IF NOT EXISTS quotes_customer_created_idx ON quotes (customer_id, created_at, id);
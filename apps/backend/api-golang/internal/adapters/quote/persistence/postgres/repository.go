@@ -3,6 +3,9 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
 
 	"api-golang/internal/quote"
 	"api-golang/internal/shared/errors"
@@ -11,10 +14,16 @@ import (
 // PostgresRepository implements quote.Repository interface
 // This is a CONCRETE EXAMPLE of a driven adapter (outbound).
 // This adapter replaces InMemoryRepository when using PostgreSQL.
+//
+// The quotes table is created by the embedded migrations; run Migrate before
+// use. Contribution details and order items use their own driver.Valuer and
+// sql.Scanner implementations; the customer address is encoded here.
 type PostgresRepository struct {
 	db *sql.DB
 }
 
+var _ quote.Repository = (*PostgresRepository)(nil)
+
 // NewPostgresRepository creates a new PostgreSQL repository adapter
 func NewPostgresRepository(db *sql.DB) *PostgresRepository {
 	return &PostgresRepository{
@@ -22,90 +31,285 @@ func NewPostgresRepository(db *sql.DB) *PostgresRepository {
 	}
 }
 
+// quoteColumns lists every quote column. created_at comes last so that
+// Update can leave it out.
+const quoteColumns = `
+	id, quote_reference, calculation_reference, organisation_id, customer_id,
+	customer_address, currency, carbon_credit_total, carbon_credit_impact,
+	carbon_credit_impact_sales_tax, impact_tax_rate, carbon_credit_service_fee,
+	carbon_credit_service_fee_sales_tax, service_fee_tax_rate, price_per_tonne_co2e,
+	payment_service_provider_id, carbon_credit_processor_fee, contribution_details,
+	is_merchant_tax_liable, tax_exemption_certificate_id, customer_location_filter,
+	include_partner_detail, include_project_detail, ekko_product, service_fee_share,
+	order_items, status, expires_at, updated_at, created_at`
+
 // Create stores a new quote in PostgreSQL
-func (r *PostgresRepository) Create(ctx context.Context, quote *quote.Entity) error {
+func (r *PostgresRepository) Create(ctx context.Context, q *quote.Entity) error {
 	query := `
-		INSERT INTO quotes (
-			id, quote_reference, calculation_reference, organisation_id, 
-			customer_id, currency, carbon_credit_total, status, 
-			expires_at, created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		INSERT INTO quotes (` + quoteColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15,
+		        $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29, $30)
 	`
 
-	_, err := r.db.ExecContext(ctx, query,
-		quote.ID,
-		quote.QuoteReference,
-		quote.CalculationReference,
-		quote.OrganisationID,
-		quote.CustomerID,
-		quote.Currency,
-		quote.CarbonCreditTotal,
-		quote.Status,
-		quote.ExpiresAt,
-		quote.CreatedAt,
-		quote.UpdatedAt,
-	)
-
+	args, err := quoteArgs(q)
+	if err != nil {
+		return err
+	}
+	_, err = r.db.ExecContext(ctx, query, args...)
 	return err
 }
 
 // GetByID retrieves a quote by ID from PostgreSQL
 func (r *PostgresRepository) GetByID(ctx context.Context, id string) (*quote.Entity, error) {
+	query := `SELECT ` + quoteColumns + ` FROM quotes WHERE id = $1`
+
+	q, err := scanQuote(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, errors.NewNotFoundError("quote", "quote not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// Update updates an existing quote in PostgreSQL
+func (r *PostgresRepository) Update(ctx context.Context, q *quote.Entity) error {
 	query := `
-		SELECT id, quote_reference, calculation_reference, organisation_id,
-		       customer_id, currency, carbon_credit_total, status,
-		       expires_at, created_at, updated_at
-		FROM quotes
+		UPDATE quotes
+		SET quote_reference = $2, calculation_reference = $3, organisation_id = $4,
+		    customer_id = $5, customer_address = $6, currency = $7,
+		    carbon_credit_total = $8, carbon_credit_impact = $9,
+		    carbon_credit_impact_sales_tax = $10, impact_tax_rate = $11,
+		    carbon_credit_service_fee = $12, carbon_credit_service_fee_sales_tax = $13,
+		    service_fee_tax_rate = $14, price_per_tonne_co2e = $15,
+		    payment_service_provider_id = $16, carbon_credit_processor_fee = $17,
+		    contribution_details = $18, is_merchant_tax_liable = $19,
+		    tax_exemption_certificate_id = $20, customer_location_filter = $21,
+		    include_partner_detail = $22, include_project_detail = $23,
+		    ekko_product = $24, service_fee_share = $25, order_items = $26,
+		    status = $27, expires_at = $28, updated_at = $29
 		WHERE id = $1
 	`
 
+	args, err := quoteArgs(q)
+	if err != nil {
+		return err
+	}
+	// created_at is set once on insert
+	result, err := r.db.ExecContext(ctx, query, args[:len(args)-1]...)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return errors.NewNotFoundError("quote", "quote not found")
+	}
+	return nil
+}
+
+// ListByCustomer retrieves a customer's quotes from PostgreSQL, oldest first
+func (r *PostgresRepository) ListByCustomer(ctx context.Context, filter quote.CustomerFilter) ([]*quote.Entity, error) {
+	query := `SELECT ` + quoteColumns + ` FROM quotes WHERE customer_id = $1`
+	args := []interface{}{filter.CustomerID}
+	if filter.OrganisationID != "" {
+		args = append(args, filter.OrganisationID)
+		query += fmt.Sprintf(" AND organisation_id = $%d", len(args))
+	}
+	if len(filter.Statuses) > 0 {
+		placeholders := make([]string, len(filter.Statuses))
+		for i, status := range filter.Statuses {
+			args = append(args, status)
+			placeholders[i] = fmt.Sprintf("$%d", len(args))
+		}
+		query += " AND status IN (" + strings.Join(placeholders, ", ") + ")"
+	}
+	if !filter.From.IsZero() {
+		args = append(args, filter.From)
+		query += fmt.Sprintf(" AND created_at >= $%d", len(args))
+	}
+	if !filter.To.IsZero() {
+		args = append(args, filter.To)
+		query += fmt.Sprintf(" AND created_at < $%d", len(args))
+	}
+	query += " ORDER BY created_at, id"
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return scanQuotes(rows)
+}
+
+// List retrieves quotes matching the filter from PostgreSQL, newest first.
+// Pages continue from the cursor with a keyset condition on (created_at, id),
+// so deep pages cost the same as the first.
+func (r *PostgresRepository) List(ctx context.Context, filter quote.ListFilter) ([]*quote.Entity, error) {
+	query := `SELECT ` + quoteColumns + ` FROM quotes WHERE TRUE`
+	var args []interface{}
+	in := func(column string, values []string) {
+		placeholders := make([]string, len(values))
+		for i, value := range values {
+			args = append(args, value)
+			placeholders[i] = fmt.Sprintf("$%d", len(args))
+		}
+		query += " AND " + column + " IN (" + strings.Join(placeholders, ", ") + ")"
+	}
+	where := func(condition string, value interface{}) {
+		args = append(args, value)
+		query += " AND " + fmt.Sprintf(condition, len(args))
+	}
+
+	in("organisation_id", filter.OrganisationIDs)
+	if filter.CustomerID != "" {
+		where("customer_id = $%d", filter.CustomerID)
+	}
+	if len(filter.Statuses) > 0 {
+		statuses := make([]string, len(filter.Statuses))
+		for i, status := range filter.Statuses {
+			statuses[i] = string(status)
+		}
+		in("status", statuses)
+	}
+	if filter.Currency != "" {
+		where("currency = $%d", filter.Currency)
+	}
+	if filter.Product != "" {
+		where("ekko_product = $%d", filter.Product)
+	}
+	if !filter.From.IsZero() {
+		where("created_at >= $%d", filter.From)
+	}
+	if !filter.To.IsZero() {
+		where("created_at < $%d", filter.To)
+	}
+	if filter.After != nil {
+		args = append(args, filter.After.CreatedAt, filter.After.ID)
+		query += fmt.Sprintf(" AND (created_at, id) < ($%d, $%d)", len(args)-1, len(args))
+	}
+	query += " ORDER BY created_at DESC, id DESC"
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return scanQuotes(rows)
+}
+
+// quoteArgs returns the column values of a quote in quoteColumns order
+func quoteArgs(q *quote.Entity) ([]interface{}, error) {
+	customerAddress, err := json.Marshal(q.CustomerAddress)
+	if err != nil {
+		return nil, fmt.Errorf("encoding customer address: %w", err)
+	}
+
+	return []interface{}{
+		q.ID,
+		q.QuoteReference,
+		q.CalculationReference,
+		q.OrganisationID,
+		q.CustomerID,
+		customerAddress,
+		q.Currency,
+		q.CarbonCreditTotal,
+		q.CarbonCreditImpact,
+		q.CarbonCreditImpactSalesTax,
+		q.ImpactTaxRate,
+		q.CarbonCreditServiceFee,
+		q.CarbonCreditServiceFeeSalesTax,
+		q.ServiceFeeTaxRate,
+		q.PricePerTonneCo2e,
+		nullString(q.PaymentServiceProviderID),
+		q.CarbonCreditProcessorFee,
+		q.ContributionDetails,
+		q.IsMerchantTaxLiable,
+		nullString(q.TaxExemptionCertificateID),
+		q.CustomerLocationFilter,
+		q.IncludePartnerDetail,
+		q.IncludeProjectDetail,
+		q.EkkoProduct,
+		q.ServiceFeeShare,
+		q.OrderItems,
+		string(q.Status),
+		q.ExpiresAt,
+		q.UpdatedAt,
+		q.CreatedAt,
+	}, nil
+}
+
+// rowScanner is satisfied by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanQuote scans a row selected with quoteColumns
+func scanQuote(row rowScanner) (*quote.Entity, error) {
 	var q quote.Entity
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
+	var customerAddress []byte
+	var paymentServiceProviderID, taxExemptionCertificateID sql.NullString
+	err := row.Scan(
 		&q.ID,
 		&q.QuoteReference,
 		&q.CalculationReference,
 		&q.OrganisationID,
 		&q.CustomerID,
+		&customerAddress,
 		&q.Currency,
 		&q.CarbonCreditTotal,
+		&q.CarbonCreditImpact,
+		&q.CarbonCreditImpactSalesTax,
+		&q.ImpactTaxRate,
+		&q.CarbonCreditServiceFee,
+		&q.CarbonCreditServiceFeeSalesTax,
+		&q.ServiceFeeTaxRate,
+		&q.PricePerTonneCo2e,
+		&paymentServiceProviderID,
+		&q.CarbonCreditProcessorFee,
+		&q.ContributionDetails,
+		&q.IsMerchantTaxLiable,
+		&taxExemptionCertificateID,
+		&q.CustomerLocationFilter,
+		&q.IncludePartnerDetail,
+		&q.IncludeProjectDetail,
+		&q.EkkoProduct,
+		&q.ServiceFeeShare,
+		&q.OrderItems,
 		&q.Status,
 		&q.ExpiresAt,
-		&q.CreatedAt,
 		&q.UpdatedAt,
+		&q.CreatedAt,
 	)
-
-	if err == sql.ErrNoRows {
-		return nil, errors.NewNotFoundError("quote", "quote not found")
-	}
 	if err != nil {
 		return nil, err
 	}
 
+	if err := json.Unmarshal(customerAddress, &q.CustomerAddress); err != nil {
+		return nil, fmt.Errorf("decoding customer address of %s: %w", q.ID, err)
+	}
+	q.PaymentServiceProviderID = paymentServiceProviderID.String
+	q.TaxExemptionCertificateID = taxExemptionCertificateID.String
 	return &q, nil
 }
 
-// Update updates an existing quote in PostgreSQL
-func (r *PostgresRepository) Update(ctx context.Context, quote *quote.Entity) error {
-	query := `
-		UPDATE quotes
-		SET quote_reference = $2, calculation_reference = $3, organisation_id = $4,
-		    customer_id = $5, currency = $6, carbon_credit_total = $7, status = $8,
-		    expires_at = $9, updated_at = $10
-		WHERE id = $1
-	`
+// scanQuotes scans and closes a result set selected with quoteColumns
+func scanQuotes(rows *sql.Rows) ([]*quote.Entity, error) {
+	defer rows.Close()
 
-	_, err := r.db.ExecContext(ctx, query,
-		quote.ID,
-		quote.QuoteReference,
-		quote.CalculationReference,
-		quote.OrganisationID,
-		quote.CustomerID,
-		quote.Currency,
-		quote.CarbonCreditTotal,
-		quote.Status,
-		quote.ExpiresAt,
-		quote.UpdatedAt,
-	)
+	quotes := make([]*quote.Entity, 0)
+	for rows.Next() {
+		q, err := scanQuote(rows)
+		if err != nil {
+			return nil, err
+		}
+		quotes = append(quotes, q)
+	}
+	return quotes, rows.Err()
+}
 
-	return err
+// nullString stores unset optional identifiers as NULL
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
 }
@@ -0,0 +1,205 @@
+package postgres
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io/fs"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"api-golang/internal/organisation/customer"
+	"api-golang/internal/quote"
+	"api-golang/internal/shared/types"
+)
+
+// fakeRow scans stored column values the way database/sql does for the types
+// the adapter uses: Valuers are stored by value and Scanners read them back
+type fakeRow []interface{}
+
+func (r fakeRow) Scan(dest ...interface{}) error {
+	if len(dest) != len(r) {
+		return fmt.Errorf("expected %d destinations, got %d", len(r), len(dest))
+	}
+	for i, d := range dest {
+		value := r[i]
+		if valuer, ok := value.(driver.Valuer); ok {
+			v, err := valuer.Value()
+			if err != nil {
+				return err
+			}
+			value = v
+		}
+		if scanner, ok := d.(sql.Scanner); ok {
+			if err := scanner.Scan(value); err != nil {
+				return err
+			}
+			continue
+		}
+
+		target := reflect.ValueOf(d).Elem()
+		source := reflect.ValueOf(value)
+		switch {
+		case value == nil:
+			target.Set(reflect.Zero(target.Type()))
+		case source.Type().AssignableTo(target.Type()):
+			target.Set(source)
+		case source.Type().ConvertibleTo(target.Type()):
+			target.Set(source.Convert(target.Type()))
+		default:
+			return fmt.Errorf("column %d: cannot scan %T into %T", i, value, d)
+		}
+	}
+	return nil
+}
+
+func float64Ptr(f float64) *float64 {
+	return &f
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func TestQuoteRoundTrip(t *testing.T) {
+	createdAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	full := &quote.Entity{
+		ID:                   "quote-1",
+		QuoteReference:       "ref-1",
+		CalculationReference: "calc-1",
+		OrganisationID:       "org-1",
+		CustomerID:           "cust-1",
+		CustomerAddress: customer.QuoteCustomer{
+			Reference:   "customer-ref",
+			PostalCode:  stringPtr("M1 1AD"),
+			City:        stringPtr("Manchester"),
+			CountryCode: "GBR",
+		},
+		Currency:                       "GBP",
+		CarbonCreditTotal:              1.44,
+		CarbonCreditImpact:             1,
+		CarbonCreditImpactSalesTax:     0.2,
+		ImpactTaxRate:                  0.2,
+		CarbonCreditServiceFee:         0.2,
+		CarbonCreditServiceFeeSalesTax: 0.04,
+		ServiceFeeTaxRate:              0.2,
+		PricePerTonneCo2e:              20,
+		PaymentServiceProviderID:       "psp-1",
+		CarbonCreditProcessorFee:       float64Ptr(0.03),
+		ContributionDetails: quote.ContributionDetails{
+			ImpactPercentage:     69.44,
+			ServiceFeePercentage: 13.89,
+			ImpactPartners: []quote.ContributionImpactPartner{
+				{ID: "partner-1", ImpactPercentage: 69.44, ProjectIDs: []string{"project-1"}},
+			},
+		},
+		IsMerchantTaxLiable:       true,
+		TaxExemptionCertificateID: "cert-1",
+		CustomerLocationFilter:    true,
+		IncludePartnerDetail:      true,
+		IncludeProjectDetail:      true,
+		EkkoProduct:               "checkout-sdk",
+		ServiceFeeShare:           20,
+		OrderItems: quote.OrderItems{
+			{ItemID: "item-1", SKU: "sku-1", Name: "Coffee", Category: "food", Quantity: 2,
+				UnitPrice: types.Money{Amount: 3.5, Currency: "GBP"}},
+		},
+		Status:    quote.StatusAccepted,
+		ExpiresAt: createdAt.Add(15 * time.Minute),
+		CreatedAt: createdAt,
+		UpdatedAt: createdAt.Add(time.Minute),
+	}
+	minimal := &quote.Entity{
+		ID:                  "quote-2",
+		OrganisationID:      "org-1",
+		CustomerID:          "cust-2",
+		CustomerAddress:     customer.QuoteCustomer{CountryCode: "FRA"},
+		Currency:            "EUR",
+		ContributionDetails: quote.ContributionDetails{ImpactPartners: []quote.ContributionImpactPartner{}},
+		EkkoProduct:         "API",
+		Status:              quote.StatusPending,
+		ExpiresAt:           createdAt,
+		CreatedAt:           createdAt,
+		UpdatedAt:           createdAt,
+	}
+
+	for _, tt := range []struct {
+		name  string
+		quote *quote.Entity
+	}{
+		{name: "All fields set", quote: full},
+		{name: "Optional fields unset", quote: minimal},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			args, err := quoteArgs(tt.quote)
+			if err != nil {
+				t.Fatalf("quoteArgs failed: %v", err)
+			}
+			got, err := scanQuote(fakeRow(args))
+			if err != nil {
+				t.Fatalf("scanQuote failed: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.quote) {
+				t.Errorf("Round trip changed the quote:\n got %+v\nwant %+v", got, tt.quote)
+			}
+		})
+	}
+
+	// Unset optional identifiers are stored as NULL
+	args, _ := quoteArgs(minimal)
+	for _, i := range []int{15, 16, 19, 25} {
+		if v, ok := args[i].(driver.Valuer); ok {
+			if value, _ := v.Value(); value != nil {
+				t.Errorf("Expected NULL for column %d, got %v", i, value)
+			}
+		} else if !reflect.ValueOf(args[i]).IsNil() {
+			t.Errorf("Expected NULL for column %d, got %v", i, args[i])
+		}
+	}
+}
+
+func TestMigrationsCoverQuoteColumns(t *testing.T) {
+	names, err := fs.Glob(migrations, "migrations/*.sql")
+	if err != nil {
+		t.Fatalf("Listing migrations failed: %v", err)
+	}
+
+	// Columns come from the CREATE TABLE body and from ADD COLUMN clauses
+	defined := map[string]bool{}
+	for _, name := range names {
+		script, err := migrations.ReadFile(name)
+		if err != nil {
+			t.Fatalf("Reading migration failed: %v", err)
+		}
+		inTable := false
+		for _, line := range strings.Split(string(script), "\n") {
+			line = strings.TrimSpace(line)
+			switch {
+			case strings.HasPrefix(line, "CREATE TABLE IF NOT EXISTS quotes"):
+				inTable = true
+			case strings.HasPrefix(line, ");"):
+				inTable = false
+			case inTable && line != "":
+				defined[strings.Fields(line)[0]] = true
+			case strings.HasPrefix(line, "ADD COLUMN IF NOT EXISTS "):
+				defined[strings.Fields(line)[5]] = true
+			}
+		}
+	}
+
+	columns := strings.Split(quoteColumns, ",")
+	args, _ := quoteArgs(&quote.Entity{})
+	if len(columns) != len(args) {
+		t.Fatalf("Expected %d column values, got %d", len(columns), len(args))
+	}
+	for _, column := range columns {
+		if column = strings.TrimSpace(column); !defined[column] {
+			t.Errorf("Column %s is not created by the migrations", column)
+		}
+	}
+	if len(defined) != len(columns) {
+		t.Errorf("Migration defines %d columns, adapter maps %d", len(defined), len(columns))
+	}
+}
@@ -0,0 +1,42 @@
+package holder
+
+import (
+	"context"
+
+	"api-golang/internal/funds/salestax"
+	"api-golang/internal/organisation/customer"
+	"api-golang/internal/organisation/organisation"
+)
+
+// AccessAdapter implements salestax.HolderAccess interface
+// It answers the sales tax domain's questions about exemption holders from the
+// organisation and customer domains, so sales tax does not depend on their entities.
+type AccessAdapter struct {
+	organisations organisation.Service
+	customers     customer.Service
+}
+
+var _ salestax.HolderAccess = (*AccessAdapter)(nil)
+
+// NewAccessAdapter creates a new exemption holder access adapter
+func NewAccessAdapter(organisations organisation.Service, customers customer.Service) *AccessAdapter {
+	return &AccessAdapter{
+		organisations: organisations,
+		customers:     customers,
+	}
+}
+
+// ValidateOrganisation implements salestax.HolderAccess interface
+func (a *AccessAdapter) ValidateOrganisation(ctx context.Context, callerOrgID, orgID string) error {
+	_, err := a.organisations.ValidateOrganisation(ctx, callerOrgID, orgID)
+	return err
+}
+
+// GetCustomerOrganisationID implements salestax.HolderAccess interface
+func (a *AccessAdapter) GetCustomerOrganisationID(ctx context.Context, customerID string) (string, error) {
+	cust, err := a.customers.GetCustomer(ctx, customerID)
+	if err != nil {
+		return "", err
+	}
+	return cust.OrganisationID, nil
+}
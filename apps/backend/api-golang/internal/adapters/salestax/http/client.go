@@ -0,0 +1,233 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"api-golang/internal/funds/salestax"
+)
+
+const (
+	defaultTimeout   = 5 * time.Second
+	defaultCacheTTL  = 1 * time.Hour
+	defaultCacheSize = 10000
+)
+
+// HTTPClientAdapter implements salestax.Repository interface
+// This adapter calls an external tax-calculation API (e.g. Avalara) to
+// resolve the rate for a jurisdiction.
+//
+// When the provider cannot be reached or fails with a 5xx response the lookup
+// falls back to the local tax table, so a quote can always be priced. A 4xx
+// response is returned as an error, since the local table cannot fix a bad
+// request. Successful provider responses are cached by the returned
+// TaxJurisdictionID, so locations in the same jurisdiction share one rate.
+type HTTPClientAdapter struct {
+	baseURL    string
+	httpClient *http.Client
+	fallback   salestax.Repository
+
+	cacheTTL  time.Duration
+	cacheSize int
+	locations map[string]string      // key: countryCode:state:postalCode, value: TaxJurisdictionID
+	order     []string               // location keys, oldest first
+	rates     map[string]*cachedRate // key: TaxJurisdictionID
+	mu        sync.RWMutex
+	now       func() time.Time
+}
+
+type cachedRate struct {
+	rate      salestax.TaxRate
+	expiresAt time.Time
+	locations int // number of location keys pointing at this rate
+}
+
+// statusError is returned when the provider answers with a non-200 status
+type statusError struct {
+	status int
+	body   string
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("unexpected status %d: %s", e.status, e.body)
+}
+
+// Option configures an HTTPClientAdapter
+type Option func(*HTTPClientAdapter)
+
+// WithTimeout sets the timeout for calls to the tax provider
+func WithTimeout(timeout time.Duration) Option {
+	return func(a *HTTPClientAdapter) {
+		a.httpClient.Timeout = timeout
+	}
+}
+
+// WithCacheTTL sets how long provider rates are cached per jurisdiction
+func WithCacheTTL(ttl time.Duration) Option {
+	return func(a *HTTPClientAdapter) {
+		a.cacheTTL = ttl
+	}
+}
+
+// WithCacheSize sets how many locations are cached; the oldest is evicted first
+func WithCacheSize(size int) Option {
+	return func(a *HTTPClientAdapter) {
+		a.cacheSize = size
+	}
+}
+
+// NewHTTPClientAdapter creates a new tax provider adapter.
+// fallback is used whenever the provider cannot be reached or fails with a 5xx response.
+func NewHTTPClientAdapter(baseURL string, fallback salestax.Repository, opts ...Option) *HTTPClientAdapter {
+	a := &HTTPClientAdapter{
+		baseURL: baseURL,
+		httpClient: &http.Client{
+			Timeout: defaultTimeout,
+		},
+		fallback:  fallback,
+		cacheTTL:  defaultCacheTTL,
+		cacheSize: defaultCacheSize,
+		locations: make(map[string]string),
+		rates:     make(map[string]*cachedRate),
+		now:       time.Now,
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
+}
+
+// GetTaxRate implements salestax.Repository interface
+// Makes HTTP GET request to /api/tax-rates?country={country}&state={state}&postalCode={postalCode}
+func (a *HTTPClientAdapter) GetTaxRate(ctx context.Context, countryCode, state, postalCode string) (*salestax.TaxRate, error) {
+	key := countryCode + ":" + state + ":" + postalCode
+	if rate, ok := a.cached(key); ok {
+		return rate, nil
+	}
+
+	rate, err := a.fetchTaxRate(ctx, countryCode, state, postalCode)
+	if err != nil {
+		var statusErr *statusError
+		if a.fallback == nil || (errors.As(err, &statusErr) && statusErr.status < http.StatusInternalServerError) {
+			return nil, err
+		}
+		// Provider unavailable - use the local tax table (not cached, so the
+		// provider is retried on the next lookup)
+		return a.fallback.GetTaxRate(ctx, countryCode, state, postalCode)
+	}
+
+	if rate.TaxJurisdictionID != "" {
+		a.store(key, rate)
+	}
+	return rate, nil
+}
+
+// cached returns a copy of the cached rate for a location, if it has not expired
+func (a *HTTPClientAdapter) cached(key string) (*salestax.TaxRate, bool) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	jurisdictionID, exists := a.locations[key]
+	if !exists {
+		return nil, false
+	}
+	entry, exists := a.rates[jurisdictionID]
+	if !exists || !a.now().Before(entry.expiresAt) {
+		return nil, false
+	}
+	return copyRate(&entry.rate), true
+}
+
+// store caches a provider rate under its jurisdiction and maps the location
+// to it, evicting the oldest locations once the cache is full
+func (a *HTTPClientAdapter) store(key string, rate *salestax.TaxRate) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if previous, exists := a.locations[key]; exists {
+		a.release(previous)
+	} else {
+		a.order = append(a.order, key)
+	}
+
+	entry, exists := a.rates[rate.TaxJurisdictionID]
+	if !exists {
+		entry = &cachedRate{}
+		a.rates[rate.TaxJurisdictionID] = entry
+	}
+	entry.rate = *copyRate(rate)
+	entry.expiresAt = a.now().Add(a.cacheTTL)
+	entry.locations++
+	a.locations[key] = rate.TaxJurisdictionID
+
+	for len(a.order) > a.cacheSize {
+		oldest := a.order[0]
+		a.order = a.order[1:]
+		a.release(a.locations[oldest])
+		delete(a.locations, oldest)
+	}
+}
+
+// release drops one location reference to a jurisdiction rate, removing the
+// rate once nothing points at it. Callers must hold mu.
+func (a *HTTPClientAdapter) release(jurisdictionID string) {
+	entry, exists := a.rates[jurisdictionID]
+	if !exists {
+		return
+	}
+	entry.locations--
+	if entry.locations <= 0 {
+		delete(a.rates, jurisdictionID)
+	}
+}
+
+// copyRate returns a copy of rate that shares no pointers with it
+func copyRate(rate *salestax.TaxRate) *salestax.TaxRate {
+	c := *rate
+	if rate.Components != nil {
+		components := *rate.Components
+		c.Components = &components
+	}
+	return &c
+}
+
+func (a *HTTPClientAdapter) fetchTaxRate(ctx context.Context, countryCode, state, postalCode string) (*salestax.TaxRate, error) {
+	query := url.Values{}
+	query.Set("country", countryCode)
+	if state != "" {
+		query.Set("state", state)
+	}
+	if postalCode != "" {
+		query.Set("postalCode", postalCode)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", a.baseURL+"/api/tax-rates?"+query.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, &statusError{status: resp.StatusCode, body: string(body)}
+	}
+
+	var rate salestax.TaxRate
+	if err := json.NewDecoder(resp.Body).Decode(&rate); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &rate, nil
+}
@@ -0,0 +1,187 @@
+package http
+
+import (
+	"context"
+	"testing"
+
+	"api-golang/internal/funds/salestax"
+)
+
+func newTestServer() *FakeServer {
+	return NewFakeServer([]*salestax.TaxRate{
+		{
+			ID:                "provider-ca-90001",
+			MerchantLocation:  salestax.MerchantLocation{Country: "USA", State: "CA"},
+			CustomerLocation:  salestax.CustomerLocation{Country: "USA", State: "CA", Zip: "90001"},
+			CarbonCreditRate:  0.1025,
+			TaxJurisdictionID: "CA-LA-90001",
+			Components:        &salestax.TaxRateComponents{State: 0.06, County: 0.0025, SpecialDistrict: 0.04},
+		},
+		{
+			ID:                "provider-tx",
+			MerchantLocation:  salestax.MerchantLocation{Country: "USA", State: "TX"},
+			CustomerLocation:  salestax.CustomerLocation{Country: "USA", State: "TX"},
+			CarbonCreditRate:  0.0625,
+			TaxJurisdictionID: "TX",
+		},
+	})
+}
+
+func TestGetTaxRate_FromProvider(t *testing.T) {
+	server := newTestServer()
+	defer server.Close()
+
+	adapter := NewHTTPClientAdapter(server.URL(), salestax.NewInMemoryRepository())
+
+	rate, err := adapter.GetTaxRate(context.Background(), "USA", "CA", "90001")
+	if err != nil {
+		t.Fatalf("GetTaxRate failed: %v", err)
+	}
+	if rate.TaxJurisdictionID != "CA-LA-90001" {
+		t.Errorf("Expected provider jurisdiction CA-LA-90001, got %q", rate.TaxJurisdictionID)
+	}
+	if rate.CarbonCreditRate != 0.1025 {
+		t.Errorf("Expected provider rate 0.1025, got %f", rate.CarbonCreditRate)
+	}
+}
+
+func TestGetTaxRate_CachesPerJurisdiction(t *testing.T) {
+	server := newTestServer()
+	defer server.Close()
+
+	adapter := NewHTTPClientAdapter(server.URL(), salestax.NewInMemoryRepository())
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		if _, err := adapter.GetTaxRate(ctx, "USA", "CA", "90001"); err != nil {
+			t.Fatalf("GetTaxRate failed: %v", err)
+		}
+	}
+	if server.Requests() != 1 {
+		t.Errorf("Expected 1 provider request, got %d", server.Requests())
+	}
+
+	// Still served from cache while the provider is down
+	server.SetUnavailable(true)
+	rate, err := adapter.GetTaxRate(ctx, "USA", "CA", "90001")
+	if err != nil {
+		t.Fatalf("GetTaxRate failed: %v", err)
+	}
+	if rate.TaxJurisdictionID != "CA-LA-90001" {
+		t.Errorf("Expected cached provider rate, got %q", rate.TaxJurisdictionID)
+	}
+}
+
+func TestGetTaxRate_SharesRatePerJurisdiction(t *testing.T) {
+	server := newTestServer()
+	defer server.Close()
+
+	adapter := NewHTTPClientAdapter(server.URL(), salestax.NewInMemoryRepository())
+	ctx := context.Background()
+
+	for _, postalCode := range []string{"73301", "75001", "77001"} {
+		if _, err := adapter.GetTaxRate(ctx, "USA", "TX", postalCode); err != nil {
+			t.Fatalf("GetTaxRate failed: %v", err)
+		}
+	}
+	if len(adapter.rates) != 1 {
+		t.Errorf("Expected 1 cached jurisdiction rate, got %d", len(adapter.rates))
+	}
+	if len(adapter.locations) != 3 {
+		t.Errorf("Expected 3 cached locations, got %d", len(adapter.locations))
+	}
+}
+
+func TestGetTaxRate_EvictsOldestLocation(t *testing.T) {
+	server := newTestServer()
+	defer server.Close()
+
+	adapter := NewHTTPClientAdapter(server.URL(), salestax.NewInMemoryRepository(), WithCacheSize(2))
+	ctx := context.Background()
+
+	for _, postalCode := range []string{"73301", "75001", "77001"} {
+		if _, err := adapter.GetTaxRate(ctx, "USA", "TX", postalCode); err != nil {
+			t.Fatalf("GetTaxRate failed: %v", err)
+		}
+	}
+	if len(adapter.locations) != 2 {
+		t.Errorf("Expected 2 cached locations, got %d", len(adapter.locations))
+	}
+	if _, exists := adapter.locations["USA:TX:73301"]; exists {
+		t.Error("Expected the oldest location to be evicted")
+	}
+
+	// The evicted location is fetched again
+	if _, err := adapter.GetTaxRate(ctx, "USA", "TX", "73301"); err != nil {
+		t.Fatalf("GetTaxRate failed: %v", err)
+	}
+	if server.Requests() != 4 {
+		t.Errorf("Expected 4 provider requests, got %d", server.Requests())
+	}
+}
+
+func TestGetTaxRate_ReturnsCopies(t *testing.T) {
+	server := newTestServer()
+	defer server.Close()
+
+	adapter := NewHTTPClientAdapter(server.URL(), salestax.NewInMemoryRepository())
+	ctx := context.Background()
+
+	rate, err := adapter.GetTaxRate(ctx, "USA", "CA", "90001")
+	if err != nil {
+		t.Fatalf("GetTaxRate failed: %v", err)
+	}
+	rate.CarbonCreditRate = 0
+	rate.Components.State = 0
+
+	cached, err := adapter.GetTaxRate(ctx, "USA", "CA", "90001")
+	if err != nil {
+		t.Fatalf("GetTaxRate failed: %v", err)
+	}
+	if cached.CarbonCreditRate != 0.1025 {
+		t.Errorf("Expected cached rate 0.1025, got %f", cached.CarbonCreditRate)
+	}
+	if cached.Components.State != 0.06 {
+		t.Errorf("Expected cached state component 0.06, got %f", cached.Components.State)
+	}
+}
+
+func TestGetTaxRate_NoFallbackOnClientError(t *testing.T) {
+	server := newTestServer()
+	defer server.Close()
+
+	adapter := NewHTTPClientAdapter(server.URL(), salestax.NewInMemoryRepository())
+
+	// The fake provider has no GBR rate and answers 404
+	if _, err := adapter.GetTaxRate(context.Background(), "GBR", "", ""); err == nil {
+		t.Error("Expected error when the provider rejects the lookup")
+	}
+}
+
+func TestGetTaxRate_FallsBackWhenProviderUnavailable(t *testing.T) {
+	server := newTestServer()
+	defer server.Close()
+	server.SetUnavailable(true)
+
+	adapter := NewHTTPClientAdapter(server.URL(), salestax.NewInMemoryRepository())
+
+	rate, err := adapter.GetTaxRate(context.Background(), "GBR", "", "")
+	if err != nil {
+		t.Fatalf("GetTaxRate failed: %v", err)
+	}
+	if rate.CarbonCreditRate != 0.20 {
+		t.Errorf("Expected local GBR rate 0.20, got %f", rate.CarbonCreditRate)
+	}
+}
+
+func TestGetTaxRate_NoFallback(t *testing.T) {
+	server := newTestServer()
+	defer server.Close()
+	server.SetUnavailable(true)
+
+	adapter := NewHTTPClientAdapter(server.URL(), nil)
+
+	if _, err := adapter.GetTaxRate(context.Background(), "USA", "CA", "90001"); err == nil {
+		t.Error("Expected error when provider is unavailable and no fallback is configured")
+	}
+}
@@ -0,0 +1,94 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	"api-golang/internal/funds/salestax"
+)
+
+// FakeServer is an in-process stand-in for the external tax provider.
+// Integration tests point an HTTPClientAdapter at URL() and can toggle
+// availability to exercise the local table fallback.
+type FakeServer struct {
+	server *httptest.Server
+
+	rates       map[string]*salestax.TaxRate // key: countryCode:state:postalCode
+	unavailable bool
+	requests    int
+	mu          sync.Mutex
+}
+
+// NewFakeServer starts a fake tax provider serving the given rates.
+// Rates are matched by country, state and postal code, then by country and
+// state, then by country only.
+func NewFakeServer(rates []*salestax.TaxRate) *FakeServer {
+	f := &FakeServer{
+		rates: make(map[string]*salestax.TaxRate),
+	}
+	for _, r := range rates {
+		key := r.CustomerLocation.Country + ":" + r.CustomerLocation.State + ":" + r.CustomerLocation.Zip
+		f.rates[key] = r
+	}
+	f.server = httptest.NewServer(http.HandlerFunc(f.handleTaxRate))
+	return f
+}
+
+// URL returns the base URL of the fake provider
+func (f *FakeServer) URL() string {
+	return f.server.URL
+}
+
+// Close shuts down the fake provider
+func (f *FakeServer) Close() {
+	f.server.Close()
+}
+
+// SetUnavailable makes the fake provider respond with 503 Service Unavailable
+func (f *FakeServer) SetUnavailable(unavailable bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.unavailable = unavailable
+}
+
+// Requests returns the number of tax rate requests received
+func (f *FakeServer) Requests() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.requests
+}
+
+// handleTaxRate handles GET /api/tax-rates
+func (f *FakeServer) handleTaxRate(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests++
+
+	if r.URL.Path != "/api/tax-rates" {
+		http.NotFound(w, r)
+		return
+	}
+	if f.unavailable {
+		http.Error(w, "tax provider unavailable", http.StatusServiceUnavailable)
+		return
+	}
+
+	query := r.URL.Query()
+	country, state, postalCode := query.Get("country"), query.Get("state"), query.Get("postalCode")
+
+	for _, key := range []string{
+		country + ":" + state + ":" + postalCode,
+		country + ":" + state + ":",
+		country + "::",
+	} {
+		if rate, exists := f.rates[key]; exists {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(rate)
+			return
+		}
+	}
+
+	http.Error(w, "no tax rate for jurisdiction", http.StatusNotFound)
+}
@@ -0,0 +1,113 @@
+package customerimpact
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"api-golang/internal/shared/auth"
+	"api-golang/internal/shared/errors"
+)
+
+// Controller handles HTTP requests for customer impact
+type Controller struct {
+	orchestrator *Orchestrator
+}
+
+// NewController creates a new customer impact controller
+func NewController(orchestrator *Orchestrator) *Controller {
+	return &Controller{orchestrator: orchestrator}
+}
+
+// HandleCustomerImpact handles GET /api/customers/{id}/impact?from={date}&to={date}
+// Dates are RFC 3339 timestamps or YYYY-MM-DD days; a day in to includes the whole day.
+func (c *Controller) HandleCustomerImpact(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		c.writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed")
+		return
+	}
+
+	id := strings.TrimSuffix(strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/customers/"), "/"), "/impact")
+	if id == "" || strings.Contains(id, "/") {
+		c.writeError(w, http.StatusBadRequest, "MISSING_FIELD", "Customer ID is required")
+		return
+	}
+
+	caller, ok := auth.PrincipalFromContext(r.Context())
+	if !ok {
+		c.writeError(w, http.StatusUnauthorized, errors.ErrCodeUnauthorized, "API key is required")
+		return
+	}
+
+	input := SummaryInput{CustomerID: id}
+	query := r.URL.Query()
+	var err error
+	if input.From, err = parseDate(query.Get("from"), false); err != nil {
+		c.writeFieldError(w, http.StatusBadRequest, "VALIDATION_ERROR", "from", "from must be an RFC 3339 timestamp or a YYYY-MM-DD date")
+		return
+	}
+	if input.To, err = parseDate(query.Get("to"), true); err != nil {
+		c.writeFieldError(w, http.StatusBadRequest, "VALIDATION_ERROR", "to", "to must be an RFC 3339 timestamp or a YYYY-MM-DD date")
+		return
+	}
+
+	summary, err := c.orchestrator.GetSummary(r.Context(), caller, input)
+	if err != nil {
+		var domainErr *errors.DomainError
+		switch {
+		case errors.IsDomainError(err, &domainErr) && domainErr.Code == errors.ErrCodeNotFound:
+			c.writeError(w, http.StatusNotFound, "NOT_FOUND", "Customer not found")
+		case errors.IsDomainError(err, &domainErr) && domainErr.Code == errors.ErrCodeValidation:
+			c.writeFieldError(w, http.StatusBadRequest, "VALIDATION_ERROR", domainErr.Field, domainErr.Message)
+		default:
+			c.writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		}
+		return
+	}
+
+	c.writeJSON(w, http.StatusOK, summary)
+}
+
+// parseDate parses an optional RFC 3339 timestamp or YYYY-MM-DD day. As the
+// end of a range, a day is moved to the start of the next day so that it is
+// included.
+func parseDate(value string, endOfRange bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if day, err := time.Parse(time.DateOnly, value); err == nil {
+		if endOfRange {
+			return day.AddDate(0, 0, 1), nil
+		}
+		return day, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+// ErrorResponse represents an error response
+type ErrorResponse struct {
+	Error struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+		Field   string `json:"field,omitempty"` // Request field that failed validation
+	} `json:"error"`
+}
+
+func (c *Controller) writeError(w http.ResponseWriter, status int, code, message string) {
+	c.writeFieldError(w, status, code, "", message)
+}
+
+func (c *Controller) writeFieldError(w http.ResponseWriter, status int, code, field, message string) {
+	resp := ErrorResponse{}
+	resp.Error.Code = code
+	resp.Error.Message = message
+	resp.Error.Field = field
+	c.writeJSON(w, status, resp)
+}
+
+func (c *Controller) writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
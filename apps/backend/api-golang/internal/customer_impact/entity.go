@@ -0,0 +1,68 @@
+// Package customerimpact reports a customer's impact: the footprints calculated
+// for them and the carbon compensated by the quotes they accepted.
+package customerimpact
+
+import (
+	"time"
+
+	"api-golang/internal/quote"
+)
+
+// CompensatingStatuses are the quote statuses that count towards the carbon a
+// customer has compensated
+var CompensatingStatuses = []quote.Status{quote.StatusAccepted, quote.StatusCompleted}
+
+// SummaryInput selects the customer and period to summarise. Zero times leave
+// that end of the period open.
+type SummaryInput struct {
+	CustomerID string
+	From       time.Time // Inclusive
+	To         time.Time // Exclusive
+}
+
+// Summary is a customer's impact over a period
+type Summary struct {
+	CustomerID        string          `json:"customerId"`
+	OrganisationID    string          `json:"organisationId"`
+	From              *time.Time      `json:"from,omitempty"`
+	To                *time.Time      `json:"to,omitempty"`
+	Footprints        FootprintTotals `json:"footprints"`        // Footprints by transaction date
+	Quotes            QuoteTotals     `json:"quotes"`            // Accepted and completed quotes by creation date
+	TonnesCompensated float64         `json:"tonnesCompensated"` // CO2e compensated by accepted and completed quotes
+	Amounts           []CurrencyTotal `json:"amounts"`           // Accepted and completed quote amounts, per currency
+	History           []HistoryEntry  `json:"history"`           // Accepted and completed quotes, oldest first
+}
+
+// FootprintTotals aggregates a customer's footprints
+type FootprintTotals struct {
+	Count      int     `json:"count"`
+	Co2eGrams  float64 `json:"co2eGrams"`
+	Co2eTonnes float64 `json:"co2eTonnes"`
+}
+
+// QuoteTotals counts a customer's compensating quotes
+type QuoteTotals struct {
+	Accepted  int `json:"accepted"`
+	Completed int `json:"completed"`
+}
+
+// CurrencyTotal sums compensating quote amounts in one currency
+type CurrencyTotal struct {
+	Currency   string  `json:"currency"` // ISO-3 currency code
+	Quotes     int     `json:"quotes"`
+	Total      float64 `json:"total"`      // Including fees and sales tax
+	Impact     float64 `json:"impact"`     // Spent on carbon credits
+	ServiceFee float64 `json:"serviceFee"` // Excluding sales tax
+	SalesTax   float64 `json:"salesTax"`   // On the impact and the service fee
+}
+
+// HistoryEntry is one compensating quote
+type HistoryEntry struct {
+	QuoteID        string       `json:"quoteId"`
+	QuoteReference string       `json:"quoteReference"`
+	Status         quote.Status `json:"status"`
+	Co2eTonnes     float64      `json:"co2eTonnes"`
+	Currency       string       `json:"currency"`
+	Total          float64      `json:"total"`
+	CreatedAt      time.Time    `json:"createdAt"`
+}
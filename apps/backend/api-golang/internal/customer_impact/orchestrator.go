@@ -0,0 +1,173 @@
+package customerimpact
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	carbonfootprint "api-golang/internal/impact/carbon_footprint"
+	"api-golang/internal/organisation/customer"
+	"api-golang/internal/quote"
+	"api-golang/internal/shared/auth"
+	"api-golang/internal/shared/errors"
+)
+
+const domainName = "customer_impact"
+
+// Orchestrator aggregates a customer's footprints and quotes
+type Orchestrator struct {
+	organisations   OrganisationAccess
+	customerService customer.Service
+	carbonService   carbonfootprint.Service
+	quoteService    quote.Service
+}
+
+// OrchestratorDeps contains all dependencies for the orchestrator
+type OrchestratorDeps struct {
+	Organisations   OrganisationAccess
+	CustomerService customer.Service
+	CarbonService   carbonfootprint.Service
+	QuoteService    quote.Service
+}
+
+// NewOrchestrator creates a new customer impact orchestrator
+func NewOrchestrator(deps OrchestratorDeps) *Orchestrator {
+	return &Orchestrator{
+		organisations:   deps.Organisations,
+		customerService: deps.CustomerService,
+		carbonService:   deps.CarbonService,
+		quoteService:    deps.QuoteService,
+	}
+}
+
+// GetSummary aggregates the impact of a customer of the caller's organisation
+// or one of its descendants. Footprints are selected by transaction date and
+// quotes by creation date; the tonnes compensated by a quote are those of the
+// footprint it was calculated from.
+func (o *Orchestrator) GetSummary(ctx context.Context, caller *auth.Principal, input SummaryInput) (*Summary, error) {
+	if !input.From.IsZero() && !input.To.IsZero() && !input.From.Before(input.To) {
+		return nil, errors.NewFieldValidationError(domainName, "to", "to must be after from")
+	}
+
+	cust, err := o.customerService.GetCustomer(ctx, input.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := o.organisations.ValidateOrganisation(ctx, caller.OrganisationID, cust.OrganisationID); err != nil {
+		var domainErr *errors.DomainError
+		if errors.IsDomainError(err, &domainErr) && domainErr.Code == errors.ErrCodeForbidden {
+			return nil, errors.NewNotFoundError(domainName, "customer not found")
+		}
+		return nil, err
+	}
+
+	footprints, err := o.carbonService.ListByCustomer(ctx, carbonfootprint.CustomerFilter{
+		CustomerID:     cust.ID,
+		OrganisationID: cust.OrganisationID,
+		From:           input.From,
+		To:             input.To,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing footprints: %w", err)
+	}
+	quotes, err := o.quoteService.ListByCustomer(ctx, quote.CustomerFilter{
+		CustomerID:     cust.ID,
+		OrganisationID: cust.OrganisationID,
+		Statuses:       CompensatingStatuses,
+		From:           input.From,
+		To:             input.To,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing quotes: %w", err)
+	}
+
+	summary := &Summary{
+		CustomerID:     cust.ID,
+		OrganisationID: cust.OrganisationID,
+		Amounts:        make([]CurrencyTotal, 0),
+		History:        make([]HistoryEntry, 0, len(quotes)),
+	}
+	if !input.From.IsZero() {
+		summary.From = &input.From
+	}
+	if !input.To.IsZero() {
+		summary.To = &input.To
+	}
+
+	footprintsByID := make(map[string]*carbonfootprint.Footprint, len(footprints))
+	for _, footprint := range footprints {
+		footprintsByID[footprint.ID] = footprint
+		summary.Footprints.Count++
+		summary.Footprints.Co2eGrams += footprint.CarbonCo2eGrams
+	}
+	summary.Footprints.Co2eGrams = math.Round(summary.Footprints.Co2eGrams*100) / 100
+	summary.Footprints.Co2eTonnes = roundTonnes(summary.Footprints.Co2eGrams / 1e6)
+
+	amounts := make(map[string]*CurrencyTotal)
+	for _, q := range quotes {
+		// The quote's footprint can fall outside the period when its
+		// transaction date differs from the quote date
+		footprint, ok := footprintsByID[q.CalculationReference]
+		if !ok {
+			footprint, err = o.carbonService.GetByID(ctx, q.CalculationReference)
+			if err != nil {
+				return nil, fmt.Errorf("getting footprint for quote %s: %w", q.ID, err)
+			}
+		}
+		tonnes := footprint.CarbonKg() / 1000.0
+		summary.TonnesCompensated += tonnes
+
+		switch q.Status {
+		case quote.StatusAccepted:
+			summary.Quotes.Accepted++
+		case quote.StatusCompleted:
+			summary.Quotes.Completed++
+		}
+
+		total, ok := amounts[q.Currency]
+		if !ok {
+			total = &CurrencyTotal{Currency: q.Currency}
+			amounts[q.Currency] = total
+		}
+		total.Quotes++
+		total.Total += q.CarbonCreditTotal
+		total.Impact += q.CarbonCreditImpact
+		total.ServiceFee += q.CarbonCreditServiceFee
+		total.SalesTax += q.CarbonCreditImpactSalesTax + q.CarbonCreditServiceFeeSalesTax
+
+		summary.History = append(summary.History, HistoryEntry{
+			QuoteID:        q.ID,
+			QuoteReference: q.QuoteReference,
+			Status:         q.Status,
+			Co2eTonnes:     roundTonnes(tonnes),
+			Currency:       q.Currency,
+			Total:          q.CarbonCreditTotal,
+			CreatedAt:      q.CreatedAt,
+		})
+	}
+	summary.TonnesCompensated = roundTonnes(summary.TonnesCompensated)
+
+	for _, total := range amounts {
+		total.Total = roundAmount(total.Total)
+		total.Impact = roundAmount(total.Impact)
+		total.ServiceFee = roundAmount(total.ServiceFee)
+		total.SalesTax = roundAmount(total.SalesTax)
+		summary.Amounts = append(summary.Amounts, *total)
+	}
+	sort.Slice(summary.Amounts, func(i, j int) bool {
+		return summary.Amounts[i].Currency < summary.Amounts[j].Currency
+	})
+
+	return summary, nil
+}
+
+// roundTonnes rounds to the nearest kilogram
+func roundTonnes(tonnes float64) float64 {
+	return math.Round(tonnes*1000) / 1000
+}
+
+// roundAmount rounds to two decimal places
+func roundAmount(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
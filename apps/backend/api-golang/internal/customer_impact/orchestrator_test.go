@@ -0,0 +1,154 @@
+package customerimpact
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"api-golang/internal/finance/currency"
+	carbonfootprint "api-golang/internal/impact/carbon_footprint"
+	"api-golang/internal/organisation/customer"
+	"api-golang/internal/organisation/organisation"
+	"api-golang/internal/platform/airport"
+	"api-golang/internal/platform/country"
+	"api-golang/internal/platform/mcc"
+	"api-golang/internal/quote"
+	"api-golang/internal/shared/auth"
+	"api-golang/internal/shared/errors"
+)
+
+var (
+	january = time.Date(2026, time.January, 15, 12, 0, 0, 0, time.UTC)
+	march   = time.Date(2026, time.March, 10, 12, 0, 0, 0, time.UTC)
+)
+
+// setupOrchestrator creates an orchestrator and a customer of org-child-1 with
+// an accepted EUR quote in January, a completed GBP quote and a pending EUR
+// quote in March
+func setupOrchestrator(t *testing.T) (*Orchestrator, *customer.Entity) {
+	t.Helper()
+	ctx := context.Background()
+
+	countryService := country.NewService(country.NewInMemoryRepository())
+	orgService := organisation.NewService(organisation.NewInMemoryRepository(), countryService,
+		currency.NewService(currency.NewInMemoryRepository()), mcc.NewService(mcc.NewInMemoryRepository()))
+	customerService := customer.NewService(customer.NewInMemoryRepository(), countryService)
+	footprintRepo := carbonfootprint.NewInMemoryFootprintRepository()
+	carbonService := carbonfootprint.NewService(carbonfootprint.NewInMemoryFactorRepository(),
+		carbonfootprint.NewInMemoryCategoryRepository(), carbonfootprint.NewInMemoryNatureFactorRepository(), footprintRepo, airport.NewService(airport.NewInMemoryRepository()))
+	quoteRepo := quote.NewInMemoryRepository()
+
+	cust, err := customerService.GetOrCreateCustomer(ctx, customer.CreateCustomerInput{
+		OrganisationID: "org-child-1", Reference: "cust-1", CountryCode: "IRL",
+	})
+	if err != nil {
+		t.Fatalf("GetOrCreateCustomer failed: %v", err)
+	}
+
+	for _, fp := range []*carbonfootprint.Footprint{
+		{ID: "fp-jan", CarbonCo2eGrams: 200000, TransactionDate: january},
+		{ID: "fp-mar", CarbonCo2eGrams: 100000, TransactionDate: march},
+		{ID: "fp-pending", CarbonCo2eGrams: 50000, TransactionDate: march},
+	} {
+		fp.CustomerID, fp.OrganisationID = cust.ID, "org-child-1"
+		if err := footprintRepo.Create(ctx, fp); err != nil {
+			t.Fatalf("creating footprint failed: %v", err)
+		}
+	}
+	for _, q := range []*quote.Entity{
+		{ID: "q-jan", CalculationReference: "fp-jan", Currency: "EUR", Status: quote.StatusAccepted, CreatedAt: january,
+			CarbonCreditTotal: 3.69, CarbonCreditImpact: 3, CarbonCreditImpactSalesTax: 0.69},
+		{ID: "q-mar", CalculationReference: "fp-mar", Currency: "GBP", Status: quote.StatusCompleted, CreatedAt: march,
+			CarbonCreditTotal: 1.5, CarbonCreditImpact: 1.2, CarbonCreditServiceFee: 0.25, CarbonCreditServiceFeeSalesTax: 0.05},
+		{ID: "q-pending", CalculationReference: "fp-pending", Currency: "EUR", Status: quote.StatusPending, CreatedAt: march,
+			CarbonCreditTotal: 0.8, CarbonCreditImpact: 0.8},
+	} {
+		q.CustomerID, q.OrganisationID = cust.ID, "org-child-1"
+		if err := quoteRepo.Create(ctx, q); err != nil {
+			t.Fatalf("creating quote failed: %v", err)
+		}
+	}
+
+	return NewOrchestrator(OrchestratorDeps{
+		Organisations:   orgService,
+		CustomerService: customerService,
+		CarbonService:   carbonService,
+		QuoteService: quote.NewOrchestrator(quote.OrchestratorDeps{
+			OrganisationService: orgService,
+			CustomerService:     customerService,
+			QuoteRepo:           quoteRepo,
+		}),
+	}), cust
+}
+
+func caller(organisationID string) *auth.Principal {
+	return &auth.Principal{OrganisationID: organisationID, CredentialType: auth.CredentialAPIKey, Scopes: auth.AllScopes}
+}
+
+func TestGetSummary(t *testing.T) {
+	o, cust := setupOrchestrator(t)
+
+	summary, err := o.GetSummary(context.Background(), caller("org-parent-1"), SummaryInput{CustomerID: cust.ID})
+	if err != nil {
+		t.Fatalf("GetSummary failed: %v", err)
+	}
+
+	if summary.Footprints.Count != 3 || summary.Footprints.Co2eTonnes != 0.35 {
+		t.Errorf("Expected 3 footprints totalling 0.35 tonnes, got %+v", summary.Footprints)
+	}
+	// Pending quotes do not count towards compensation
+	if summary.Quotes.Accepted != 1 || summary.Quotes.Completed != 1 || summary.TonnesCompensated != 0.3 {
+		t.Errorf("Expected 1 accepted and 1 completed quote compensating 0.3 tonnes, got %+v and %v", summary.Quotes, summary.TonnesCompensated)
+	}
+	want := []CurrencyTotal{
+		{Currency: "EUR", Quotes: 1, Total: 3.69, Impact: 3, SalesTax: 0.69},
+		{Currency: "GBP", Quotes: 1, Total: 1.5, Impact: 1.2, ServiceFee: 0.25, SalesTax: 0.05},
+	}
+	if len(summary.Amounts) != len(want) {
+		t.Fatalf("Expected %d currencies, got %+v", len(want), summary.Amounts)
+	}
+	for i := range want {
+		if summary.Amounts[i] != want[i] {
+			t.Errorf("Expected %+v, got %+v", want[i], summary.Amounts[i])
+		}
+	}
+	if len(summary.History) != 2 || summary.History[0].QuoteID != "q-jan" || summary.History[0].Co2eTonnes != 0.2 {
+		t.Errorf("Expected the January quote first in the history, got %+v", summary.History)
+	}
+}
+
+func TestGetSummary_DateRange(t *testing.T) {
+	o, cust := setupOrchestrator(t)
+	ctx := context.Background()
+
+	summary, err := o.GetSummary(ctx, caller("org-child-1"), SummaryInput{
+		CustomerID: cust.ID,
+		From:       time.Date(2026, time.February, 1, 0, 0, 0, 0, time.UTC),
+		To:         time.Date(2026, time.April, 1, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("GetSummary failed: %v", err)
+	}
+	if summary.Footprints.Count != 2 || summary.Quotes.Accepted != 0 || summary.Quotes.Completed != 1 || summary.TonnesCompensated != 0.1 {
+		t.Errorf("Expected only March activity, got %+v", summary)
+	}
+	if len(summary.Amounts) != 1 || summary.Amounts[0].Currency != "GBP" {
+		t.Errorf("Expected only GBP amounts, got %+v", summary.Amounts)
+	}
+
+	_, err = o.GetSummary(ctx, caller("org-child-1"), SummaryInput{CustomerID: cust.ID, From: march, To: january})
+	var domainErr *errors.DomainError
+	if !errors.IsDomainError(err, &domainErr) || domainErr.Code != errors.ErrCodeValidation || domainErr.Field != "to" {
+		t.Errorf("Expected a validation error for to, got %v", err)
+	}
+}
+
+func TestGetSummary_OutsideCallerHierarchy(t *testing.T) {
+	o, cust := setupOrchestrator(t)
+
+	_, err := o.GetSummary(context.Background(), caller("org-child-2"), SummaryInput{CustomerID: cust.ID})
+	var domainErr *errors.DomainError
+	if !errors.IsDomainError(err, &domainErr) || domainErr.Code != errors.ErrCodeNotFound {
+		t.Errorf("Expected not found, got %v", err)
+	}
+}
@@ -12,4 +12,5 @@ type Repository interface {
 type Service interface {
 	ConvertToEUR(ctx context.Context, amount float64, fromCurrency string) (*ConversionResult, error)
 	ConvertFromEUR(ctx context.Context, amount float64, toCurrency string) (*ConversionResult, error)
+	ValidateCurrency(ctx context.Context, code string) error
 }
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 
+	"api-golang/internal/shared/errors"
+
 	"github.com/bilo-mono/packages/common/service"
 )
 
@@ -78,3 +80,28 @@ func (s *DefaultService) ConvertFromEUR(ctx context.Context, amount float64, toC
 		ExchangeRate:     rate.Rate,
 	}, nil
 }
+
+// ValidateCurrency checks that a currency code is a 3-letter ISO 4217 code
+// that can be converted to and from EUR
+func (s *DefaultService) ValidateCurrency(ctx context.Context, code string) error {
+	if len(code) != 3 {
+		return errors.NewValidationError(domainName, fmt.Sprintf("%q is not a valid currency code: must be 3 letters", code))
+	}
+	for _, c := range code {
+		if c < 'A' || c > 'Z' {
+			return errors.NewValidationError(domainName, fmt.Sprintf("%q is not a valid currency code: must be 3 uppercase letters", code))
+		}
+	}
+	if code == baseCurrency {
+		return nil
+	}
+
+	if _, err := s.Repo.GetExchangeRate(ctx, code, baseCurrency); err != nil {
+		var domainErr *errors.DomainError
+		if errors.IsDomainError(err, &domainErr) && domainErr.Code == errors.ErrCodeNotFound {
+			return errors.NewValidationError(domainName, fmt.Sprintf("unsupported currency: %s", code))
+		}
+		return fmt.Errorf("getting exchange rate from %s to %s: %w", code, baseCurrency, err)
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package footprint
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"api-golang/internal/shared/auth"
+	"api-golang/internal/shared/errors"
+)
+
+// Controller handles HTTP requests for footprints
+type Controller struct {
+	orchestrator *Orchestrator
+}
+
+// NewController creates a new footprint controller
+func NewController(orchestrator *Orchestrator) *Controller {
+	return &Controller{orchestrator: orchestrator}
+}
+
+// HandleEstimate handles POST /api/footprints/estimate
+func (c *Controller) HandleEstimate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		c.writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed")
+		return
+	}
+
+	var req EstimateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body: "+err.Error())
+		return
+	}
+	if req.Locale == "" {
+		req.Locale = "en-GB"
+	}
+
+	response, err := c.orchestrator.Estimate(r.Context(), &req)
+	if err != nil {
+		var domainErr *errors.DomainError
+		switch {
+		case errors.IsDomainError(err, &domainErr) && domainErr.Code == errors.ErrCodeValidation:
+			c.writeFieldError(w, http.StatusBadRequest, "VALIDATION_ERROR", domainErr.Field, domainErr.Message)
+		case errors.IsDomainError(err, &domainErr) && domainErr.Code == errors.ErrCodeNotFound:
+			c.writeError(w, http.StatusBadRequest, "VALIDATION_ERROR", domainErr.Message)
+		default:
+			c.writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		}
+		return
+	}
+
+	c.writeJSON(w, http.StatusOK, response)
+}
+
+// HandleGetByID handles GET /api/footprints/{id}
+func (c *Controller) HandleGetByID(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		c.writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed")
+		return
+	}
+
+	caller, ok := auth.PrincipalFromContext(r.Context())
+	if !ok {
+		c.writeError(w, http.StatusUnauthorized, errors.ErrCodeUnauthorized, "API key is required")
+		return
+	}
+
+	id := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/footprints/"), "/")[0]
+	if id == "" {
+		c.writeError(w, http.StatusBadRequest, "MISSING_FIELD", "Footprint ID is required")
+		return
+	}
+
+	footprint, err := c.orchestrator.GetFootprint(r.Context(), caller, id)
+	if err != nil {
+		var domainErr *errors.DomainError
+		if errors.IsDomainError(err, &domainErr) && domainErr.Code == errors.ErrCodeNotFound {
+			c.writeError(w, http.StatusNotFound, "NOT_FOUND", "Footprint not found")
+			return
+		}
+		c.writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		return
+	}
+
+	c.writeJSON(w, http.StatusOK, footprint)
+}
+
+// ErrorResponse represents an error response
+type ErrorResponse struct {
+	Error struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+		Field   string `json:"field,omitempty"` // Request field that failed validation
+	} `json:"error"`
+}
+
+func (c *Controller) writeError(w http.ResponseWriter, status int, code, message string) {
+	c.writeFieldError(w, status, code, "", message)
+}
+
+func (c *Controller) writeFieldError(w http.ResponseWriter, status int, code, field, message string) {
+	resp := ErrorResponse{}
+	resp.Error.Code = code
+	resp.Error.Message = message
+	resp.Error.Field = field
+	c.writeJSON(w, status, resp)
+}
+
+func (c *Controller) writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
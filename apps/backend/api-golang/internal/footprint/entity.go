@@ -0,0 +1,42 @@
+// Package footprint handles footprint estimates and lookups for dashboards
+// and marketing tools, without creating customers or quotes.
+package footprint
+
+import (
+	carbonfootprint "api-golang/internal/impact/carbon_footprint"
+	"api-golang/internal/impact/equivalent"
+)
+
+// EstimateRequest represents the request body for a footprint estimate
+type EstimateRequest struct {
+	Locale            string                    `json:"locale,omitempty"`
+	Country           string                    `json:"country"`       // Required - merchant country, ISO 3166-1 alpha-2 or alpha-3
+	MCC               string                    `json:"mcc,omitempty"` // Merchant Category Code, default factor when empty
+	Amount            *AmountRequest            `json:"amount,omitempty"`
+	OrderItems        []OrderItemRequest        `json:"orderItems,omitempty"`
+	CalculationMethod string                    `json:"calculationMethod,omitempty"` // spend (default), flight, fuel or electricity
+	Activity          *carbonfootprint.Activity `json:"activity,omitempty"`
+	IncludeNature     bool                      `json:"includeNature,omitempty"`
+}
+
+// AmountRequest represents a transaction amount
+type AmountRequest struct {
+	Value        float64 `json:"value"`
+	CurrencyCode string  `json:"currencyCode"` // ISO 4217 (3 chars)
+}
+
+// OrderItemRequest represents an order item in an estimate request
+type OrderItemRequest struct {
+	ItemID    string        `json:"itemId"`
+	Name      string        `json:"name,omitempty"`
+	Category  string        `json:"category,omitempty"`
+	Quantity  int           `json:"quantity"`
+	UnitPrice AmountRequest `json:"unitPrice"`
+}
+
+// EstimateResponse represents a footprint estimate. Nothing is stored, so the
+// footprint has no ID.
+type EstimateResponse struct {
+	Footprint   *carbonfootprint.Footprint `json:"footprint"`
+	Equivalents []equivalent.Result        `json:"equivalents"`
+}
@@ -0,0 +1,167 @@
+package footprint
+
+import (
+	"context"
+	"fmt"
+
+	"api-golang/internal/finance/currency"
+	carbonfootprint "api-golang/internal/impact/carbon_footprint"
+	"api-golang/internal/impact/equivalent"
+	"api-golang/internal/platform/country"
+	"api-golang/internal/platform/mcc"
+	"api-golang/internal/shared/auth"
+	"api-golang/internal/shared/errors"
+)
+
+const domainName = "footprint"
+
+// Orchestrator coordinates footprint estimates across the country, MCC, currency,
+// carbon footprint and equivalents domains
+type Orchestrator struct {
+	organisations     OrganisationAccess
+	countryService    country.Service
+	mccService        mcc.Service
+	currencyService   currency.Service
+	carbonService     carbonfootprint.Service
+	equivalentService equivalent.Service
+}
+
+// OrchestratorDeps contains all dependencies for the orchestrator
+type OrchestratorDeps struct {
+	Organisations     OrganisationAccess
+	CountryService    country.Service
+	MCCService        mcc.Service
+	CurrencyService   currency.Service
+	CarbonService     carbonfootprint.Service
+	EquivalentService equivalent.Service
+}
+
+// NewOrchestrator creates a new footprint orchestrator
+func NewOrchestrator(deps OrchestratorDeps) *Orchestrator {
+	return &Orchestrator{
+		organisations:     deps.Organisations,
+		countryService:    deps.CountryService,
+		mccService:        deps.MCCService,
+		currencyService:   deps.CurrencyService,
+		carbonService:     deps.CarbonService,
+		equivalentService: deps.EquivalentService,
+	}
+}
+
+// Estimate calculates a footprint and its equivalents without persisting anything
+func (o *Orchestrator) Estimate(ctx context.Context, req *EstimateRequest) (*EstimateResponse, error) {
+	if req.Country == "" {
+		return nil, errors.NewFieldValidationError(domainName, "country", "country is required")
+	}
+	if req.Amount == nil && len(req.OrderItems) == 0 && req.Activity == nil {
+		return nil, errors.NewValidationError(domainName, "amount, orderItems or activity is required")
+	}
+	if req.Amount != nil && req.Amount.Value <= 0 {
+		return nil, errors.NewFieldValidationError(domainName, "amount.value", "amount must be greater than zero")
+	}
+	for i, item := range req.OrderItems {
+		if item.Quantity <= 0 {
+			return nil, errors.NewFieldValidationError(domainName, fmt.Sprintf("orderItems[%d].quantity", i), "quantity must be greater than zero")
+		}
+		if item.UnitPrice.Value <= 0 {
+			return nil, errors.NewFieldValidationError(domainName, fmt.Sprintf("orderItems[%d].unitPrice.value", i), "unit price must be greater than zero")
+		}
+	}
+
+	if req.MCC != "" {
+		if _, err := o.mccService.ValidateMCC(ctx, req.MCC); err != nil {
+			var domainErr *errors.DomainError
+			if errors.IsDomainError(err, &domainErr) && domainErr.Code == errors.ErrCodeValidation {
+				return nil, errors.NewFieldValidationError(domainName, "mcc", domainErr.Message)
+			}
+			return nil, fmt.Errorf("validating merchant category code: %w", err)
+		}
+	}
+
+	// Step 1: Resolve the merchant country
+	merchantCountry, err := o.countryService.GetCountryByCode(ctx, req.Country)
+	if err != nil {
+		return nil, fmt.Errorf("step 1 - get merchant country: %w", err)
+	}
+
+	// Step 2: Convert the amount and order item line totals to EUR
+	var amountEUR float64
+	if req.Amount != nil {
+		if amountEUR, err = o.toEUR(ctx, req.Amount.Value, req.Amount.CurrencyCode); err != nil {
+			return nil, fmt.Errorf("step 2 - convert amount: %w", err)
+		}
+	}
+	items := make([]carbonfootprint.ItemInput, 0, len(req.OrderItems))
+	for _, item := range req.OrderItems {
+		lineAmount, err := o.toEUR(ctx, item.UnitPrice.Value*float64(item.Quantity), item.UnitPrice.CurrencyCode)
+		if err != nil {
+			return nil, fmt.Errorf("step 2 - convert order item currency: %w", err)
+		}
+		items = append(items, carbonfootprint.ItemInput{
+			ItemID:    item.ItemID,
+			Name:      item.Name,
+			Category:  item.Category,
+			AmountEUR: lineAmount,
+		})
+	}
+
+	// Step 3: Estimate the footprint
+	footprint, err := o.carbonService.Estimate(ctx, carbonfootprint.CalculateInput{
+		AmountEUR:     amountEUR,
+		MCC:           req.MCC,
+		CountryID:     merchantCountry.ID,
+		Items:         items,
+		Method:        carbonfootprint.MethodType(req.CalculationMethod),
+		Activity:      req.Activity,
+		IncludeNature: req.IncludeNature,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("step 3 - estimate carbon footprint: %w", err)
+	}
+
+	// Step 4: Render localised equivalents
+	equivalents, err := o.equivalentService.Calculate(ctx, footprint.CarbonKg(), req.Locale)
+	if err != nil {
+		return nil, fmt.Errorf("step 4 - calculate equivalents: %w", err)
+	}
+	carbonEquivalents := make(carbonfootprint.Equivalents, len(equivalents))
+	for i, e := range equivalents {
+		carbonEquivalents[i] = carbonfootprint.Equivalent{Key: e.Key, Value: e.Value, Template: e.Template, Unit: e.Unit}
+	}
+	footprint.CarbonEquivalents = &carbonEquivalents
+
+	return &EstimateResponse{
+		Footprint:   footprint,
+		Equivalents: equivalents,
+	}, nil
+}
+
+// GetFootprint retrieves a stored footprint of the caller's organisation or
+// one of its descendants. Other organisations' footprints are reported as not
+// found so that their IDs are not disclosed.
+func (o *Orchestrator) GetFootprint(ctx context.Context, caller *auth.Principal, id string) (*carbonfootprint.Footprint, error) {
+	footprint, err := o.carbonService.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := o.organisations.ValidateOrganisation(ctx, caller.OrganisationID, footprint.OrganisationID); err != nil {
+		var domainErr *errors.DomainError
+		if errors.IsDomainError(err, &domainErr) && domainErr.Code == errors.ErrCodeForbidden {
+			return nil, errors.NewNotFoundError(domainName, "footprint not found")
+		}
+		return nil, err
+	}
+	return footprint, nil
+}
+
+// toEUR converts an amount to EUR, treating an empty currency as EUR
+func (o *Orchestrator) toEUR(ctx context.Context, amount float64, currencyCode string) (float64, error) {
+	if currencyCode == "" || currencyCode == "EUR" {
+		return amount, nil
+	}
+	result, err := o.currencyService.ConvertToEUR(ctx, amount, currencyCode)
+	if err != nil {
+		return 0, err
+	}
+	return result.ConvertedAmount, nil
+}
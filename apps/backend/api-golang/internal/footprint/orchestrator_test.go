@@ -0,0 +1,140 @@
+package footprint
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"api-golang/internal/finance/currency"
+	carbonfootprint "api-golang/internal/impact/carbon_footprint"
+	"api-golang/internal/impact/equivalent"
+	"api-golang/internal/organisation/organisation"
+	"api-golang/internal/platform/airport"
+	"api-golang/internal/platform/country"
+	"api-golang/internal/platform/mcc"
+	"api-golang/internal/shared/auth"
+	"api-golang/internal/shared/errors"
+)
+
+func setupOrchestrator() (*Orchestrator, carbonfootprint.Service) {
+	carbonService := carbonfootprint.NewService(
+		carbonfootprint.NewInMemoryFactorRepository(),
+		carbonfootprint.NewInMemoryCategoryRepository(),
+		carbonfootprint.NewInMemoryNatureFactorRepository(),
+		carbonfootprint.NewInMemoryFootprintRepository(),
+		airport.NewService(airport.NewInMemoryRepository()),
+	)
+	countryService := country.NewService(country.NewInMemoryRepository())
+	mccService := mcc.NewService(mcc.NewInMemoryRepository())
+	currencyService := currency.NewService(currency.NewInMemoryRepository())
+	return NewOrchestrator(OrchestratorDeps{
+		Organisations:     organisation.NewService(organisation.NewInMemoryRepository(), countryService, currencyService, mccService),
+		CountryService:    countryService,
+		MCCService:        mccService,
+		CurrencyService:   currencyService,
+		CarbonService:     carbonService,
+		EquivalentService: equivalent.NewService(equivalent.NewInMemoryRepository()),
+	}), carbonService
+}
+
+func TestEstimate_DoesNotPersist(t *testing.T) {
+	orchestrator, _ := setupOrchestrator()
+	ctx := context.Background()
+
+	resp, err := orchestrator.Estimate(ctx, &EstimateRequest{
+		Locale:  "en-GB",
+		Country: "GB",
+		MCC:     "5411",
+		Amount:  &AmountRequest{Value: 100, CurrencyCode: "EUR"},
+	})
+	if err != nil {
+		t.Fatalf("Estimate failed: %v", err)
+	}
+
+	// UK grocery factor 0.15 kg CO2e per EUR
+	if math.Abs(resp.Footprint.CarbonCo2eGrams-15000) > 0.001 {
+		t.Errorf("Expected 15000 grams, got %v", resp.Footprint.CarbonCo2eGrams)
+	}
+	if resp.Footprint.ID != "" {
+		t.Errorf("Expected estimate without ID, got %q", resp.Footprint.ID)
+	}
+	if len(resp.Equivalents) == 0 || resp.Footprint.CarbonEquivalents == nil {
+		t.Error("Expected equivalents")
+	}
+}
+
+func TestEstimate_Validation(t *testing.T) {
+	orchestrator, _ := setupOrchestrator()
+	ctx := context.Background()
+
+	requests := []*EstimateRequest{
+		{Amount: &AmountRequest{Value: 100, CurrencyCode: "EUR"}}, // No country
+		{Country: "GBR"}, // Nothing to estimate
+		{Country: "XXX", Amount: &AmountRequest{Value: 100, CurrencyCode: "EUR"}},
+	}
+	for _, req := range requests {
+		_, err := orchestrator.Estimate(ctx, req)
+		var domainErr *errors.DomainError
+		if !errors.IsDomainError(err, &domainErr) {
+			t.Errorf("Expected domain error for %+v, got %v", req, err)
+		}
+	}
+	item := func(quantity int, unitPrice float64) []OrderItemRequest {
+		return []OrderItemRequest{{ItemID: "item-1", Quantity: quantity, UnitPrice: AmountRequest{Value: unitPrice, CurrencyCode: "EUR"}}}
+	}
+	for _, tt := range []struct {
+		name  string
+		req   *EstimateRequest
+		field string
+	}{
+		{name: "Negative amount", req: &EstimateRequest{Country: "GBR", Amount: &AmountRequest{Value: -100, CurrencyCode: "EUR"}}, field: "amount.value"},
+		{name: "Zero amount", req: &EstimateRequest{Country: "GBR", Amount: &AmountRequest{CurrencyCode: "EUR"}}, field: "amount.value"},
+		{name: "Negative quantity", req: &EstimateRequest{Country: "GBR", OrderItems: item(-2, 10)}, field: "orderItems[0].quantity"},
+		{name: "Negative unit price", req: &EstimateRequest{Country: "GBR", OrderItems: item(2, -10)}, field: "orderItems[0].unitPrice.value"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := orchestrator.Estimate(ctx, tt.req)
+			var domainErr *errors.DomainError
+			if !errors.IsDomainError(err, &domainErr) || domainErr.Field != tt.field {
+				t.Errorf("Expected validation error on %s, got %v", tt.field, err)
+			}
+		})
+	}
+}
+
+func TestGetFootprint(t *testing.T) {
+	orchestrator, carbonService := setupOrchestrator()
+	ctx := context.Background()
+
+	stored, err := carbonService.Calculate(ctx, carbonfootprint.CalculateInput{
+		OrganisationID: "org-child-1",
+		AmountEUR:      50,
+		MCC:            "5812",
+		CountryID:      "FRA",
+	})
+	if err != nil {
+		t.Fatalf("Calculate failed: %v", err)
+	}
+
+	for _, callerOrgID := range []string{"org-child-1", "org-parent-1"} {
+		got, err := orchestrator.GetFootprint(ctx, &auth.Principal{OrganisationID: callerOrgID}, stored.ID)
+		if err != nil {
+			t.Fatalf("GetFootprint for %s failed: %v", callerOrgID, err)
+		}
+		if got.ID != stored.ID {
+			t.Errorf("Expected footprint %s, got %s", stored.ID, got.ID)
+		}
+	}
+
+	// Unknown footprints and those of other organisations are not found
+	for _, tt := range []struct{ callerOrgID, id string }{
+		{"org-parent-1", "missing"},
+		{"org-child-2", stored.ID},
+	} {
+		_, err := orchestrator.GetFootprint(ctx, &auth.Principal{OrganisationID: tt.callerOrgID}, tt.id)
+		var domainErr *errors.DomainError
+		if !errors.IsDomainError(err, &domainErr) || domainErr.Code != errors.ErrCodeNotFound {
+			t.Errorf("Expected not found for %s as %s, got %v", tt.id, tt.callerOrgID, err)
+		}
+	}
+}
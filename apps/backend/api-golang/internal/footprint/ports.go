@@ -0,0 +1,14 @@
+// Package footprint defines ports for footprint estimates and lookups.
+package footprint
+
+import (
+	"context"
+
+	"api-golang/internal/organisation/organisation"
+)
+
+// OrganisationAccess defines the port for checking that a caller may act for
+// an organisation (provided by the organisation domain)
+type OrganisationAccess interface {
+	ValidateOrganisation(ctx context.Context, callerOrgID, orgID string) (*organisation.Entity, error)
+}
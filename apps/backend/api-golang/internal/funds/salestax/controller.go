@@ -0,0 +1,107 @@
+package salestax
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"api-golang/internal/shared/auth"
+	"api-golang/internal/shared/errors"
+)
+
+// Controller handles HTTP requests for sales tax exemptions
+type Controller struct {
+	service Service
+}
+
+// NewController creates a new sales tax controller
+func NewController(service Service) *Controller {
+	return &Controller{service: service}
+}
+
+// HandleExemptions handles GET and POST /api/tax-exemptions
+func (c *Controller) HandleExemptions(w http.ResponseWriter, r *http.Request) {
+	caller, ok := auth.PrincipalFromContext(r.Context())
+	if !ok {
+		c.writeError(w, http.StatusUnauthorized, errors.ErrCodeUnauthorized, "API key is required")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		c.handleGetExemptions(w, r, caller)
+	case http.MethodPost:
+		c.handleRegisterExemption(w, r, caller)
+	default:
+		c.writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed")
+	}
+}
+
+// handleGetExemptions handles GET /api/tax-exemptions?holderType={type}&holderId={id}
+func (c *Controller) handleGetExemptions(w http.ResponseWriter, r *http.Request, caller *auth.Principal) {
+	holderType := ExemptionHolderType(r.URL.Query().Get("holderType"))
+	holderID := r.URL.Query().Get("holderId")
+	if holderType == "" || holderID == "" {
+		c.writeError(w, http.StatusBadRequest, "MISSING_FIELD", "holderType and holderId are required")
+		return
+	}
+
+	certificates, err := c.service.GetExemptions(r.Context(), caller.OrganisationID, holderType, holderID)
+	if err != nil {
+		c.writeServiceError(w, err)
+		return
+	}
+
+	c.writeJSON(w, http.StatusOK, certificates)
+}
+
+// handleRegisterExemption handles POST /api/tax-exemptions
+func (c *Controller) handleRegisterExemption(w http.ResponseWriter, r *http.Request, caller *auth.Principal) {
+	var input RegisterExemptionInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		c.writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body: "+err.Error())
+		return
+	}
+
+	certificate, err := c.service.RegisterExemption(r.Context(), caller.OrganisationID, input)
+	if err != nil {
+		c.writeServiceError(w, err)
+		return
+	}
+
+	c.writeJSON(w, http.StatusCreated, certificate)
+}
+
+// ErrorResponse represents an error response
+type ErrorResponse struct {
+	Error struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func (c *Controller) writeServiceError(w http.ResponseWriter, err error) {
+	var domainErr *errors.DomainError
+	switch {
+	case errors.IsDomainError(err, &domainErr) && domainErr.Code == errors.ErrCodeValidation:
+		c.writeError(w, http.StatusBadRequest, "VALIDATION_ERROR", domainErr.Message)
+	case errors.IsDomainError(err, &domainErr) && domainErr.Code == errors.ErrCodeForbidden:
+		c.writeError(w, http.StatusForbidden, "FORBIDDEN", domainErr.Message)
+	case errors.IsDomainError(err, &domainErr) && domainErr.Code == errors.ErrCodeNotFound:
+		c.writeError(w, http.StatusNotFound, "NOT_FOUND", domainErr.Message)
+	default:
+		c.writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+	}
+}
+
+func (c *Controller) writeError(w http.ResponseWriter, status int, code, message string) {
+	resp := ErrorResponse{}
+	resp.Error.Code = code
+	resp.Error.Message = message
+	c.writeJSON(w, status, resp)
+}
+
+func (c *Controller) writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
@@ -1,6 +1,16 @@
 // Package salestax handles sales tax calculations.
 package salestax
 
+import "time"
+
+// TaxRegime represents the kind of indirect tax levied in a jurisdiction
+type TaxRegime string
+
+const (
+	TaxRegimeVAT      TaxRegime = "vat"      // Value added tax (EU, UK)
+	TaxRegimeSalesTax TaxRegime = "salesTax" // US state sales tax
+)
+
 // TaxRate represents a sales tax rate configuration
 // Matches Sales tax data model
 // See: https://www.notion.so/ekko-earth/Sales-tax-2b7f93807de480a69495c23d832f98a8
@@ -13,19 +23,37 @@ type TaxRate struct {
 	// Customer location (zip, state, country ISO-3)
 	CustomerLocation CustomerLocation `json:"customerLocation"` // Required
 
+	// Tax regime of the jurisdiction
+	Regime TaxRegime `json:"regime,omitempty"`
+
 	// Tax liability
 	IsEkkoTaxLiable bool `json:"isEkkoTaxLiable"` // YES/NO - who is liable for sales tax
 
 	// Tax rates
-	ServiceFeeRate   float64 `json:"serviceFeeRate,omitempty"`
-	CarbonCreditRate float64 `json:"carbonCreditRate,omitempty"`
-	CharityRate      float64 `json:"charityRate,omitempty"`
-	NonCharityRate   float64 `json:"nonCharityRate,omitempty"`
+	// For US zip-level rates CarbonCreditRate is the combined rate of Components
+	Components       *TaxRateComponents `json:"components,omitempty"`
+	ServiceFeeRate   float64            `json:"serviceFeeRate,omitempty"`
+	CarbonCreditRate float64            `json:"carbonCreditRate,omitempty"`
+	CharityRate      float64            `json:"charityRate,omitempty"`
+	NonCharityRate   float64            `json:"nonCharityRate,omitempty"`
 
 	// External tax service
 	TaxJurisdictionID string `json:"taxJurisdictionId,omitempty"` // From Avalara
 }
 
+// TaxRateComponents represents the jurisdiction components of a combined US sales tax rate
+type TaxRateComponents struct {
+	State           float64 `json:"state"`
+	County          float64 `json:"county"`
+	City            float64 `json:"city"`
+	SpecialDistrict float64 `json:"specialDistrict"`
+}
+
+// Combined returns the total of all components
+func (c TaxRateComponents) Combined() float64 {
+	return c.State + c.County + c.City + c.SpecialDistrict
+}
+
 // MerchantLocation represents merchant location for tax calculation
 type MerchantLocation struct {
 	Zip     string `json:"zip,omitempty"` // Postal code
@@ -48,6 +76,9 @@ type TaxCalculationInput struct {
 	CustomerCountry    string
 	CustomerState      string
 	CustomerPostalCode string
+	MerchantTaxNumber  string // From organisation billing, empty if not tax registered
+	OrganisationID     string // Used to look up exemption certificates
+	CustomerID         string // Used to look up exemption certificates
 	Amount             float64
 }
 
@@ -58,4 +89,67 @@ type TaxResult struct {
 	TaxAmount     float64 `json:"taxAmount"`
 	TaxName       string  `json:"taxName"`
 	IsApplicable  bool    `json:"isApplicable"`
+
+	// Breakdown by jurisdiction component (US zip-level rates only)
+	Components []TaxComponent `json:"components,omitempty"`
+
+	// Exemption certificate applied, if the sale was zero-rated by an exemption
+	ExemptionCertificateID string `json:"exemptionCertificateId,omitempty"`
+
+	// IsMerchantTaxLiable is true when the merchant remits the tax, false when Ekko does
+	IsMerchantTaxLiable bool `json:"isMerchantTaxLiable"`
+}
+
+// TaxComponent represents one jurisdiction's share of the calculated tax
+type TaxComponent struct {
+	Jurisdiction string  `json:"jurisdiction"` // state, county, city, specialDistrict
+	TaxRate      float64 `json:"taxRate"`
+	TaxAmount    float64 `json:"taxAmount"`
+}
+
+// ExemptionHolderType represents who an exemption certificate was issued to
+type ExemptionHolderType string
+
+const (
+	ExemptionHolderCustomer     ExemptionHolderType = "customer"
+	ExemptionHolderOrganisation ExemptionHolderType = "organisation"
+)
+
+// ExemptionReason represents why a holder is exempt from sales tax
+type ExemptionReason string
+
+const (
+	ExemptionReasonResale     ExemptionReason = "resale"
+	ExemptionReasonNonProfit  ExemptionReason = "nonProfit"
+	ExemptionReasonGovernment ExemptionReason = "government"
+	ExemptionReasonOther      ExemptionReason = "other"
+)
+
+// ExemptionCertificate represents a sales tax exemption for a customer or organisation
+// in a jurisdiction (country, optionally narrowed to a state)
+type ExemptionCertificate struct {
+	ID                string              `json:"id"`
+	HolderType        ExemptionHolderType `json:"holderType"` // customer or organisation
+	HolderID          string              `json:"holderId"`
+	Country           string              `json:"country"`         // ISO-3 (required)
+	State             string              `json:"state,omitempty"` // Empty = whole country
+	Reason            ExemptionReason     `json:"reason"`
+	CertificateNumber string              `json:"certificateNumber,omitempty"` // Issued by the tax authority
+	ValidFrom         time.Time           `json:"validFrom"`
+	ValidTo           *time.Time          `json:"validTo,omitempty"` // Nil = no expiry
+	CreatedAt         time.Time           `json:"createdAt"`
+}
+
+// Covers checks if the certificate applies to a jurisdiction at the given time
+func (c *ExemptionCertificate) Covers(country, state string, at time.Time) bool {
+	if c.Country != country {
+		return false
+	}
+	if c.State != "" && c.State != state {
+		return false
+	}
+	if at.Before(c.ValidFrom) {
+		return false
+	}
+	return c.ValidTo == nil || at.Before(*c.ValidTo)
 }
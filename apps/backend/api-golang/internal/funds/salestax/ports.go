@@ -1,14 +1,61 @@
 // Package salestax defines ports for the sales tax sub-domain.
 package salestax
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // Repository defines the port for tax rate data access
 type Repository interface {
 	GetTaxRate(ctx context.Context, countryCode, state, postalCode string) (*TaxRate, error)
 }
 
+// RateTableRepository defines the port for bulk tax rate storage
+type RateTableRepository interface {
+	SaveRates(ctx context.Context, rates []*TaxRate) error
+}
+
+// ExemptionRepository defines the port for exemption certificate data access
+type ExemptionRepository interface {
+	Create(ctx context.Context, certificate *ExemptionCertificate) error
+	Update(ctx context.Context, certificate *ExemptionCertificate) error
+	GetByHolder(ctx context.Context, holderType ExemptionHolderType, holderID string) ([]*ExemptionCertificate, error)
+}
+
+// RegisterExemptionInput represents input for registering an exemption certificate
+type RegisterExemptionInput struct {
+	HolderType        ExemptionHolderType `json:"holderType"`
+	HolderID          string              `json:"holderId"`
+	Country           string              `json:"country"`
+	State             string              `json:"state,omitempty"`
+	Reason            ExemptionReason     `json:"reason"`
+	CertificateNumber string              `json:"certificateNumber,omitempty"`
+	ValidFrom         *time.Time          `json:"validFrom,omitempty"` // Defaults to now
+	ValidTo           *time.Time          `json:"validTo,omitempty"`
+}
+
+// PostalCodeNormaliser defines the port for postal code validation (provided by the country domain)
+type PostalCodeNormaliser interface {
+	NormalisePostalCode(ctx context.Context, countryCode, postalCode string) (string, error)
+}
+
+// HolderAccess defines the port for checking that a caller may act for an
+// exemption holder (provided by the organisation and customer domains)
+type HolderAccess interface {
+	// ValidateOrganisation returns a forbidden error when the organisation is
+	// outside the caller's subtree
+	ValidateOrganisation(ctx context.Context, callerOrgID, orgID string) error
+	// GetCustomerOrganisationID returns the organisation a customer belongs to
+	GetCustomerOrganisationID(ctx context.Context, customerID string) (string, error)
+}
+
 // Service defines the port for sales tax calculation business logic
 type Service interface {
 	CalculateSalesTax(ctx context.Context, input TaxCalculationInput) (*TaxResult, error)
+	RegisterExemption(ctx context.Context, callerOrgID string, input RegisterExemptionInput) (*ExemptionCertificate, error)
+	GetExemptions(ctx context.Context, callerOrgID string, holderType ExemptionHolderType, holderID string) ([]*ExemptionCertificate, error)
+	// ErasePersonalData removes the certificate numbers from a customer's
+	// exemption certificates and returns how many certificates it erased
+	ErasePersonalData(ctx context.Context, customerID string) (int, error)
 }
@@ -0,0 +1,163 @@
+package salestax
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Rate table CSV columns (matches the Avalara free tax rate table layout)
+const (
+	columnState           = "State"
+	columnZipCode         = "ZipCode"
+	columnTaxRegionName   = "TaxRegionName"
+	columnStateRate       = "StateRate"
+	columnCountyRate      = "EstimatedCountyRate"
+	columnCityRate        = "EstimatedCityRate"
+	columnSpecialRate     = "EstimatedSpecialRate"
+	columnCombinedRate    = "EstimatedCombinedRate"
+	combinedRateTolerance = 0.00001
+)
+
+// ParseRateTableCSV parses a US zip-level rate table CSV into tax rates.
+// The combined rate column is optional; when present it must match the sum
+// of the component rates.
+func ParseRateTableCSV(r io.Reader) ([]*TaxRate, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading header: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.TrimSpace(name)] = i
+	}
+	for _, required := range []string{columnState, columnZipCode, columnStateRate, columnCountyRate, columnCityRate, columnSpecialRate} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("missing column %s", required)
+		}
+	}
+
+	var rates []*TaxRate
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		zip, ok := normaliseZip(padZip(record[columns[columnZipCode]]))
+		if !ok {
+			return nil, fmt.Errorf("line %d: invalid zip code %q", line, record[columns[columnZipCode]])
+		}
+
+		var components TaxRateComponents
+		for column, target := range map[string]*float64{
+			columnStateRate:   &components.State,
+			columnCountyRate:  &components.County,
+			columnCityRate:    &components.City,
+			columnSpecialRate: &components.SpecialDistrict,
+		} {
+			value, err := parseRate(record[columns[column]])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %s: %w", line, column, err)
+			}
+			*target = value
+		}
+
+		if i, ok := columns[columnCombinedRate]; ok && strings.TrimSpace(record[i]) != "" {
+			combined, err := parseRate(record[i])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %s: %w", line, columnCombinedRate, err)
+			}
+			if diff := combined - components.Combined(); diff > combinedRateTolerance || diff < -combinedRateTolerance {
+				return nil, fmt.Errorf("line %d: combined rate %v does not match components %v", line, combined, components.Combined())
+			}
+		}
+
+		var region string
+		if i, ok := columns[columnTaxRegionName]; ok {
+			region = strings.TrimSpace(record[i])
+		}
+
+		rates = append(rates, newZipRate(strings.TrimSpace(record[columns[columnState]]), zip, region, components))
+	}
+
+	return rates, nil
+}
+
+// ImportRateTable parses a rate table CSV and stores it in the repository.
+// It returns the number of rates imported.
+func ImportRateTable(ctx context.Context, repo RateTableRepository, r io.Reader) (int, error) {
+	rates, err := ParseRateTableCSV(r)
+	if err != nil {
+		return 0, fmt.Errorf("parsing rate table: %w", err)
+	}
+	if err := repo.SaveRates(ctx, rates); err != nil {
+		return 0, fmt.Errorf("saving rate table: %w", err)
+	}
+	return len(rates), nil
+}
+
+// newZipRate creates a US zip-level tax rate from its components
+func newZipRate(state, zip, region string, components TaxRateComponents) *TaxRate {
+	return &TaxRate{
+		ID:                "USA:" + zip,
+		MerchantLocation:  MerchantLocation{Country: "USA", State: state},
+		CustomerLocation:  CustomerLocation{Country: "USA", State: state, Zip: zip},
+		Regime:            TaxRegimeSalesTax,
+		Components:        &components,
+		CarbonCreditRate:  components.Combined(),
+		TaxJurisdictionID: region,
+	}
+}
+
+// padZip restores the leading zeros spreadsheets often drop from rate table
+// zip codes (e.g. "2108" for Boston). Only rate tables are padded: a short zip
+// code in a request is ambiguous and is rejected instead.
+func padZip(zip string) string {
+	zip = strings.TrimSpace(zip)
+	if len(zip) >= 3 && len(zip) < 5 {
+		zip = strings.Repeat("0", 5-len(zip)) + zip
+	}
+	return zip
+}
+
+// normaliseZip reduces a US zip code (e.g. "90001" or "90001-1234") to its 5-digit form
+func normaliseZip(postalCode string) (string, bool) {
+	zip := strings.TrimSpace(postalCode)
+	if i := strings.IndexByte(zip, '-'); i >= 0 {
+		zip = zip[:i]
+	}
+	if len(zip) != 5 {
+		return "", false
+	}
+	for _, c := range zip {
+		if c < '0' || c > '9' {
+			return "", false
+		}
+	}
+	return zip, true
+}
+
+func parseRate(value string) (float64, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+	rate, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid rate %q", value)
+	}
+	if rate < 0 || rate > 1 {
+		return 0, fmt.Errorf("rate %v out of range", rate)
+	}
+	return rate, nil
+}
@@ -0,0 +1,90 @@
+package salestax
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestImportRateTable(t *testing.T) {
+	repo := NewInMemoryRepository()
+	ctx := context.Background()
+
+	csv := `State,ZipCode,TaxRegionName,EstimatedCombinedRate,StateRate,EstimatedCountyRate,EstimatedCityRate,EstimatedSpecialRate,RiskLevel
+WA,98101,WA SEATTLE,0.1035,0.065,0,0.0385,0.0,1
+MA,2108,MA BOSTON,0.0625,0.0625,0,0,0,1
+`
+	count, err := ImportRateTable(ctx, repo, strings.NewReader(csv))
+	if err != nil {
+		t.Fatalf("ImportRateTable failed: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("Expected 2 rates imported, got %d", count)
+	}
+
+	rate, err := repo.GetTaxRate(ctx, "USA", "WA", "98101")
+	if err != nil {
+		t.Fatalf("GetTaxRate failed: %v", err)
+	}
+	if rate.Components == nil || rate.Components.City != 0.0385 {
+		t.Errorf("Expected imported city rate 0.0385, got %+v", rate.Components)
+	}
+
+	// Rate table zip codes with dropped leading zeros are padded on import
+	rate, err = repo.GetTaxRate(ctx, "USA", "MA", "02108")
+	if err != nil {
+		t.Fatalf("GetTaxRate failed: %v", err)
+	}
+	if rate.CarbonCreditRate != 0.0625 {
+		t.Errorf("Expected MA rate 0.0625, got %v", rate.CarbonCreditRate)
+	}
+
+	// Lookups are not padded, so a short zip code does not match
+	rate, err = repo.GetTaxRate(ctx, "USA", "MA", "2108")
+	if err != nil {
+		t.Fatalf("GetTaxRate failed: %v", err)
+	}
+	if rate.Components != nil {
+		t.Errorf("Expected no zip-level rate for a short zip code, got %+v", rate)
+	}
+}
+
+func TestSaveRates_InvalidRateStoresNothing(t *testing.T) {
+	repo := NewInMemoryRepository()
+	ctx := context.Background()
+
+	err := repo.SaveRates(ctx, []*TaxRate{
+		newZipRate("WA", "98101", "WA SEATTLE", TaxRateComponents{State: 0.065, City: 0.0385}),
+		newZipRate("WA", "981", "WA SHORT", TaxRateComponents{State: 0.065}),
+	})
+	if err == nil {
+		t.Fatal("Expected error for invalid zip code")
+	}
+
+	rate, err := repo.GetTaxRate(ctx, "USA", "WA", "98101")
+	if err != nil {
+		t.Fatalf("GetTaxRate failed: %v", err)
+	}
+	if rate.Components != nil {
+		t.Errorf("Expected no zip-level rate to be stored, got %+v", rate)
+	}
+}
+
+func TestParseRateTableCSV_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		csv  string
+	}{
+		{name: "Missing column", csv: "State,ZipCode\nCA,90001\n"},
+		{name: "Invalid zip", csv: "State,ZipCode,StateRate,EstimatedCountyRate,EstimatedCityRate,EstimatedSpecialRate\nCA,9A001,0.06,0,0,0\n"},
+		{name: "Combined mismatch", csv: "State,ZipCode,EstimatedCombinedRate,StateRate,EstimatedCountyRate,EstimatedCityRate,EstimatedSpecialRate\nCA,90001,0.2,0.06,0,0,0\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseRateTableCSV(strings.NewReader(tt.csv)); err == nil {
+				t.Error("Expected error for invalid rate table")
+			}
+		})
+	}
+}
@@ -2,55 +2,115 @@ package salestax
 
 import (
 	"context"
+	"fmt"
 	"sync"
+
+	"api-golang/internal/shared/errors"
 )
 
-// InMemoryRepository implements Repository interface
+const domainName = "salestax"
+
+// InMemoryRepository implements Repository and RateTableRepository interfaces
 type InMemoryRepository struct {
-	rates map[string]*TaxRate // key: countryCode:state:postalCode (empty parts = *)
-	mu    sync.RWMutex
+	rates    map[string]*TaxRate // key: merchantCountry:merchantState:customerCountry:customerState
+	zipRates map[string]*TaxRate // key: 5-digit US zip code
+	mu       sync.RWMutex
 }
 
 // NewInMemoryRepository creates a new repository with sample data
 func NewInMemoryRepository() *InMemoryRepository {
 	repo := &InMemoryRepository{
-		rates: make(map[string]*TaxRate),
+		rates:    make(map[string]*TaxRate),
+		zipRates: make(map[string]*TaxRate),
 	}
 
 	// Seed with sample VAT rates by country
 	rates := []*TaxRate{
 		// EU VAT rates
-		{ID: "1", MerchantLocation: MerchantLocation{Country: "GBR"}, CustomerLocation: CustomerLocation{Country: "GBR"}, IsEkkoTaxLiable: false, CarbonCreditRate: 0.20},
-		{ID: "2", MerchantLocation: MerchantLocation{Country: "DEU"}, CustomerLocation: CustomerLocation{Country: "DEU"}, IsEkkoTaxLiable: false, CarbonCreditRate: 0.19},
-		{ID: "3", MerchantLocation: MerchantLocation{Country: "FRA"}, CustomerLocation: CustomerLocation{Country: "FRA"}, IsEkkoTaxLiable: false, CarbonCreditRate: 0.20},
-		{ID: "4", MerchantLocation: MerchantLocation{Country: "IRL"}, CustomerLocation: CustomerLocation{Country: "IRL"}, IsEkkoTaxLiable: false, CarbonCreditRate: 0.23},
-		{ID: "5", MerchantLocation: MerchantLocation{Country: "NLD"}, CustomerLocation: CustomerLocation{Country: "NLD"}, IsEkkoTaxLiable: false, CarbonCreditRate: 0.21},
-		{ID: "6", MerchantLocation: MerchantLocation{Country: "ESP"}, CustomerLocation: CustomerLocation{Country: "ESP"}, IsEkkoTaxLiable: false, CarbonCreditRate: 0.21},
+		{ID: "1", MerchantLocation: MerchantLocation{Country: "GBR"}, CustomerLocation: CustomerLocation{Country: "GBR"}, Regime: TaxRegimeVAT, IsEkkoTaxLiable: false, CarbonCreditRate: 0.20},
+		{ID: "2", MerchantLocation: MerchantLocation{Country: "DEU"}, CustomerLocation: CustomerLocation{Country: "DEU"}, Regime: TaxRegimeVAT, IsEkkoTaxLiable: false, CarbonCreditRate: 0.19},
+		{ID: "3", MerchantLocation: MerchantLocation{Country: "FRA"}, CustomerLocation: CustomerLocation{Country: "FRA"}, Regime: TaxRegimeVAT, IsEkkoTaxLiable: false, CarbonCreditRate: 0.20},
+		{ID: "4", MerchantLocation: MerchantLocation{Country: "IRL"}, CustomerLocation: CustomerLocation{Country: "IRL"}, Regime: TaxRegimeVAT, IsEkkoTaxLiable: false, CarbonCreditRate: 0.23},
+		{ID: "5", MerchantLocation: MerchantLocation{Country: "NLD"}, CustomerLocation: CustomerLocation{Country: "NLD"}, Regime: TaxRegimeVAT, IsEkkoTaxLiable: false, CarbonCreditRate: 0.21},
+		{ID: "6", MerchantLocation: MerchantLocation{Country: "ESP"}, CustomerLocation: CustomerLocation{Country: "ESP"}, Regime: TaxRegimeVAT, IsEkkoTaxLiable: false, CarbonCreditRate: 0.21},
 		// US - Sales tax varies by state
-		{ID: "7", MerchantLocation: MerchantLocation{Country: "USA", State: "CA"}, CustomerLocation: CustomerLocation{Country: "USA", State: "CA"}, IsEkkoTaxLiable: false, CarbonCreditRate: 0.0725},
-		{ID: "8", MerchantLocation: MerchantLocation{Country: "USA", State: "NY"}, CustomerLocation: CustomerLocation{Country: "USA", State: "NY"}, IsEkkoTaxLiable: false, CarbonCreditRate: 0.08},
-		{ID: "9", MerchantLocation: MerchantLocation{Country: "USA", State: "TX"}, CustomerLocation: CustomerLocation{Country: "USA", State: "TX"}, IsEkkoTaxLiable: false, CarbonCreditRate: 0.0625},
+		{ID: "7", MerchantLocation: MerchantLocation{Country: "USA", State: "CA"}, CustomerLocation: CustomerLocation{Country: "USA", State: "CA"}, Regime: TaxRegimeSalesTax, IsEkkoTaxLiable: false, CarbonCreditRate: 0.0725},
+		{ID: "8", MerchantLocation: MerchantLocation{Country: "USA", State: "NY"}, CustomerLocation: CustomerLocation{Country: "USA", State: "NY"}, Regime: TaxRegimeSalesTax, IsEkkoTaxLiable: false, CarbonCreditRate: 0.08},
+		{ID: "9", MerchantLocation: MerchantLocation{Country: "USA", State: "TX"}, CustomerLocation: CustomerLocation{Country: "USA", State: "TX"}, Regime: TaxRegimeSalesTax, IsEkkoTaxLiable: false, CarbonCreditRate: 0.0625},
 		// Default for US without state
-		{ID: "10", MerchantLocation: MerchantLocation{Country: "USA"}, CustomerLocation: CustomerLocation{Country: "USA"}, IsEkkoTaxLiable: false, CarbonCreditRate: 0.0},
+		{ID: "10", MerchantLocation: MerchantLocation{Country: "USA"}, CustomerLocation: CustomerLocation{Country: "USA"}, Regime: TaxRegimeSalesTax, IsEkkoTaxLiable: false, CarbonCreditRate: 0.0},
 	}
 
-	for _, r := range rates {
-		key := r.MerchantLocation.Country + ":" + r.MerchantLocation.State + ":" + r.CustomerLocation.Country + ":" + r.CustomerLocation.State
-		repo.rates[key] = r
+	// Sample US zip-level combined rates (state + county + city + special district)
+	rates = append(rates,
+		newZipRate("CA", "90001", "LOS ANGELES", TaxRateComponents{State: 0.06, County: 0.0025, City: 0, SpecialDistrict: 0.0325}),
+		newZipRate("NY", "10001", "NEW YORK CITY", TaxRateComponents{State: 0.04, County: 0, City: 0.045, SpecialDistrict: 0.00375}),
+		newZipRate("TX", "73301", "AUSTIN", TaxRateComponents{State: 0.0625, County: 0, City: 0.01, SpecialDistrict: 0.01}),
+	)
+
+	if err := repo.SaveRates(context.Background(), rates); err != nil {
+		panic(fmt.Sprintf("salestax: invalid seed rates: %v", err))
 	}
 
 	return repo
 }
 
+// SaveRates stores or replaces tax rates. Rates with a customer zip code are
+// indexed by 5-digit zip, all others by merchant and customer country/state.
+// Every rate is validated first, so an invalid rate stores none of them.
+func (r *InMemoryRepository) SaveRates(_ context.Context, rates []*TaxRate) error {
+	zips := make([]string, len(rates))
+	for i, rate := range rates {
+		if rate.CustomerLocation.Zip == "" {
+			continue
+		}
+		zip, ok := normaliseZip(rate.CustomerLocation.Zip)
+		if !ok {
+			return errors.NewValidationError(domainName, "invalid zip code: "+rate.CustomerLocation.Zip)
+		}
+		zips[i] = zip
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, rate := range rates {
+		if zips[i] != "" {
+			r.zipRates[zips[i]] = rate
+			continue
+		}
+		key := rate.MerchantLocation.Country + ":" + rate.MerchantLocation.State + ":" + rate.CustomerLocation.Country + ":" + rate.CustomerLocation.State
+		r.rates[key] = rate
+	}
+	return nil
+}
+
 // GetTaxRate retrieves the tax rate for a location
 func (r *InMemoryRepository) GetTaxRate(_ context.Context, countryCode, state, postalCode string) (*TaxRate, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	// Try exact match first (merchant:customer)
 	key := countryCode + ":" + state + ":" + countryCode + ":" + state
-	if rate, exists := r.rates[key]; exists {
-		return rate, nil
+	stateRate, hasStateRate := r.rates[key]
+
+	// Try zip-level rate first (US only)
+	if countryCode == "USA" {
+		if zip, ok := normaliseZip(postalCode); ok {
+			if rate, exists := r.zipRates[zip]; exists {
+				// Liability is set per state, not per zip
+				if hasStateRate && rate.IsEkkoTaxLiable != stateRate.IsEkkoTaxLiable {
+					zipRate := *rate
+					zipRate.IsEkkoTaxLiable = stateRate.IsEkkoTaxLiable
+					return &zipRate, nil
+				}
+				return rate, nil
+			}
+		}
+	}
+
+	// Try state match (merchant:customer)
+	if hasStateRate {
+		return stateRate, nil
 	}
 
 	// Try country only (merchant:customer)
@@ -61,10 +121,59 @@ func (r *InMemoryRepository) GetTaxRate(_ context.Context, countryCode, state, p
 
 	// Return no tax as default
 	return &TaxRate{
-		ID:             "default",
+		ID:               "default",
 		MerchantLocation: MerchantLocation{Country: countryCode, State: state, Zip: postalCode},
 		CustomerLocation: CustomerLocation{Country: countryCode, State: state, Zip: postalCode},
-		IsEkkoTaxLiable: false,
+		IsEkkoTaxLiable:  false,
 		CarbonCreditRate: 0,
 	}, nil
 }
+
+// InMemoryExemptionRepository implements ExemptionRepository interface
+type InMemoryExemptionRepository struct {
+	certificates map[string][]*ExemptionCertificate // key: holderType:holderID
+	mu           sync.RWMutex
+}
+
+// NewInMemoryExemptionRepository creates a new repository
+func NewInMemoryExemptionRepository() *InMemoryExemptionRepository {
+	return &InMemoryExemptionRepository{
+		certificates: make(map[string][]*ExemptionCertificate),
+	}
+}
+
+// Create stores a new exemption certificate
+func (r *InMemoryExemptionRepository) Create(_ context.Context, certificate *ExemptionCertificate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := string(certificate.HolderType) + ":" + certificate.HolderID
+	r.certificates[key] = append(r.certificates[key], certificate)
+	return nil
+}
+
+// Update replaces a stored exemption certificate
+func (r *InMemoryExemptionRepository) Update(_ context.Context, certificate *ExemptionCertificate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := string(certificate.HolderType) + ":" + certificate.HolderID
+	for i, existing := range r.certificates[key] {
+		if existing.ID == certificate.ID {
+			r.certificates[key][i] = certificate
+			return nil
+		}
+	}
+	return errors.NewNotFoundError(domainName, "exemption certificate not found")
+}
+
+// GetByHolder retrieves all exemption certificates for a customer or organisation
+func (r *InMemoryExemptionRepository) GetByHolder(_ context.Context, holderType ExemptionHolderType, holderID string) ([]*ExemptionCertificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	key := string(holderType) + ":" + holderID
+	certificates := make([]*ExemptionCertificate, len(r.certificates[key]))
+	copy(certificates, r.certificates[key])
+	return certificates, nil
+}
@@ -4,27 +4,60 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"time"
+
+	"api-golang/internal/shared/errors"
 
 	"github.com/bilo-mono/packages/common/service"
+
+	"github.com/google/uuid"
 )
 
 // DefaultService implements the Service interface
 type DefaultService struct {
 	service.BaseService[Repository]
+	exemptionRepo ExemptionRepository
+	postalCodes   PostalCodeNormaliser
+	holders       HolderAccess
 }
 
-// NewService creates a new sales tax service
-func NewService(repo Repository) *DefaultService {
+// NewService creates a new sales tax service.
+// postalCodes may be nil, in which case postal codes are used as given.
+// holders checks that callers may manage the exemptions of an organisation or customer.
+func NewService(repo Repository, exemptionRepo ExemptionRepository, postalCodes PostalCodeNormaliser, holders HolderAccess) *DefaultService {
 	return &DefaultService{
-		BaseService: service.NewBaseService(repo),
+		BaseService:   service.NewBaseService(repo),
+		exemptionRepo: exemptionRepo,
+		postalCodes:   postalCodes,
+		holders:       holders,
 	}
 }
 
 // CalculateSalesTax calculates sales tax based on merchant and customer locations
 // Tax jurisdiction is typically based on the customer's location for digital services
 func (s *DefaultService) CalculateSalesTax(ctx context.Context, input TaxCalculationInput) (*TaxResult, error) {
+	// Normalise the customer postal code so equivalent spellings hit the same rate
+	customerPostalCode := input.CustomerPostalCode
+	if customerPostalCode != "" && s.postalCodes != nil {
+		normalised, err := s.postalCodes.NormalisePostalCode(ctx, input.CustomerCountry, customerPostalCode)
+		if err != nil {
+			var domainErr *errors.DomainError
+			if errors.IsDomainError(err, &domainErr) && domainErr.Code == errors.ErrCodeValidation {
+				return nil, errors.NewFieldValidationError(domainName, "customerPostalCode", domainErr.Message)
+			}
+			return nil, fmt.Errorf("normalising customer postal code: %w", err)
+		}
+		customerPostalCode = normalised
+	}
+	if customerPostalCode != "" && input.CustomerCountry == "USA" {
+		if _, ok := normaliseZip(customerPostalCode); !ok {
+			return nil, errors.NewFieldValidationError(domainName, "customerPostalCode",
+				fmt.Sprintf("invalid zip code %q: must be 5 digits or ZIP+4", customerPostalCode))
+		}
+	}
+
 	// For B2C digital services, use customer location for tax calculation
-	taxRate, err := s.Repo.GetTaxRate(ctx, input.CustomerCountry, input.CustomerState, input.CustomerPostalCode)
+	taxRate, err := s.Repo.GetTaxRate(ctx, input.CustomerCountry, input.CustomerState, customerPostalCode)
 	if err != nil {
 		return nil, fmt.Errorf("getting tax rate: %w", err)
 	}
@@ -44,6 +77,26 @@ func (s *DefaultService) CalculateSalesTax(ctx context.Context, input TaxCalcula
 			TaxAmount:     0,
 			TaxName:       "N/A",
 			IsApplicable:  false,
+
+			IsMerchantTaxLiable: isMerchantTaxLiable(taxRate, input),
+		}, nil
+	}
+
+	// Zero-rate the sale if the customer or organisation holds a valid exemption
+	certificate, err := s.findExemption(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("checking tax exemptions: %w", err)
+	}
+	if certificate != nil {
+		return &TaxResult{
+			TaxableAmount:          input.Amount,
+			TaxRate:                0,
+			TaxAmount:              0,
+			TaxName:                "Exempt",
+			IsApplicable:           false,
+			ExemptionCertificateID: certificate.ID,
+
+			IsMerchantTaxLiable: isMerchantTaxLiable(taxRate, input),
 		}, nil
 	}
 
@@ -59,5 +112,197 @@ func (s *DefaultService) CalculateSalesTax(ctx context.Context, input TaxCalcula
 		TaxAmount:     taxAmount,
 		TaxName:       "Sales Tax",
 		IsApplicable:  true,
+		Components:    componentBreakdown(taxRate.Components, input.Amount),
+
+		IsMerchantTaxLiable: isMerchantTaxLiable(taxRate, input),
 	}, nil
 }
+
+// componentBreakdown splits the tax on amount across state, county, city and special district
+func componentBreakdown(components *TaxRateComponents, amount float64) []TaxComponent {
+	if components == nil {
+		return nil
+	}
+
+	breakdown := make([]TaxComponent, 0, 4)
+	for _, c := range []struct {
+		jurisdiction string
+		rate         float64
+	}{
+		{"state", components.State},
+		{"county", components.County},
+		{"city", components.City},
+		{"specialDistrict", components.SpecialDistrict},
+	} {
+		if c.rate == 0 {
+			continue
+		}
+		breakdown = append(breakdown, TaxComponent{
+			Jurisdiction: c.jurisdiction,
+			TaxRate:      c.rate,
+			TaxAmount:    math.Round(amount*c.rate*100) / 100,
+		})
+	}
+	return breakdown
+}
+
+// isMerchantTaxLiable determines whether the merchant or Ekko remits the tax,
+// based on the merchant's tax registration and the jurisdiction's tax regime
+func isMerchantTaxLiable(taxRate *TaxRate, input TaxCalculationInput) bool {
+	// The jurisdiction makes Ekko the collecting party (e.g. marketplace facilitator rules)
+	if taxRate.IsEkkoTaxLiable {
+		return false
+	}
+
+	// Merchants without a tax registration cannot remit, so Ekko collects
+	if input.MerchantTaxNumber == "" {
+		return false
+	}
+
+	switch taxRate.Regime {
+	case TaxRegimeVAT:
+		// Domestic supplies are accounted for by the registered merchant,
+		// cross-border B2C supplies fall under Ekko's OSS registration
+		return input.MerchantCountry == input.CustomerCountry
+	case TaxRegimeSalesTax:
+		// Registered merchants collect where they have nexus in the customer's state
+		return input.MerchantCountry == input.CustomerCountry && input.MerchantState == input.CustomerState
+	default:
+		return false
+	}
+}
+
+// findExemption returns the first valid exemption certificate covering the customer's
+// jurisdiction, checking customer certificates before organisation certificates
+func (s *DefaultService) findExemption(ctx context.Context, input TaxCalculationInput) (*ExemptionCertificate, error) {
+	now := time.Now()
+	holders := []struct {
+		holderType ExemptionHolderType
+		holderID   string
+	}{
+		{ExemptionHolderCustomer, input.CustomerID},
+		{ExemptionHolderOrganisation, input.OrganisationID},
+	}
+
+	for _, holder := range holders {
+		if holder.holderID == "" {
+			continue
+		}
+		certificates, err := s.exemptionRepo.GetByHolder(ctx, holder.holderType, holder.holderID)
+		if err != nil {
+			return nil, err
+		}
+		for _, certificate := range certificates {
+			if certificate.Covers(input.CustomerCountry, input.CustomerState, now) {
+				return certificate, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// RegisterExemption validates and stores a new exemption certificate for the
+// caller's organisation, one of its descendants or one of their customers
+func (s *DefaultService) RegisterExemption(ctx context.Context, callerOrgID string, input RegisterExemptionInput) (*ExemptionCertificate, error) {
+	if err := s.validateHolder(ctx, callerOrgID, input.HolderType, input.HolderID); err != nil {
+		return nil, err
+	}
+	if len(input.Country) != 3 {
+		return nil, errors.NewValidationError(domainName, "country must be an ISO 3166-1 alpha-3 code")
+	}
+	switch input.Reason {
+	case ExemptionReasonResale, ExemptionReasonNonProfit, ExemptionReasonGovernment, ExemptionReasonOther:
+	default:
+		return nil, errors.NewValidationError(domainName, fmt.Sprintf("invalid exemption reason: %s", input.Reason))
+	}
+
+	now := time.Now()
+	validFrom := now
+	if input.ValidFrom != nil {
+		validFrom = *input.ValidFrom
+	}
+	if input.ValidTo != nil && !input.ValidTo.After(validFrom) {
+		return nil, errors.NewValidationError(domainName, "validTo must be after validFrom")
+	}
+
+	certificate := &ExemptionCertificate{
+		ID:                uuid.New().String(),
+		HolderType:        input.HolderType,
+		HolderID:          input.HolderID,
+		Country:           input.Country,
+		State:             input.State,
+		Reason:            input.Reason,
+		CertificateNumber: input.CertificateNumber,
+		ValidFrom:         validFrom,
+		ValidTo:           input.ValidTo,
+		CreatedAt:         now,
+	}
+
+	if err := s.exemptionRepo.Create(ctx, certificate); err != nil {
+		return nil, fmt.Errorf("creating exemption certificate: %w", err)
+	}
+	return certificate, nil
+}
+
+// GetExemptions retrieves all exemption certificates for a customer or
+// organisation the caller can act for
+func (s *DefaultService) GetExemptions(ctx context.Context, callerOrgID string, holderType ExemptionHolderType, holderID string) ([]*ExemptionCertificate, error) {
+	if err := s.validateHolder(ctx, callerOrgID, holderType, holderID); err != nil {
+		return nil, err
+	}
+
+	certificates, err := s.exemptionRepo.GetByHolder(ctx, holderType, holderID)
+	if err != nil {
+		return nil, fmt.Errorf("getting exemption certificates: %w", err)
+	}
+	return certificates, nil
+}
+
+// ErasePersonalData removes the certificate numbers, which identify the
+// customer to the tax authority, from a customer's exemption certificates. The
+// jurisdiction, reason and validity are kept so zero-rated quotes can still be
+// accounted for.
+func (s *DefaultService) ErasePersonalData(ctx context.Context, customerID string) (int, error) {
+	certificates, err := s.exemptionRepo.GetByHolder(ctx, ExemptionHolderCustomer, customerID)
+	if err != nil {
+		return 0, fmt.Errorf("getting exemption certificates: %w", err)
+	}
+
+	for _, certificate := range certificates {
+		erased := *certificate
+		erased.CertificateNumber = ""
+		if err := s.exemptionRepo.Update(ctx, &erased); err != nil {
+			return 0, fmt.Errorf("updating exemption certificate %s: %w", certificate.ID, err)
+		}
+	}
+	return len(certificates), nil
+}
+
+// validateHolder checks that the caller may act for an exemption holder: an
+// organisation in its subtree, or a customer of one. Customers of other
+// organisations are reported as not found, as for other single resources.
+func (s *DefaultService) validateHolder(ctx context.Context, callerOrgID string, holderType ExemptionHolderType, holderID string) error {
+	if holderType != ExemptionHolderCustomer && holderType != ExemptionHolderOrganisation {
+		return errors.NewValidationError(domainName, "holderType must be customer or organisation")
+	}
+	if holderID == "" {
+		return errors.NewValidationError(domainName, "holderId is required")
+	}
+
+	if holderType == ExemptionHolderOrganisation {
+		return s.holders.ValidateOrganisation(ctx, callerOrgID, holderID)
+	}
+
+	organisationID, err := s.holders.GetCustomerOrganisationID(ctx, holderID)
+	if err != nil {
+		return err
+	}
+	if err := s.holders.ValidateOrganisation(ctx, callerOrgID, organisationID); err != nil {
+		var domainErr *errors.DomainError
+		if errors.IsDomainError(err, &domainErr) && domainErr.Code == errors.ErrCodeForbidden {
+			return errors.NewNotFoundError(domainName, "customer not found")
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,310 @@
+package salestax
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"api-golang/internal/shared/errors"
+)
+
+// fakeHolders models org-parent-1 with children org-child-1 and org-child-2,
+// and a customer of org-child-1
+type fakeHolders struct{}
+
+const testCustomerID = "cust-1"
+
+func (fakeHolders) ValidateOrganisation(_ context.Context, callerOrgID, orgID string) error {
+	parents := map[string]string{"org-parent-1": "", "org-child-1": "org-parent-1", "org-child-2": "org-parent-1"}
+	if _, exists := parents[orgID]; !exists {
+		return errors.NewNotFoundError("organisation", "organisation not found")
+	}
+	if orgID != callerOrgID && parents[orgID] != callerOrgID {
+		return errors.NewForbiddenError("organisation", "organisation is outside the caller's hierarchy")
+	}
+	return nil
+}
+
+func (fakeHolders) GetCustomerOrganisationID(_ context.Context, customerID string) (string, error) {
+	if customerID != testCustomerID {
+		return "", errors.NewNotFoundError("customer", "customer not found")
+	}
+	return "org-child-1", nil
+}
+
+func newTestService() *DefaultService {
+	return NewService(NewInMemoryRepository(), NewInMemoryExemptionRepository(), nil, fakeHolders{})
+}
+
+func TestCalculateSalesTax_MerchantTaxLiability(t *testing.T) {
+	ctx := context.Background()
+	repo := NewInMemoryRepository()
+	// A jurisdiction where Ekko collects regardless of the merchant's registration
+	if err := repo.SaveRates(ctx, []*TaxRate{{
+		ID:               "ekko-liable",
+		MerchantLocation: MerchantLocation{Country: "USA", State: "WA"},
+		CustomerLocation: CustomerLocation{Country: "USA", State: "WA"},
+		Regime:           TaxRegimeSalesTax,
+		IsEkkoTaxLiable:  true,
+		CarbonCreditRate: 0.065,
+	}}); err != nil {
+		t.Fatalf("SaveRates failed: %v", err)
+	}
+	service := NewService(repo, NewInMemoryExemptionRepository(), nil, fakeHolders{})
+
+	tests := []struct {
+		name  string
+		input TaxCalculationInput
+		want  bool
+	}{
+		{
+			name: "Domestic VAT with registered merchant",
+			input: TaxCalculationInput{
+				MerchantCountry: "GBR", CustomerCountry: "GBR",
+				MerchantTaxNumber: "GB123456789", Amount: 10,
+			},
+			want: true,
+		},
+		{
+			name: "Domestic VAT with unregistered merchant",
+			input: TaxCalculationInput{
+				MerchantCountry: "GBR", CustomerCountry: "GBR",
+				Amount: 10,
+			},
+			want: false,
+		},
+		{
+			name: "Cross-border VAT",
+			input: TaxCalculationInput{
+				MerchantCountry: "GBR", CustomerCountry: "DEU",
+				MerchantTaxNumber: "GB123456789", Amount: 10,
+			},
+			want: false,
+		},
+		{
+			name: "US sales tax with nexus in customer state",
+			input: TaxCalculationInput{
+				MerchantCountry: "USA", MerchantState: "CA", CustomerCountry: "USA", CustomerState: "CA",
+				MerchantTaxNumber: "CA-123", Amount: 10,
+			},
+			want: true,
+		},
+		{
+			name: "Jurisdiction makes Ekko liable",
+			input: TaxCalculationInput{
+				MerchantCountry: "USA", MerchantState: "WA", CustomerCountry: "USA", CustomerState: "WA",
+				MerchantTaxNumber: "WA-123", Amount: 10,
+			},
+			want: false,
+		},
+		{
+			name: "Zero-rated US state with nexus in customer state",
+			input: TaxCalculationInput{
+				MerchantCountry: "USA", MerchantState: "OR", CustomerCountry: "USA", CustomerState: "OR",
+				MerchantTaxNumber: "OR-123", Amount: 10,
+			},
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := service.CalculateSalesTax(ctx, tt.input)
+			if err != nil {
+				t.Fatalf("CalculateSalesTax failed: %v", err)
+			}
+			if result.IsMerchantTaxLiable != tt.want {
+				t.Errorf("IsMerchantTaxLiable = %v, want %v", result.IsMerchantTaxLiable, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalculateSalesTax_ZipLevelRate(t *testing.T) {
+	service := newTestService()
+	ctx := context.Background()
+
+	result, err := service.CalculateSalesTax(ctx, TaxCalculationInput{
+		MerchantCountry: "USA", MerchantState: "CA",
+		CustomerCountry: "USA", CustomerState: "CA", CustomerPostalCode: "90001-1234",
+		Amount: 100,
+	})
+	if err != nil {
+		t.Fatalf("CalculateSalesTax failed: %v", err)
+	}
+	if result.TaxAmount != 9.50 {
+		t.Errorf("Expected combined tax 9.50, got %.2f", result.TaxAmount)
+	}
+	if len(result.Components) != 3 {
+		t.Fatalf("Expected 3 non-zero components, got %d", len(result.Components))
+	}
+	if result.Components[0].Jurisdiction != "state" || result.Components[0].TaxAmount != 6.00 {
+		t.Errorf("Expected state component 6.00, got %+v", result.Components[0])
+	}
+
+	// Unknown zip falls back to the state rate
+	result, err = service.CalculateSalesTax(ctx, TaxCalculationInput{
+		CustomerCountry: "USA", CustomerState: "CA", CustomerPostalCode: "94105",
+		Amount: 100,
+	})
+	if err != nil {
+		t.Fatalf("CalculateSalesTax failed: %v", err)
+	}
+	if result.TaxRate != 0.0725 {
+		t.Errorf("Expected CA state rate 0.0725, got %v", result.TaxRate)
+	}
+	if result.Components != nil {
+		t.Errorf("Expected no component breakdown for state rate, got %+v", result.Components)
+	}
+
+	// Short zip codes are ambiguous and rejected rather than padded
+	_, err = service.CalculateSalesTax(ctx, TaxCalculationInput{
+		CustomerCountry: "USA", CustomerState: "CA", CustomerPostalCode: "9001",
+		Amount: 100,
+	})
+	var domainErr *errors.DomainError
+	if !errors.IsDomainError(err, &domainErr) || domainErr.Field != "customerPostalCode" {
+		t.Errorf("Expected customerPostalCode validation error, got %v", err)
+	}
+}
+
+func TestCalculateSalesTax_AppliesExemption(t *testing.T) {
+	service := newTestService()
+	ctx := context.Background()
+	caller := "org-parent-1"
+
+	expired := time.Now().Add(-time.Hour)
+	if _, err := service.RegisterExemption(ctx, caller, RegisterExemptionInput{
+		HolderType: ExemptionHolderCustomer,
+		HolderID:   testCustomerID,
+		Country:    "GBR",
+		Reason:     ExemptionReasonResale,
+		ValidFrom:  timePtr(expired.Add(-24 * time.Hour)),
+		ValidTo:    &expired,
+	}); err != nil {
+		t.Fatalf("RegisterExemption failed: %v", err)
+	}
+	certificate, err := service.RegisterExemption(ctx, caller, RegisterExemptionInput{
+		HolderType: ExemptionHolderOrganisation,
+		HolderID:   "org-child-1",
+		Country:    "GBR",
+		Reason:     ExemptionReasonNonProfit,
+	})
+	if err != nil {
+		t.Fatalf("RegisterExemption failed: %v", err)
+	}
+
+	tests := []struct {
+		name                  string
+		input                 TaxCalculationInput
+		wantCertificate       string
+		wantMerchantTaxLiable bool
+	}{
+		{
+			name:            "Organisation exemption applies",
+			input:           TaxCalculationInput{CustomerCountry: "GBR", OrganisationID: "org-child-1", CustomerID: testCustomerID, Amount: 10},
+			wantCertificate: certificate.ID,
+		},
+		{
+			name: "Exempt sale keeps the merchant's tax liability",
+			input: TaxCalculationInput{
+				MerchantCountry: "GBR", CustomerCountry: "GBR", MerchantTaxNumber: "GB123456789",
+				OrganisationID: "org-child-1", Amount: 10,
+			},
+			wantCertificate:       certificate.ID,
+			wantMerchantTaxLiable: true,
+		},
+		{
+			name:  "Expired customer exemption is ignored",
+			input: TaxCalculationInput{CustomerCountry: "GBR", OrganisationID: "org-child-2", CustomerID: testCustomerID, Amount: 10},
+		},
+		{
+			name:  "Exemption outside jurisdiction is ignored",
+			input: TaxCalculationInput{CustomerCountry: "DEU", OrganisationID: "org-child-1", Amount: 10},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := service.CalculateSalesTax(ctx, tt.input)
+			if err != nil {
+				t.Fatalf("CalculateSalesTax failed: %v", err)
+			}
+			if result.ExemptionCertificateID != tt.wantCertificate {
+				t.Errorf("ExemptionCertificateID = %q, want %q", result.ExemptionCertificateID, tt.wantCertificate)
+			}
+			if tt.wantCertificate != "" && result.TaxAmount != 0 {
+				t.Errorf("Expected zero tax for exempt sale, got %.2f", result.TaxAmount)
+			}
+			if tt.wantCertificate == "" && result.TaxAmount == 0 {
+				t.Error("Expected tax for non-exempt sale")
+			}
+			if result.IsMerchantTaxLiable != tt.wantMerchantTaxLiable {
+				t.Errorf("IsMerchantTaxLiable = %v, want %v", result.IsMerchantTaxLiable, tt.wantMerchantTaxLiable)
+			}
+		})
+	}
+}
+
+func TestRegisterExemption_Validation(t *testing.T) {
+	service := newTestService()
+
+	_, err := service.RegisterExemption(context.Background(), "org-child-1", RegisterExemptionInput{
+		HolderType: ExemptionHolderCustomer,
+		HolderID:   testCustomerID,
+		Country:    "GBR",
+		Reason:     "unknown",
+	})
+	if err == nil {
+		t.Error("Expected validation error for unknown reason")
+	}
+}
+
+func TestExemptions_HolderAccess(t *testing.T) {
+	service := newTestService()
+	ctx := context.Background()
+
+	tests := []struct {
+		name       string
+		caller     string
+		holderType ExemptionHolderType
+		holderID   string
+		wantCode   string
+	}{
+		{name: "Own organisation", caller: "org-child-1", holderType: ExemptionHolderOrganisation, holderID: "org-child-1"},
+		{name: "Descendant organisation", caller: "org-parent-1", holderType: ExemptionHolderOrganisation, holderID: "org-child-2"},
+		{name: "Ancestor organisation", caller: "org-child-1", holderType: ExemptionHolderOrganisation, holderID: "org-parent-1", wantCode: errors.ErrCodeForbidden},
+		{name: "Own customer", caller: "org-child-1", holderType: ExemptionHolderCustomer, holderID: testCustomerID},
+		{name: "Sibling's customer", caller: "org-child-2", holderType: ExemptionHolderCustomer, holderID: testCustomerID, wantCode: errors.ErrCodeNotFound},
+		{name: "Unknown customer", caller: "org-parent-1", holderType: ExemptionHolderCustomer, holderID: "missing", wantCode: errors.ErrCodeNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, registerErr := service.RegisterExemption(ctx, tt.caller, RegisterExemptionInput{
+				HolderType: tt.holderType,
+				HolderID:   tt.holderID,
+				Country:    "GBR",
+				Reason:     ExemptionReasonResale,
+			})
+			_, getErr := service.GetExemptions(ctx, tt.caller, tt.holderType, tt.holderID)
+
+			for _, err := range []error{registerErr, getErr} {
+				if tt.wantCode == "" {
+					if err != nil {
+						t.Errorf("Expected access, got %v", err)
+					}
+					continue
+				}
+				var domainErr *errors.DomainError
+				if !errors.IsDomainError(err, &domainErr) || domainErr.Code != tt.wantCode {
+					t.Errorf("Expected %s error, got %v", tt.wantCode, err)
+				}
+			}
+		})
+	}
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
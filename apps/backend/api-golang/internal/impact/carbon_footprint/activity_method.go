@@ -0,0 +1,269 @@
+package carbonfootprint
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"api-golang/internal/platform/airport"
+	"api-golang/internal/shared/errors"
+)
+
+// ============================================
+// Flights
+// ============================================
+
+const (
+	earthRadiusKm = 6371.0
+	// Great-circle distances are uplifted to account for routing and stacking
+	flightDistanceUplift = 1.08
+	// Haul thresholds (great-circle km) used to pick flight factors
+	domesticMaxKm  = 500.0
+	shortHaulMaxKm = 3700.0
+	// Load factors and radiative forcing make flight factors less certain than fuel
+	flightUncertainty = 0.2
+)
+
+// Flight cabin classes
+const (
+	CabinEconomy        = "economy"
+	CabinPremiumEconomy = "premiumEconomy"
+	CabinBusiness       = "business"
+	CabinFirst          = "first"
+)
+
+// flightFactors holds kg CO2e per passenger-km (including radiative forcing)
+// by haul and cabin class, from the UK DEFRA/DESNZ 2024 conversion factors
+var flightFactors = map[string]map[string]float64{
+	"domestic": {
+		CabinEconomy: 0.246,
+	},
+	"shortHaul": {
+		CabinEconomy:        0.151,
+		CabinPremiumEconomy: 0.151,
+		CabinBusiness:       0.227,
+		CabinFirst:          0.227,
+	},
+	"longHaul": {
+		CabinEconomy:        0.148,
+		CabinPremiumEconomy: 0.237,
+		CabinBusiness:       0.429,
+		CabinFirst:          0.592,
+	},
+}
+
+// FlightMethod calculates flight footprints from the great-circle distance
+// between the origin and destination airports
+type FlightMethod struct {
+	airports AirportValidator
+}
+
+// NewFlightMethod creates a new flight calculation method
+func NewFlightMethod(airports AirportValidator) *FlightMethod {
+	return &FlightMethod{
+		airports: airports,
+	}
+}
+
+// Name returns the calculation method name
+func (m *FlightMethod) Name() string {
+	return calculationMethodFlight
+}
+
+// Calculate calculates the footprint as distance x passengers x cabin class factor
+func (m *FlightMethod) Calculate(ctx context.Context, input CalculateInput, footprint *Footprint) (float64, error) {
+	if input.Activity == nil || input.Activity.Flight == nil {
+		return 0, errors.NewValidationError(domainName, "flight activity is required for the flight method")
+	}
+	flight := *input.Activity.Flight
+
+	origin, err := m.airports.ValidateAirport(ctx, flight.Origin)
+	if err != nil {
+		return 0, fieldError("activity.flight.origin", err)
+	}
+	destination, err := m.airports.ValidateAirport(ctx, flight.Destination)
+	if err != nil {
+		return 0, fieldError("activity.flight.destination", err)
+	}
+	if origin.IATACode == destination.IATACode {
+		return 0, errors.NewFieldValidationError(domainName, "activity.flight.destination", "destination must differ from origin")
+	}
+	flight.Origin, flight.Destination = origin.IATACode, destination.IATACode
+	if flight.Passengers == 0 {
+		flight.Passengers = 1
+	}
+	if flight.Passengers < 0 {
+		return 0, errors.NewFieldValidationError(domainName, "activity.flight.passengers", "passengers must be positive")
+	}
+	if flight.CabinClass == "" {
+		flight.CabinClass = CabinEconomy
+	}
+
+	distanceKm := greatCircleKm(origin, destination)
+	haul := "longHaul"
+	switch {
+	case distanceKm <= domesticMaxKm:
+		haul = "domestic"
+	case distanceKm <= shortHaulMaxKm:
+		haul = "shortHaul"
+	}
+	if _, ok := flightFactors["longHaul"][flight.CabinClass]; !ok {
+		return 0, errors.NewFieldValidationError(domainName, "activity.flight.cabinClass", fmt.Sprintf("unknown cabin class %q", flight.CabinClass))
+	}
+	factor, ok := flightFactors[haul][flight.CabinClass]
+	if !ok {
+		// Domestic flights only publish an average cabin factor
+		factor = flightFactors[haul][CabinEconomy]
+	}
+
+	distanceKm *= flightDistanceUplift
+	if flight.Return {
+		distanceKm *= 2
+	}
+	flight.DistanceKm = math.Round(distanceKm*10) / 10
+
+	footprint.Activity = &Activity{Flight: &flight}
+	setActivityFactor(footprint, "flight:"+haul+":"+flight.CabinClass, factor, DataQualityActivity, flightUncertainty)
+	return distanceKm * float64(flight.Passengers) * factor, nil
+}
+
+// greatCircleKm returns the haversine distance between two airports
+func greatCircleKm(from, to *airport.Entity) float64 {
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+	dLat := toRad(to.Latitude - from.Latitude)
+	dLon := toRad(to.Longitude - from.Longitude)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRad(from.Latitude))*math.Cos(toRad(to.Latitude))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(a))
+}
+
+// ============================================
+// Fuel
+// ============================================
+
+// fuelFactors holds kg CO2e per litre by fuel type, from the UK DEFRA/DESNZ
+// 2024 conversion factors
+var fuelFactors = map[string]float64{
+	"petrol":     2.09,
+	"diesel":     2.51,
+	"lpg":        1.56,
+	"biodiesel":  0.17,
+	"bioethanol": 0.01,
+	"kerosene":   2.54,
+}
+
+// fuelUncertainty covers fuel blend variation; combustion factors are well known
+const fuelUncertainty = 0.05
+
+// FuelMethod calculates fuel footprints from the volume purchased
+type FuelMethod struct{}
+
+// NewFuelMethod creates a new fuel calculation method
+func NewFuelMethod() *FuelMethod {
+	return &FuelMethod{}
+}
+
+// Name returns the calculation method name
+func (m *FuelMethod) Name() string {
+	return calculationMethodFuel
+}
+
+// Calculate calculates the footprint as litres x fuel type factor
+func (m *FuelMethod) Calculate(_ context.Context, input CalculateInput, footprint *Footprint) (float64, error) {
+	if input.Activity == nil || input.Activity.Fuel == nil {
+		return 0, errors.NewValidationError(domainName, "fuel activity is required for the fuel method")
+	}
+	fuel := *input.Activity.Fuel
+	fuel.FuelType = strings.ToLower(fuel.FuelType)
+
+	factor, ok := fuelFactors[fuel.FuelType]
+	if !ok {
+		return 0, errors.NewFieldValidationError(domainName, "activity.fuel.fuelType", fmt.Sprintf("unknown fuel type %q", fuel.FuelType))
+	}
+	if fuel.Litres <= 0 {
+		return 0, errors.NewFieldValidationError(domainName, "activity.fuel.litres", "litres must be positive")
+	}
+
+	footprint.Activity = &Activity{Fuel: &fuel}
+	setActivityFactor(footprint, "fuel:"+fuel.FuelType, factor, DataQualityActivity, fuelUncertainty)
+	return fuel.Litres * factor, nil
+}
+
+// ============================================
+// Electricity
+// ============================================
+
+// defaultGridFactor is the world average, used for countries without a factor
+const (
+	defaultGridFactor      = 0.436
+	gridUncertainty        = 0.1
+	defaultGridUncertainty = 0.5
+)
+
+// gridFactors holds kg CO2e per kWh by ISO-3 country, from the IEA 2024
+// emissions factors
+var gridFactors = map[string]float64{
+	"AUS": 0.631, "AUT": 0.111, "BEL": 0.143, "CAN": 0.110, "CHE": 0.030,
+	"DEU": 0.381, "DNK": 0.131, "ESP": 0.146, "FIN": 0.079, "FRA": 0.056,
+	"GBR": 0.207, "IRL": 0.296, "ITA": 0.257, "JPN": 0.457, "NLD": 0.268,
+	"NOR": 0.008, "NZL": 0.098, "POL": 0.662, "PRT": 0.156, "SWE": 0.013,
+	"USA": 0.367, "IND": 0.713, "CHN": 0.582, "ZAF": 0.709, "BRA": 0.061,
+}
+
+// ElectricityMethod calculates electricity footprints from consumption and
+// the grid intensity of the supplying country
+type ElectricityMethod struct{}
+
+// NewElectricityMethod creates a new electricity calculation method
+func NewElectricityMethod() *ElectricityMethod {
+	return &ElectricityMethod{}
+}
+
+// Name returns the calculation method name
+func (m *ElectricityMethod) Name() string {
+	return calculationMethodElectricity
+}
+
+// Calculate calculates the footprint as kWh x grid factor
+func (m *ElectricityMethod) Calculate(_ context.Context, input CalculateInput, footprint *Footprint) (float64, error) {
+	if input.Activity == nil || input.Activity.Electricity == nil {
+		return 0, errors.NewValidationError(domainName, "electricity activity is required for the electricity method")
+	}
+	electricity := *input.Activity.Electricity
+	if electricity.KWh <= 0 {
+		return 0, errors.NewFieldValidationError(domainName, "activity.electricity.kWh", "kWh must be positive")
+	}
+	if electricity.CountryID == "" {
+		electricity.CountryID = input.CountryID
+	}
+
+	factor, ok := gridFactors[strings.ToUpper(electricity.CountryID)]
+	quality, uncertainty := DataQualityActivity, gridUncertainty
+	if !ok {
+		// Measured consumption, but a guessed grid mix
+		factor = defaultGridFactor
+		quality, uncertainty = DataQualityDefault, defaultGridUncertainty
+	}
+
+	footprint.Activity = &Activity{Electricity: &electricity}
+	setActivityFactor(footprint, "electricity:"+electricity.CountryID, factor, quality, uncertainty)
+	return electricity.KWh * factor, nil
+}
+
+// setActivityFactor records an activity factor on the footprint
+func setActivityFactor(footprint *Footprint, factorID string, factor float64, quality DataQuality, uncertainty float64) {
+	footprint.Factor = factor
+	footprint.FactorID = factorID
+	footprint.DataQuality = quality
+	footprint.Uncertainty = uncertainty
+}
+
+// fieldError attaches the request field to a validation error from another domain
+func fieldError(field string, err error) error {
+	var domainErr *errors.DomainError
+	if errors.IsDomainError(err, &domainErr) && domainErr.Code == errors.ErrCodeValidation {
+		return errors.NewFieldValidationError(domainName, field, domainErr.Message)
+	}
+	return fmt.Errorf("%s: %w", field, err)
+}
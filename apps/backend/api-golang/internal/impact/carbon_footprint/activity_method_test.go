@@ -0,0 +1,133 @@
+package carbonfootprint
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"api-golang/internal/platform/airport"
+	"api-golang/internal/shared/errors"
+)
+
+func TestCalculate_ActivityMethods(t *testing.T) {
+	service := NewService(NewInMemoryFactorRepository(), NewInMemoryCategoryRepository(), NewInMemoryNatureFactorRepository(), NewInMemoryFootprintRepository(), airport.NewService(airport.NewInMemoryRepository()))
+	ctx := context.Background()
+
+	t.Run("flight", func(t *testing.T) {
+		footprint, err := service.Calculate(ctx, CalculateInput{
+			AmountEUR: 600,
+			MCC:       "4511",
+			CountryID: "GBR",
+			Activity: &Activity{Flight: &FlightActivity{
+				Origin:      "LHR",
+				Destination: "jfk",
+				CabinClass:  CabinBusiness,
+				Passengers:  2,
+			}},
+		})
+		if err != nil {
+			t.Fatalf("Calculate failed: %v", err)
+		}
+		if footprint.CalculationMethod != calculationMethodFlight {
+			t.Errorf("Expected flight method, got %q", footprint.CalculationMethod)
+		}
+		// LHR-JFK is roughly 5,540 km great-circle, uplifted by 8%
+		distance := footprint.Activity.Flight.DistanceKm
+		if distance < 5900 || distance > 6050 {
+			t.Errorf("Unexpected distance %v km", distance)
+		}
+		want := distance * 2 * 0.429 * 1000
+		if math.Abs(footprint.CarbonCo2eGrams-want) > 100 {
+			t.Errorf("Expected ~%v grams, got %v", want, footprint.CarbonCo2eGrams)
+		}
+	})
+
+	t.Run("flight between regional airports", func(t *testing.T) {
+		footprint, err := service.Calculate(ctx, CalculateInput{
+			MCC:       "4511",
+			CountryID: "GBR",
+			Activity:  &Activity{Flight: &FlightActivity{Origin: "bhd", Destination: "INV"}},
+		})
+		if err != nil {
+			t.Fatalf("Calculate failed: %v", err)
+		}
+		flight := footprint.Activity.Flight
+		if flight.Origin != "BHD" || flight.Destination != "INV" {
+			t.Errorf("Expected route BHD-INV, got %s-%s", flight.Origin, flight.Destination)
+		}
+		if footprint.FactorID != "flight:domestic:economy" {
+			t.Errorf("Expected domestic economy factor, got %q", footprint.FactorID)
+		}
+	})
+
+	t.Run("flight to the same airport", func(t *testing.T) {
+		_, err := service.Calculate(ctx, CalculateInput{
+			Activity: &Activity{Flight: &FlightActivity{Origin: "LHR", Destination: "lhr"}},
+		})
+		var domainErr *errors.DomainError
+		if !errors.IsDomainError(err, &domainErr) || domainErr.Field != "activity.flight.destination" {
+			t.Errorf("Expected activity.flight.destination validation error, got %v", err)
+		}
+	})
+
+	t.Run("fuel", func(t *testing.T) {
+		footprint, err := service.Calculate(ctx, CalculateInput{
+			MCC:       "5541",
+			CountryID: "GBR",
+			Method:    MethodFuel,
+			Activity:  &Activity{Fuel: &FuelActivity{FuelType: "Diesel", Litres: 40}},
+		})
+		if err != nil {
+			t.Fatalf("Calculate failed: %v", err)
+		}
+		if math.Abs(footprint.CarbonCo2eGrams-100400) > 0.001 {
+			t.Errorf("Expected 100400 grams, got %v", footprint.CarbonCo2eGrams)
+		}
+		if footprint.CalculationMethod != calculationMethodFuel {
+			t.Errorf("Expected fuel method, got %q", footprint.CalculationMethod)
+		}
+	})
+
+	t.Run("electricity defaults to merchant country grid", func(t *testing.T) {
+		footprint, err := service.Calculate(ctx, CalculateInput{
+			MCC:       "4900",
+			CountryID: "FRA",
+			Activity:  &Activity{Electricity: &ElectricityActivity{KWh: 1000}},
+		})
+		if err != nil {
+			t.Fatalf("Calculate failed: %v", err)
+		}
+		if math.Abs(footprint.CarbonCo2eGrams-56000) > 0.001 {
+			t.Errorf("Expected 56000 grams, got %v", footprint.CarbonCo2eGrams)
+		}
+	})
+
+	t.Run("invalid activity", func(t *testing.T) {
+		inputs := []CalculateInput{
+			{Method: MethodFlight},
+			{Activity: &Activity{Flight: &FlightActivity{Origin: "LHR", Destination: "XXX"}}},
+			{Activity: &Activity{Flight: &FlightActivity{Origin: "LHR", Destination: "JFK", CabinClass: "steerage"}}},
+			{Activity: &Activity{Fuel: &FuelActivity{FuelType: "coal", Litres: 10}}},
+			{Method: "teleport"},
+		}
+		for _, input := range inputs {
+			if _, err := service.Calculate(ctx, input); err == nil {
+				t.Errorf("Expected error for %+v", input)
+			}
+		}
+	})
+
+	t.Run("invalid calculation method", func(t *testing.T) {
+		inputs := []CalculateInput{
+			{Method: "teleport"},
+			{Method: MethodSpend, MCC: "5541", CountryID: "GBR", Activity: &Activity{Fuel: &FuelActivity{FuelType: "diesel", Litres: 40}}},
+		}
+		for _, input := range inputs {
+			_, err := service.Calculate(ctx, input)
+			var domainErr *errors.DomainError
+			if !errors.IsDomainError(err, &domainErr) || domainErr.Field != "calculationMethod" {
+				t.Errorf("Expected calculationMethod validation error for %+v, got %v", input, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,105 @@
+package carbonfootprint
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"api-golang/internal/shared/errors"
+)
+
+// Controller handles admin HTTP requests for carbon factor management
+type Controller struct {
+	importer *FactorImporter
+}
+
+// NewController creates a new carbon footprint controller
+func NewController(importer *FactorImporter) *Controller {
+	return &Controller{importer: importer}
+}
+
+// HandleListFactors handles GET /api/admin/carbon-factors
+func (c *Controller) HandleListFactors(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		c.writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed")
+		return
+	}
+
+	factors, err := c.importer.ListFactors(r.Context())
+	if err != nil {
+		c.writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		return
+	}
+
+	c.writeJSON(w, http.StatusOK, factors)
+}
+
+// HandleImportFactors handles POST /api/admin/carbon-factors/import?format={csv|json}&dryRun={bool}.
+// The format defaults to the request content type; dryRun defaults to true so
+// that a diff is always reviewed before it is committed.
+func (c *Controller) HandleImportFactors(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		c.writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed")
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = FactorFormatJSON
+		if strings.HasPrefix(r.Header.Get("Content-Type"), "text/csv") {
+			format = FactorFormatCSV
+		}
+	}
+
+	dryRun := true
+	if v := r.URL.Query().Get("dryRun"); v != "" {
+		parsed, err := strconv.ParseBool(v)
+		if err != nil {
+			c.writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "dryRun must be true or false")
+			return
+		}
+		dryRun = parsed
+	}
+
+	factors, err := ParseFactors(format, r.Body)
+	if err != nil {
+		c.writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid factor dataset: "+err.Error())
+		return
+	}
+
+	result, err := c.importer.Import(r.Context(), factors, dryRun)
+	if err != nil {
+		var domainErr *errors.DomainError
+		if errors.IsDomainError(err, &domainErr) && domainErr.Code == errors.ErrCodeValidation {
+			// The result carries the individual issues
+			c.writeJSON(w, http.StatusBadRequest, result)
+			return
+		}
+		c.writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		return
+	}
+
+	c.writeJSON(w, http.StatusOK, result)
+}
+
+// ErrorResponse represents an error response
+type ErrorResponse struct {
+	Error struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func (c *Controller) writeError(w http.ResponseWriter, status int, code, message string) {
+	resp := ErrorResponse{}
+	resp.Error.Code = code
+	resp.Error.Message = message
+	c.writeJSON(w, status, resp)
+}
+
+func (c *Controller) writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
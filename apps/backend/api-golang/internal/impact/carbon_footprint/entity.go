@@ -4,16 +4,171 @@ package carbonfootprint
 import (
 	"database/sql/driver"
 	"encoding/json"
+	"math"
 	"time"
 )
 
-// CarbonFactor represents emission factors for a specific MCC and country
+// CarbonFactor represents emission factors for a specific MCC and country.
+// Factors are versioned by the year they take effect; a factor stays in force
+// until its ValidUntilYear (if set) or until a later year's factor for the
+// same MCC and country supersedes it.
 type CarbonFactor struct {
-	ID          string  `json:"id"`
-	MCC         string  `json:"mcc"` // Merchant Category Code
-	CountryID   string  `json:"countryId"`
-	Factor      float64 `json:"factor"` // kg CO2e per EUR
-	Description string  `json:"description"`
+	ID             string  `json:"id"`
+	MCC            string  `json:"mcc"`       // Merchant Category Code ("*" for any)
+	CountryID      string  `json:"countryId"` // ISO-3 ("*" for any)
+	EffectiveYear  int     `json:"effectiveYear"`
+	ValidUntilYear int     `json:"validUntilYear,omitempty"` // Last year in force, 0 if open-ended
+	Version        int     `json:"version"`                  // Revision within the effective year
+	Factor         float64 `json:"factor"`                   // kg CO2e per EUR
+	Uncertainty    float64 `json:"uncertainty,omitempty"`    // Relative half-width of the 95% range (0.3 = ±30%), tier default when 0
+	Source         string  `json:"source"`                   // Dataset the factor was taken from
+	Methodology    string  `json:"methodology"`
+	Description    string  `json:"description"`
+}
+
+// Quality returns the data-quality tier of the factor, based on how specific it is
+func (f *CarbonFactor) Quality() DataQuality {
+	switch {
+	case f.MCC == "*":
+		return DataQualityDefault
+	case f.CountryID == "*":
+		return DataQualityMCCOnly
+	default:
+		return DataQualityExact
+	}
+}
+
+// RelativeUncertainty returns the factor's uncertainty, or the default for
+// its data-quality tier when none is set
+func (f *CarbonFactor) RelativeUncertainty() float64 {
+	if f.Uncertainty > 0 {
+		return f.Uncertainty
+	}
+	return defaultUncertainty[f.Quality()]
+}
+
+// EffectiveAt reports whether the factor is in force at the given time
+func (f *CarbonFactor) EffectiveAt(at time.Time) bool {
+	year := at.Year()
+	return f.EffectiveYear <= year && (f.ValidUntilYear == 0 || year <= f.ValidUntilYear)
+}
+
+// DataQuality describes how closely the data behind a footprint matches the transaction
+type DataQuality string
+
+const (
+	DataQualityActivity DataQuality = "activity" // Measured activity (distance, litres, kWh)
+	DataQualityExact    DataQuality = "exact"    // Factor for the MCC and country
+	DataQualityMCCOnly  DataQuality = "mccOnly"  // Factor for the MCC in any country
+	DataQualityDefault  DataQuality = "default"  // Default factor, the MCC is not covered
+)
+
+// defaultUncertainty is the relative uncertainty by tier for factors without their own
+var defaultUncertainty = map[DataQuality]float64{
+	DataQualityActivity: 0.1,
+	DataQualityExact:    0.2,
+	DataQualityMCCOnly:  0.35,
+	DataQualityDefault:  0.6,
+}
+
+// uncertaintyRange returns the low and high ends of a relative uncertainty range around grams
+func uncertaintyRange(grams, uncertainty float64) (low, high float64) {
+	return math.Max(0, grams*(1-uncertainty)), grams * (1 + uncertainty)
+}
+
+// dataQualityRank orders tiers from best to worst
+var dataQualityRank = map[DataQuality]int{
+	DataQualityActivity: 0,
+	DataQualityExact:    1,
+	DataQualityMCCOnly:  2,
+	DataQualityDefault:  3,
+}
+
+// worseQuality returns the lower of two data-quality tiers
+func worseQuality(a, b DataQuality) DataQuality {
+	if dataQualityRank[b] > dataQualityRank[a] {
+		return b
+	}
+	return a
+}
+
+// MethodType identifies a footprint calculation method
+type MethodType string
+
+const (
+	MethodSpend       MethodType = "spend"       // Amount x MCC or item category factor
+	MethodFlight      MethodType = "flight"      // Great-circle distance x cabin class factor
+	MethodFuel        MethodType = "fuel"        // Litres x fuel type factor
+	MethodElectricity MethodType = "electricity" // kWh x grid factor
+)
+
+// Activity holds the activity data used by activity-based methods (stored as JSON blob)
+type Activity struct {
+	Flight      *FlightActivity      `json:"flight,omitempty"`
+	Fuel        *FuelActivity        `json:"fuel,omitempty"`
+	Electricity *ElectricityActivity `json:"electricity,omitempty"`
+}
+
+// hasData reports whether any flight, fuel or electricity data is set
+func (a *Activity) hasData() bool {
+	return a != nil && (a.Flight != nil || a.Fuel != nil || a.Electricity != nil)
+}
+
+// FlightActivity describes a flight booking
+type FlightActivity struct {
+	Origin      string  `json:"origin"`      // IATA airport code
+	Destination string  `json:"destination"` // IATA airport code
+	CabinClass  string  `json:"cabinClass,omitempty"`
+	Passengers  int     `json:"passengers,omitempty"` // Defaults to 1
+	Return      bool    `json:"return,omitempty"`
+	DistanceKm  float64 `json:"distanceKm,omitempty"` // Set by the calculation, per passenger
+}
+
+// FuelActivity describes a fuel purchase
+type FuelActivity struct {
+	FuelType string  `json:"fuelType"`
+	Litres   float64 `json:"litres"`
+}
+
+// ElectricityActivity describes electricity consumption
+type ElectricityActivity struct {
+	KWh       float64 `json:"kWh"`
+	CountryID string  `json:"countryId,omitempty"` // ISO-3 grid country, defaults to the merchant country
+}
+
+// Nature pressure points (match impact_project.ProjectTheme values)
+const (
+	PressureLandUse       = "landUse"
+	PressureWaterUse      = "waterUse"
+	PressurePollution     = "pollution"
+	PressureClimateStress = "climateStress"
+)
+
+// NatureFactor represents biodiversity loss factors for a specific MCC and
+// country, split by pressure point. Values are MSA.m² (mean species abundance
+// loss over one square metre) per EUR spent.
+type NatureFactor struct {
+	ID            string  `json:"id"`
+	MCC           string  `json:"mcc"`       // Merchant Category Code ("*" for any)
+	CountryID     string  `json:"countryId"` // ISO-3 ("*" for any)
+	LandUse       float64 `json:"landUse"`
+	WaterUse      float64 `json:"waterUse"`
+	Pollution     float64 `json:"pollution"`
+	ClimateStress float64 `json:"climateStress"`
+	Source        string  `json:"source"`
+	Description   string  `json:"description"`
+}
+
+// Total returns the total MSA.m² per EUR across all pressure points
+func (f *NatureFactor) Total() float64 {
+	return f.LandUse + f.WaterUse + f.Pollution + f.ClimateStress
+}
+
+// CategoryMapping maps an order item category to the MCC whose factors apply to it
+type CategoryMapping struct {
+	Category    string `json:"category"`
+	MCC         string `json:"mcc"`
+	Description string `json:"description"`
 }
 
 // Footprint represents a calculated carbon footprint (matches Impact calculation data model)
@@ -42,8 +197,14 @@ type Footprint struct {
 	CarbonCo2eGrams  float64 `json:"carbonCo2eGrams"`  // High level metric
 	CarbonCo2eOunces float64 `json:"carbonCo2eOunces"` // High level metric
 
-	// Nature footprint (for future use)
-	NatureTotalMsa *float64 `json:"natureTotalMsa,omitempty"` // High level metric
+	// Uncertainty range around CarbonCo2eGrams and the quality of the data behind it
+	CarbonCo2eGramsLow  float64     `json:"carbonCo2eGramsLow"`
+	CarbonCo2eGramsHigh float64     `json:"carbonCo2eGramsHigh"`
+	Uncertainty         float64     `json:"uncertainty"` // Relative half-width of the range
+	DataQuality         DataQuality `json:"dataQuality"` // Lowest tier used, e.g. default when the MCC was not covered
+
+	// Nature footprint, only calculated for organisations with the nature calculation type
+	NatureTotalMsa *float64 `json:"natureTotalMsa,omitempty"` // High level metric (MSA.m²)
 
 	// Pressure points (pressure point details)
 	PressurePoints *PressurePoints `json:"pressurePoints,omitempty"`
@@ -52,15 +213,27 @@ type Footprint struct {
 	CarbonEquivalents *Equivalents `json:"carbonEquivalents,omitempty"`
 	NatureEquivalents *Equivalents `json:"natureEquivalents,omitempty"`
 
-	// Metadata
+	// Activity data for activity-based calculations (stored as JSON blob)
+	Activity *Activity `json:"activity,omitempty"`
+
+	// Per order item breakdown (stored as JSON blob), empty for MCC-only calculations
+	Items ItemFootprints `json:"items,omitempty"`
+
+	// Metadata. For item-level calculations Factor is the amount-weighted
+	// factor, and the factor ID, version and year are only set when every
+	// item used the same factor.
 	Factor            float64   `json:"factor"` // The factor used
+	FactorID          string    `json:"factorId,omitempty"`
+	FactorVersion     int       `json:"factorVersion,omitempty"`
+	FactorYear        int       `json:"factorYear,omitempty"`
+	TransactionDate   time.Time `json:"transactionDate"`
 	CalculationMethod string    `json:"calculationMethod"`
 	CreatedAt         time.Time `json:"createdAt"`
 }
 
 // PressurePoints represents pressure point details
 type PressurePoints struct {
-	// Structure can be expanded based on nature impact requirements
+	// MSA.m² by pressure point (landUse, waterUse, pollution, climateStress)
 	Data map[string]interface{} `json:"data"`
 }
 
@@ -75,6 +248,67 @@ type Equivalent struct {
 	Unit     string  `json:"unit,omitempty"`
 }
 
+// ItemFootprints represents the per order item breakdown (stored as JSON blob)
+type ItemFootprints []ItemFootprint
+
+// ItemFootprint represents the footprint of a single order item
+type ItemFootprint struct {
+	ItemID              string      `json:"itemId"`
+	Name                string      `json:"name,omitempty"`
+	Category            string      `json:"category,omitempty"`
+	MCC                 string      `json:"mcc"` // MCC whose factor was applied
+	AmountEUR           float64     `json:"amountEur"`
+	CarbonCo2eGrams     float64     `json:"carbonCo2eGrams"`
+	CarbonCo2eGramsLow  float64     `json:"carbonCo2eGramsLow"`
+	CarbonCo2eGramsHigh float64     `json:"carbonCo2eGramsHigh"`
+	Factor              float64     `json:"factor"`
+	FactorID            string      `json:"factorId"`
+	FactorVersion       int         `json:"factorVersion"`
+	FactorYear          int         `json:"factorYear"`
+	CategoryMatched     bool        `json:"categoryMatched"` // False when the merchant MCC factor was used as fallback
+	DataQuality         DataQuality `json:"dataQuality"`
+	Uncertainty         float64     `json:"uncertainty"`
+}
+
+// Value implements driver.Valuer for database storage
+func (i ItemFootprints) Value() (driver.Value, error) {
+	if len(i) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(i)
+}
+
+// Scan implements sql.Scanner for database retrieval
+func (i *ItemFootprints) Scan(value interface{}) error {
+	if value == nil {
+		*i = nil
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return json.Unmarshal([]byte(value.(string)), i)
+	}
+	return json.Unmarshal(bytes, i)
+}
+
+// Value implements driver.Valuer for database storage
+func (a Activity) Value() (driver.Value, error) {
+	return json.Marshal(a)
+}
+
+// Scan implements sql.Scanner for database retrieval
+func (a *Activity) Scan(value interface{}) error {
+	if value == nil {
+		*a = Activity{}
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return json.Unmarshal([]byte(value.(string)), a)
+	}
+	return json.Unmarshal(bytes, a)
+}
+
 // Value implements driver.Valuer for database storage
 func (e Equivalents) Value() (driver.Value, error) {
 	if len(e) == 0 {
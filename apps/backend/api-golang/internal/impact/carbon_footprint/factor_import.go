@@ -0,0 +1,366 @@
+package carbonfootprint
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"api-golang/internal/shared/errors"
+)
+
+// Factor dataset formats accepted by the importer
+const (
+	FactorFormatCSV  = "csv"
+	FactorFormatJSON = "json"
+)
+
+// Factor dataset CSV columns (same names as the JSON fields)
+const (
+	columnID             = "id"
+	columnMCC            = "mcc"
+	columnCountryID      = "countryId"
+	columnEffectiveYear  = "effectiveYear"
+	columnValidUntilYear = "validUntilYear"
+	columnVersion        = "version"
+	columnFactor         = "factor"
+	columnUncertainty    = "uncertainty"
+	columnSource         = "source"
+	columnMethodology    = "methodology"
+	columnDescription    = "description"
+)
+
+// Plausible range for factor effective years
+const (
+	minFactorYear = 1990
+	maxFactorYear = 2100
+)
+
+// ParseFactors parses a factor dataset in the given format (csv or json)
+func ParseFactors(format string, r io.Reader) ([]*CarbonFactor, error) {
+	switch strings.ToLower(format) {
+	case FactorFormatCSV:
+		return parseFactorsCSV(r)
+	case FactorFormatJSON:
+		var factors []*CarbonFactor
+		if err := json.NewDecoder(r).Decode(&factors); err != nil {
+			return nil, fmt.Errorf("decoding factors: %w", err)
+		}
+		for i, f := range factors {
+			if f == nil {
+				return nil, fmt.Errorf("factor %d: null entry", i)
+			}
+		}
+		return factors, nil
+	default:
+		return nil, fmt.Errorf("unsupported factor format %q", format)
+	}
+}
+
+func parseFactorsCSV(r io.Reader) ([]*CarbonFactor, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading header: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.TrimSpace(name)] = i
+	}
+	for _, required := range []string{columnID, columnMCC, columnCountryID, columnEffectiveYear, columnVersion, columnFactor, columnSource} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("missing column %s", required)
+		}
+	}
+
+	value := func(record []string, column string) string {
+		if i, ok := columns[column]; ok {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	var factors []*CarbonFactor
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		factor := &CarbonFactor{
+			ID:          value(record, columnID),
+			MCC:         value(record, columnMCC),
+			CountryID:   strings.ToUpper(value(record, columnCountryID)),
+			Source:      value(record, columnSource),
+			Methodology: value(record, columnMethodology),
+			Description: value(record, columnDescription),
+		}
+		for column, target := range map[string]*int{
+			columnEffectiveYear:  &factor.EffectiveYear,
+			columnValidUntilYear: &factor.ValidUntilYear,
+			columnVersion:        &factor.Version,
+		} {
+			if v := value(record, column); v != "" {
+				if *target, err = strconv.Atoi(v); err != nil {
+					return nil, fmt.Errorf("line %d: %s: invalid integer %q", line, column, v)
+				}
+			}
+		}
+		if factor.Factor, err = strconv.ParseFloat(value(record, columnFactor), 64); err != nil {
+			return nil, fmt.Errorf("line %d: %s: invalid number %q", line, columnFactor, value(record, columnFactor))
+		}
+		if v := value(record, columnUncertainty); v != "" {
+			if factor.Uncertainty, err = strconv.ParseFloat(v, 64); err != nil {
+				return nil, fmt.Errorf("line %d: %s: invalid number %q", line, columnUncertainty, v)
+			}
+		}
+
+		factors = append(factors, factor)
+	}
+
+	return factors, nil
+}
+
+// FactorIssue describes a problem found while validating a factor dataset
+type FactorIssue struct {
+	FactorID string `json:"factorId,omitempty"`
+	Field    string `json:"field,omitempty"`
+	Message  string `json:"message"`
+}
+
+// FactorChangeType describes how an imported factor differs from the stored dataset
+type FactorChangeType string
+
+const (
+	FactorAdded   FactorChangeType = "added"
+	FactorUpdated FactorChangeType = "updated"
+)
+
+// FactorChange is a single entry in an import diff
+type FactorChange struct {
+	Type     FactorChangeType `json:"type"`
+	Factor   *CarbonFactor    `json:"factor"`
+	Previous *CarbonFactor    `json:"previous,omitempty"` // Stored factor being replaced
+}
+
+// FactorImportResult is the outcome of a factor import, including the diff
+// against the stored dataset. Nothing is stored when DryRun is set or when
+// the dataset has issues.
+type FactorImportResult struct {
+	DryRun    bool           `json:"dryRun"`
+	Committed bool           `json:"committed"`
+	Added     int            `json:"added"`
+	Updated   int            `json:"updated"`
+	Unchanged int            `json:"unchanged"`
+	Changes   []FactorChange `json:"changes"`
+	Issues    []FactorIssue  `json:"issues,omitempty"`
+}
+
+// FactorImporter validates factor datasets and loads them into a FactorStore
+type FactorImporter struct {
+	store FactorStore
+}
+
+// NewFactorImporter creates a new factor importer
+func NewFactorImporter(store FactorStore) *FactorImporter {
+	return &FactorImporter{store: store}
+}
+
+// ListFactors returns the stored factor dataset
+func (i *FactorImporter) ListFactors(ctx context.Context) ([]*CarbonFactor, error) {
+	return i.store.ListFactors(ctx)
+}
+
+// Import validates the factors, diffs them against the stored dataset and,
+// unless dryRun is set, stores the added and updated factors. A validation
+// error is returned alongside the result when the dataset has issues.
+func (i *FactorImporter) Import(ctx context.Context, factors []*CarbonFactor, dryRun bool) (*FactorImportResult, error) {
+	existing, err := i.store.ListFactors(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing factors: %w", err)
+	}
+	existingByID := make(map[string]*CarbonFactor, len(existing))
+	for _, f := range existing {
+		existingByID[f.ID] = f
+	}
+
+	result := &FactorImportResult{DryRun: dryRun, Changes: []FactorChange{}}
+	for _, f := range factors {
+		result.Issues = append(result.Issues, validateFactor(f)...)
+	}
+	result.Issues = append(result.Issues, findConflicts(existing, factors)...)
+
+	var changed []*CarbonFactor
+	for _, f := range factors {
+		previous, exists := existingByID[f.ID]
+		switch {
+		case !exists:
+			result.Added++
+			result.Changes = append(result.Changes, FactorChange{Type: FactorAdded, Factor: f})
+		case *previous != *f:
+			result.Updated++
+			result.Changes = append(result.Changes, FactorChange{Type: FactorUpdated, Factor: f, Previous: previous})
+		default:
+			result.Unchanged++
+			continue
+		}
+		changed = append(changed, f)
+	}
+
+	if len(result.Issues) > 0 {
+		return result, errors.NewValidationError(domainName, fmt.Sprintf("factor dataset has %d issue(s)", len(result.Issues)))
+	}
+	if dryRun || len(changed) == 0 {
+		return result, nil
+	}
+
+	if err := i.store.SaveFactors(ctx, changed); err != nil {
+		return nil, fmt.Errorf("saving factors: %w", err)
+	}
+	result.Committed = true
+	return result, nil
+}
+
+// validateFactor checks a single factor's fields
+func validateFactor(f *CarbonFactor) []FactorIssue {
+	var issues []FactorIssue
+	add := func(field, message string) {
+		issues = append(issues, FactorIssue{FactorID: f.ID, Field: field, Message: message})
+	}
+
+	if f.ID == "" {
+		add(columnID, "id is required")
+	}
+	if !isValidMCC(f.MCC) {
+		add(columnMCC, fmt.Sprintf("invalid MCC %q: must be 4 digits or *", f.MCC))
+	}
+	if !isValidCountryID(f.CountryID) {
+		add(columnCountryID, fmt.Sprintf("invalid country %q: must be ISO-3 or *", f.CountryID))
+	}
+	if f.EffectiveYear < minFactorYear || f.EffectiveYear > maxFactorYear {
+		add(columnEffectiveYear, fmt.Sprintf("effective year %d out of range", f.EffectiveYear))
+	}
+	if f.ValidUntilYear != 0 && f.ValidUntilYear < f.EffectiveYear {
+		add(columnValidUntilYear, "valid until year is before the effective year")
+	}
+	if f.Version < 1 {
+		add(columnVersion, "version must be at least 1")
+	}
+	if f.Factor < 0 || math.IsNaN(f.Factor) || math.IsInf(f.Factor, 0) {
+		add(columnFactor, fmt.Sprintf("invalid factor %v", f.Factor))
+	}
+	if f.Uncertainty < 0 || f.Uncertainty >= 1 || math.IsNaN(f.Uncertainty) {
+		add(columnUncertainty, fmt.Sprintf("uncertainty %v must be between 0 and 1", f.Uncertainty))
+	}
+	if f.Source == "" {
+		add(columnSource, "source is required")
+	}
+
+	return issues
+}
+
+// findConflicts reports duplicate IDs within the import, and duplicate or
+// overlapping factors for the same MCC and country once the import is merged
+// into the existing dataset
+func findConflicts(existing, incoming []*CarbonFactor) []FactorIssue {
+	var issues []FactorIssue
+
+	incomingIDs := make(map[string]bool, len(incoming))
+	for _, f := range incoming {
+		if incomingIDs[f.ID] {
+			issues = append(issues, FactorIssue{FactorID: f.ID, Field: columnID, Message: "duplicate factor id in import"})
+		}
+		incomingIDs[f.ID] = true
+	}
+
+	// Merge: imported factors replace stored factors with the same ID
+	groups := make(map[string][]*CarbonFactor)
+	for _, f := range existing {
+		if !incomingIDs[f.ID] {
+			groups[factorKey(f.MCC, f.CountryID)] = append(groups[factorKey(f.MCC, f.CountryID)], f)
+		}
+	}
+	for _, f := range incoming {
+		groups[factorKey(f.MCC, f.CountryID)] = append(groups[factorKey(f.MCC, f.CountryID)], f)
+	}
+
+	keys := make([]string, 0, len(groups))
+	for key := range groups {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		group := groups[key]
+		sort.SliceStable(group, func(i, j int) bool {
+			if group[i].EffectiveYear != group[j].EffectiveYear {
+				return group[i].EffectiveYear < group[j].EffectiveYear
+			}
+			return group[i].Version < group[j].Version
+		})
+
+		for i, earlier := range group {
+			for _, later := range group[i+1:] {
+				// Only report conflicts the import introduces
+				if !incomingIDs[earlier.ID] && !incomingIDs[later.ID] {
+					continue
+				}
+				switch {
+				case earlier.EffectiveYear == later.EffectiveYear && earlier.Version == later.Version:
+					issues = append(issues, FactorIssue{
+						FactorID: later.ID,
+						Message:  fmt.Sprintf("duplicates factor %s (%s, %d v%d)", earlier.ID, key, later.EffectiveYear, later.Version),
+					})
+				case earlier.EffectiveYear != later.EffectiveYear && earlier.ValidUntilYear >= later.EffectiveYear:
+					issues = append(issues, FactorIssue{
+						FactorID: later.ID,
+						Field:    columnEffectiveYear,
+						Message:  fmt.Sprintf("validity period overlaps factor %s (%s, %d-%d)", earlier.ID, key, earlier.EffectiveYear, earlier.ValidUntilYear),
+					})
+				}
+			}
+		}
+	}
+
+	return issues
+}
+
+func isValidMCC(mcc string) bool {
+	if mcc == "*" {
+		return true
+	}
+	if len(mcc) != 4 {
+		return false
+	}
+	for _, c := range mcc {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func isValidCountryID(countryID string) bool {
+	if countryID == "*" {
+		return true
+	}
+	if len(countryID) != 3 {
+		return false
+	}
+	for _, c := range countryID {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+	return true
+}
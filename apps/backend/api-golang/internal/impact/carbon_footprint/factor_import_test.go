@@ -0,0 +1,78 @@
+package carbonfootprint
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+const factorCSVHeader = "id,mcc,countryId,effectiveYear,validUntilYear,version,factor,source,methodology,description\n"
+
+func TestFactorImport_DryRunThenCommit(t *testing.T) {
+	repo := NewInMemoryFactorRepository()
+	importer := NewFactorImporter(repo)
+	ctx := context.Background()
+
+	factors, err := ParseFactors(FactorFormatCSV, strings.NewReader(factorCSVHeader+
+		"17,5411,DEU,2025,,1,0.17,EXIOBASE 3.8.2,EEIO,Grocery stores (Germany)\n"+
+		"9,5411,gbr,2024,,1,0.145,UK DEFRA/DESNZ GHG conversion factors,EEIO,Grocery stores (UK)\n"))
+	if err != nil {
+		t.Fatalf("ParseFactors failed: %v", err)
+	}
+
+	result, err := importer.Import(ctx, factors, true)
+	if err != nil {
+		t.Fatalf("Dry run failed: %v", err)
+	}
+	if result.Added != 1 || result.Updated != 1 || result.Committed {
+		t.Errorf("Expected 1 added, 1 updated, not committed; got %+v", result)
+	}
+	if factor, _ := repo.GetFactor(ctx, "5411", "DEU", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)); factor.ID == "17" {
+		t.Error("Dry run should not store factors")
+	}
+
+	result, err = importer.Import(ctx, factors, false)
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if !result.Committed {
+		t.Error("Expected import to be committed")
+	}
+	factor, err := repo.GetFactor(ctx, "5411", "GBR", time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil || factor.Factor != 0.145 {
+		t.Errorf("Expected updated UK factor 0.145, got %+v (%v)", factor, err)
+	}
+}
+
+func TestFactorImport_RejectsInvalidDatasets(t *testing.T) {
+	tests := []struct {
+		name string
+		csv  string
+	}{
+		{name: "invalid MCC", csv: "20,54A1,DEU,2025,,1,0.17,EXIOBASE,EEIO,\n"},
+		{name: "invalid country", csv: "20,5411,DE,2025,,1,0.17,EXIOBASE,EEIO,\n"},
+		{name: "missing source", csv: "20,5411,DEU,2025,,1,0.17,,EEIO,\n"},
+		{name: "duplicate id", csv: "20,5411,DEU,2025,,1,0.17,EXIOBASE,EEIO,\n20,5411,AUT,2025,,1,0.17,EXIOBASE,EEIO,\n"},
+		{name: "duplicates stored factor", csv: "20,5411,GBR,2024,,1,0.14,EXIOBASE,EEIO,\n"},
+		{name: "overlapping validity", csv: "20,5411,DEU,2023,2025,1,0.17,EXIOBASE,EEIO,\n21,5411,DEU,2024,,1,0.16,EXIOBASE,EEIO,\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewInMemoryFactorRepository()
+			factors, err := ParseFactors(FactorFormatCSV, strings.NewReader(factorCSVHeader+tt.csv))
+			if err != nil {
+				t.Fatalf("ParseFactors failed: %v", err)
+			}
+
+			result, err := NewFactorImporter(repo).Import(context.Background(), factors, false)
+			if err == nil {
+				t.Fatal("Expected validation error")
+			}
+			if len(result.Issues) == 0 || result.Committed {
+				t.Errorf("Expected issues and no commit, got %+v", result)
+			}
+		})
+	}
+}
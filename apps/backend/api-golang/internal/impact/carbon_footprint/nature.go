@@ -0,0 +1,55 @@
+package carbonfootprint
+
+import (
+	"context"
+	"fmt"
+)
+
+// NatureCalculator calculates the nature footprint (MSA loss) of a
+// transaction and its split across pressure points
+type NatureCalculator struct {
+	factorRepo NatureFactorRepository
+}
+
+// NewNatureCalculator creates a new nature calculator
+func NewNatureCalculator(factorRepo NatureFactorRepository) *NatureCalculator {
+	return &NatureCalculator{factorRepo: factorRepo}
+}
+
+// Calculate sets NatureTotalMsa and PressurePoints on the footprint as
+// amount x MSA-per-EUR factor. Item-level footprints use each item's MCC.
+func (c *NatureCalculator) Calculate(ctx context.Context, footprint *Footprint) error {
+	type spend struct {
+		mcc    string
+		amount float64
+	}
+	spends := []spend{{mcc: footprint.MCC, amount: footprint.Amount}}
+	if len(footprint.Items) > 0 {
+		spends = spends[:0]
+		for _, item := range footprint.Items {
+			spends = append(spends, spend{mcc: item.MCC, amount: item.AmountEUR})
+		}
+	}
+
+	pressures := map[string]float64{}
+	var total float64
+	for _, s := range spends {
+		factor, err := c.factorRepo.GetNatureFactor(ctx, s.mcc, footprint.MerchantCountry)
+		if err != nil {
+			return fmt.Errorf("getting nature factor: %w", err)
+		}
+		pressures[PressureLandUse] += s.amount * factor.LandUse
+		pressures[PressureWaterUse] += s.amount * factor.WaterUse
+		pressures[PressurePollution] += s.amount * factor.Pollution
+		pressures[PressureClimateStress] += s.amount * factor.ClimateStress
+		total += s.amount * factor.Total()
+	}
+
+	data := make(map[string]interface{}, len(pressures))
+	for pressure, msa := range pressures {
+		data[pressure] = msa
+	}
+	footprint.NatureTotalMsa = &total
+	footprint.PressurePoints = &PressurePoints{Data: data}
+	return nil
+}
@@ -0,0 +1,48 @@
+package carbonfootprint
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"api-golang/internal/platform/airport"
+)
+
+func TestCalculate_NatureFootprint(t *testing.T) {
+	service := NewService(NewInMemoryFactorRepository(), NewInMemoryCategoryRepository(), NewInMemoryNatureFactorRepository(), NewInMemoryFootprintRepository(), airport.NewService(airport.NewInMemoryRepository()))
+	ctx := context.Background()
+
+	footprint, err := service.Calculate(ctx, CalculateInput{
+		AmountEUR:     100,
+		MCC:           "5411",
+		CountryID:     "GBR",
+		IncludeNature: true,
+	})
+	if err != nil {
+		t.Fatalf("Calculate failed: %v", err)
+	}
+
+	if footprint.NatureTotalMsa == nil || math.Abs(*footprint.NatureTotalMsa-131) > 0.001 {
+		t.Fatalf("Expected total MSA 131, got %v", footprint.NatureTotalMsa)
+	}
+	want := map[string]float64{
+		PressureLandUse:       95,
+		PressureWaterUse:      10,
+		PressurePollution:     18,
+		PressureClimateStress: 8,
+	}
+	for pressure, msa := range want {
+		got, _ := footprint.PressurePoints.Data[pressure].(float64)
+		if math.Abs(got-msa) > 0.001 {
+			t.Errorf("Pressure point %s: expected %v, got %v", pressure, msa, got)
+		}
+	}
+
+	footprint, err = service.Calculate(ctx, CalculateInput{AmountEUR: 100, MCC: "5411", CountryID: "GBR"})
+	if err != nil {
+		t.Fatalf("Calculate failed: %v", err)
+	}
+	if footprint.NatureTotalMsa != nil || footprint.PressurePoints != nil {
+		t.Error("Expected no nature footprint when nature is not requested")
+	}
+}
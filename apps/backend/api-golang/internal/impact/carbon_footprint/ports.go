@@ -1,7 +1,12 @@
 // Package carbonfootprint defines ports for the carbon footprint sub-domain.
 package carbonfootprint
 
-import "context"
+import (
+	"context"
+	"time"
+
+	"api-golang/internal/platform/airport"
+)
 
 // CalculateInput contains parameters for carbon footprint calculation
 type CalculateInput struct {
@@ -11,20 +16,95 @@ type CalculateInput struct {
 	AmountEUR      float64 // Amount in EUR (already converted)
 	MCC            string
 	CountryID      string
+	// TransactionDate selects the factor in force; defaults to now when zero
+	TransactionDate time.Time
+	// Items, when set, are calculated individually and AmountEUR is ignored
+	Items []ItemInput
+	// Method selects the calculation method; when empty it is inferred from
+	// the activity data, defaulting to spend-based
+	Method   MethodType
+	Activity *Activity
+	// IncludeNature adds the nature (MSA) footprint and pressure points
+	IncludeNature bool
+}
+
+// ItemInput contains an order item for item-level footprint calculation
+type ItemInput struct {
+	ItemID    string
+	Name      string
+	Category  string
+	AmountEUR float64 // Line total in EUR (unit price x quantity, already converted)
+}
+
+// CalculationMethod defines the strategy for calculating a footprint from one
+// kind of input data. Implementations set the factor and activity details on
+// the footprint and return the carbon footprint in kg CO2e.
+type CalculationMethod interface {
+	// Name is recorded as the footprint's CalculationMethod
+	Name() string
+	Calculate(ctx context.Context, input CalculateInput, footprint *Footprint) (float64, error)
 }
 
 // FactorRepository defines the port for carbon factor data access
 type FactorRepository interface {
-	GetFactor(ctx context.Context, mcc, countryID string) (*CarbonFactor, error)
+	// GetFactor returns the most specific factor in force at the given time
+	GetFactor(ctx context.Context, mcc, countryID string, at time.Time) (*CarbonFactor, error)
+}
+
+// FactorStore defines the port for managing the carbon factor dataset
+type FactorStore interface {
+	FactorRepository
+	ListFactors(ctx context.Context) ([]*CarbonFactor, error)
+	// SaveFactors inserts new factors and replaces existing ones with the same ID
+	SaveFactors(ctx context.Context, factors []*CarbonFactor) error
+}
+
+// NatureFactorRepository defines the port for nature factor data access
+type NatureFactorRepository interface {
+	// GetNatureFactor returns the most specific factor for MCC and country
+	GetNatureFactor(ctx context.Context, mcc, countryID string) (*NatureFactor, error)
+}
+
+// CategoryRepository defines the port for item category to MCC mappings
+type CategoryRepository interface {
+	// GetMapping returns a not found error for unmapped categories
+	GetMapping(ctx context.Context, category string) (*CategoryMapping, error)
+}
+
+// AirportValidator defines the port for airport lookups (provided by the airport reference data)
+type AirportValidator interface {
+	ValidateAirport(ctx context.Context, iataCode string) (*airport.Entity, error)
+}
+
+// CustomerFilter narrows a customer's footprints. Zero times leave that end
+// of the transaction date range open.
+type CustomerFilter struct {
+	CustomerID     string    // Required
+	OrganisationID string    // Optional, only footprints calculated for this organisation
+	From           time.Time // Inclusive
+	To             time.Time // Exclusive
 }
 
 // FootprintRepository defines the port for footprint data access
 type FootprintRepository interface {
 	Create(ctx context.Context, footprint *Footprint) error
+	Update(ctx context.Context, footprint *Footprint) error
 	GetByID(ctx context.Context, id string) (*Footprint, error)
+	// ListByCustomer returns a customer's footprints, oldest transaction first
+	ListByCustomer(ctx context.Context, filter CustomerFilter) ([]*Footprint, error)
 }
 
 // Service defines the port for carbon footprint business logic
 type Service interface {
 	Calculate(ctx context.Context, input CalculateInput) (*Footprint, error)
+	// Estimate calculates a footprint without storing it
+	Estimate(ctx context.Context, input CalculateInput) (*Footprint, error)
+	GetByID(ctx context.Context, id string) (*Footprint, error)
+	// SetCarbonEquivalents stores the localised carbon equivalents on a footprint
+	SetCarbonEquivalents(ctx context.Context, footprintID string, equivalents Equivalents) error
+	// ListByCustomer returns a customer's stored footprints
+	ListByCustomer(ctx context.Context, filter CustomerFilter) ([]*Footprint, error)
+	// ErasePersonalData removes merchant, route and item details from a
+	// customer's footprints, returning the number of footprints changed
+	ErasePersonalData(ctx context.Context, customerID string) (int, error)
 }
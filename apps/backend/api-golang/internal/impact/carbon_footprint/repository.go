@@ -2,86 +2,273 @@ package carbonfootprint
 
 import (
 	"context"
+	"sort"
+	"strings"
 	"sync"
+	"time"
 
 	"api-golang/internal/shared/errors"
 )
 
 const domainName = "carbon_footprint"
 
+// The seeded carbon and nature factors are illustrative sample values for
+// development and tests. They are not taken from any published dataset; load
+// real datasets through the factor import.
+const (
+	sourceSample    = "Sample data (not from a published dataset)"
+	methodSpendEEIO = "Spend-based environmentally-extended input-output (EEIO)"
+)
+
 // InMemoryFactorRepository implements FactorRepository interface
 type InMemoryFactorRepository struct {
-	factors map[string]*CarbonFactor // key: mcc:countryId
+	factors map[string][]*CarbonFactor // key: mcc:countryId, sorted by effective year and version
 	mu      sync.RWMutex
 }
 
 // NewInMemoryFactorRepository creates a new repository with sample data
 func NewInMemoryFactorRepository() *InMemoryFactorRepository {
 	repo := &InMemoryFactorRepository{
-		factors: make(map[string]*CarbonFactor),
-	}
-
-	// Default factor for any MCC/country combination
-	defaultFactor := &CarbonFactor{
-		ID:          "default",
-		MCC:         "*",
-		CountryID:   "*",
-		Factor:      0.23, // kg CO2e per EUR (average)
-		Description: "Default carbon factor",
+		factors: make(map[string][]*CarbonFactor),
 	}
-	repo.factors["*:*"] = defaultFactor
 
-	// Specific factors by MCC
 	factors := []*CarbonFactor{
+		// Default factor for any MCC/country combination
+		{ID: "default", MCC: "*", CountryID: "*", EffectiveYear: 2020, Version: 1, Factor: 0.23, Source: sourceSample, Methodology: methodSpendEEIO, Description: "Default carbon factor"},
+
+		// Global factors by MCC
 		// Airlines (high carbon)
-		{ID: "1", MCC: "4511", CountryID: "*", Factor: 1.2, Description: "Airlines"},
+		{ID: "1", MCC: "4511", CountryID: "*", EffectiveYear: 2020, Version: 1, Factor: 1.2, Source: sourceSample, Methodology: methodSpendEEIO, Description: "Airlines"},
 		// Restaurants (medium)
-		{ID: "2", MCC: "5812", CountryID: "*", Factor: 0.35, Description: "Restaurants"},
+		{ID: "2", MCC: "5812", CountryID: "*", EffectiveYear: 2020, Version: 1, Factor: 0.35, Source: sourceSample, Methodology: methodSpendEEIO, Description: "Restaurants"},
 		// Gas stations (high)
-		{ID: "3", MCC: "5541", CountryID: "*", Factor: 2.5, Description: "Gas stations"},
+		{ID: "3", MCC: "5541", CountryID: "*", EffectiveYear: 2020, Version: 1, Factor: 2.5, Source: sourceSample, Methodology: methodSpendEEIO, Description: "Gas stations"},
 		// Grocery stores (lower)
-		{ID: "4", MCC: "5411", CountryID: "*", Factor: 0.18, Description: "Grocery stores"},
+		{ID: "4", MCC: "5411", CountryID: "*", EffectiveYear: 2020, Version: 1, Factor: 0.18, Source: sourceSample, Methodology: methodSpendEEIO, Description: "Grocery stores"},
 		// Banks (low)
-		{ID: "5", MCC: "6011", CountryID: "*", Factor: 0.05, Description: "Banks/Financial"},
+		{ID: "5", MCC: "6011", CountryID: "*", EffectiveYear: 2020, Version: 1, Factor: 0.05, Source: sourceSample, Methodology: methodSpendEEIO, Description: "Banks/Financial"},
 		// Electronics
-		{ID: "6", MCC: "5732", CountryID: "*", Factor: 0.45, Description: "Electronics"},
+		{ID: "6", MCC: "5732", CountryID: "*", EffectiveYear: 2020, Version: 1, Factor: 0.45, Source: sourceSample, Methodology: methodSpendEEIO, Description: "Electronics"},
 		// Clothing
-		{ID: "7", MCC: "5651", CountryID: "*", Factor: 0.40, Description: "Clothing"},
+		{ID: "7", MCC: "5651", CountryID: "*", EffectiveYear: 2020, Version: 1, Factor: 0.40, Source: sourceSample, Methodology: methodSpendEEIO, Description: "Clothing"},
+
+		// Country-specific factors, where the grid mix and supply chain
+		// differ materially from the global average
+		{ID: "8", MCC: "5411", CountryID: "GBR", EffectiveYear: 2023, Version: 1, Factor: 0.16, Source: sourceSample, Methodology: methodSpendEEIO, Description: "Grocery stores (UK)"},
+		{ID: "9", MCC: "5411", CountryID: "GBR", EffectiveYear: 2024, Version: 1, Factor: 0.15, Source: sourceSample, Methodology: methodSpendEEIO, Description: "Grocery stores (UK)"},
+		{ID: "10", MCC: "5411", CountryID: "FRA", EffectiveYear: 2023, Version: 1, Factor: 0.14, Source: sourceSample, Methodology: methodSpendEEIO, Description: "Grocery stores (France)"},
+		{ID: "11", MCC: "5411", CountryID: "USA", EffectiveYear: 2023, Version: 1, Factor: 0.21, Source: sourceSample, Methodology: methodSpendEEIO, Description: "Grocery stores (US)"},
+		{ID: "12", MCC: "5541", CountryID: "GBR", EffectiveYear: 2023, Version: 1, Factor: 2.1, Source: sourceSample, Methodology: methodSpendEEIO, Description: "Gas stations (UK)"},
+		{ID: "13", MCC: "5541", CountryID: "GBR", EffectiveYear: 2024, Version: 1, Factor: 2.0, Source: sourceSample, Methodology: methodSpendEEIO, Description: "Gas stations (UK)"},
+		// A second version of the 2024 factor supersedes the first
+		{ID: "14", MCC: "5541", CountryID: "GBR", EffectiveYear: 2024, Version: 2, Factor: 1.95, Source: sourceSample, Methodology: methodSpendEEIO, Description: "Gas stations (UK)"},
+		{ID: "15", MCC: "5541", CountryID: "USA", EffectiveYear: 2023, Version: 1, Factor: 2.8, Source: sourceSample, Methodology: methodSpendEEIO, Description: "Gas stations (US)"},
+		{ID: "16", MCC: "5812", CountryID: "FRA", EffectiveYear: 2023, Version: 1, Factor: 0.28, Source: sourceSample, Methodology: methodSpendEEIO, Description: "Restaurants (France)"},
 	}
 
 	for _, f := range factors {
-		key := f.MCC + ":" + f.CountryID
-		repo.factors[key] = f
+		repo.add(f)
 	}
 
 	return repo
 }
 
-// GetFactor retrieves a carbon factor for MCC and country
-func (r *InMemoryFactorRepository) GetFactor(_ context.Context, mcc, countryID string) (*CarbonFactor, error) {
+// ListFactors returns every factor ordered by MCC, country, effective year and version
+func (r *InMemoryFactorRepository) ListFactors(_ context.Context) ([]*CarbonFactor, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	// Try exact match first
-	key := mcc + ":" + countryID
-	if factor, exists := r.factors[key]; exists {
-		return factor, nil
+	keys := make([]string, 0, len(r.factors))
+	for key := range r.factors {
+		keys = append(keys, key)
 	}
+	sort.Strings(keys)
+
+	var factors []*CarbonFactor
+	for _, key := range keys {
+		factors = append(factors, r.factors[key]...)
+	}
+	return factors, nil
+}
+
+// SaveFactors inserts new factors and replaces existing ones with the same ID
+func (r *InMemoryFactorRepository) SaveFactors(_ context.Context, factors []*CarbonFactor) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
 
-	// Try MCC with wildcard country
-	key = mcc + ":*"
-	if factor, exists := r.factors[key]; exists {
-		return factor, nil
+	for _, f := range factors {
+		r.remove(f.ID)
+		r.add(f)
 	}
+	return nil
+}
 
-	// Fall back to default
-	if factor, exists := r.factors["*:*"]; exists {
-		return factor, nil
+// remove deletes the factor with the given ID, if any.
+// Callers must hold the write lock.
+func (r *InMemoryFactorRepository) remove(id string) {
+	for key, factors := range r.factors {
+		for i, f := range factors {
+			if f.ID != id {
+				continue
+			}
+			factors = append(factors[:i:i], factors[i+1:]...)
+			if len(factors) == 0 {
+				delete(r.factors, key)
+			} else {
+				r.factors[key] = factors
+			}
+			return
+		}
+	}
+}
+
+// add inserts a factor keeping each key's factors ordered oldest first.
+// Callers must hold the write lock (or own the repository exclusively).
+func (r *InMemoryFactorRepository) add(factor *CarbonFactor) {
+	key := factorKey(factor.MCC, factor.CountryID)
+	factors := append(r.factors[key], factor)
+	sort.SliceStable(factors, func(i, j int) bool {
+		if factors[i].EffectiveYear != factors[j].EffectiveYear {
+			return factors[i].EffectiveYear < factors[j].EffectiveYear
+		}
+		return factors[i].Version < factors[j].Version
+	})
+	r.factors[key] = factors
+}
+
+// GetFactor retrieves the carbon factor for MCC and country in force at the
+// given time. The most specific match wins: MCC and country, then MCC for any
+// country, then the default factor.
+func (r *InMemoryFactorRepository) GetFactor(_ context.Context, mcc, countryID string, at time.Time) (*CarbonFactor, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	keys := []string{
+		factorKey(mcc, countryID),
+		factorKey(mcc, "*"),
+		factorKey("*", "*"),
+	}
+	for _, key := range keys {
+		if factor := effectiveFactor(r.factors[key], at); factor != nil {
+			return factor, nil
+		}
 	}
 
 	return nil, errors.NewNotFoundError(domainName, "no carbon factor found")
 }
 
+// effectiveFactor returns the latest factor in force at the given time from a
+// list ordered oldest first, or nil if none has taken effect yet
+func effectiveFactor(factors []*CarbonFactor, at time.Time) *CarbonFactor {
+	for i := len(factors) - 1; i >= 0; i-- {
+		if factors[i].EffectiveAt(at) {
+			return factors[i]
+		}
+	}
+	return nil
+}
+
+func factorKey(mcc, countryID string) string {
+	return mcc + ":" + countryID
+}
+
+// InMemoryNatureFactorRepository implements NatureFactorRepository interface
+type InMemoryNatureFactorRepository struct {
+	factors map[string]*NatureFactor // key: mcc:countryId
+	mu      sync.RWMutex
+}
+
+// NewInMemoryNatureFactorRepository creates a new repository with sample data
+func NewInMemoryNatureFactorRepository() *InMemoryNatureFactorRepository {
+	repo := &InMemoryNatureFactorRepository{
+		factors: make(map[string]*NatureFactor),
+	}
+
+	factors := []*NatureFactor{
+		// Default factor for any MCC/country combination
+		{ID: "nature-default", MCC: "*", CountryID: "*", LandUse: 0.40, WaterUse: 0.05, Pollution: 0.08, ClimateStress: 0.12, Source: sourceSample, Description: "Default nature factor"},
+
+		// Global factors by MCC
+		{ID: "nature-1", MCC: "4511", CountryID: "*", LandUse: 0.05, WaterUse: 0.01, Pollution: 0.10, ClimateStress: 0.60, Source: sourceSample, Description: "Airlines"},
+		{ID: "nature-2", MCC: "5812", CountryID: "*", LandUse: 0.90, WaterUse: 0.12, Pollution: 0.15, ClimateStress: 0.18, Source: sourceSample, Description: "Restaurants"},
+		{ID: "nature-3", MCC: "5541", CountryID: "*", LandUse: 0.10, WaterUse: 0.02, Pollution: 0.35, ClimateStress: 1.25, Source: sourceSample, Description: "Gas stations"},
+		{ID: "nature-4", MCC: "5411", CountryID: "*", LandUse: 1.20, WaterUse: 0.18, Pollution: 0.20, ClimateStress: 0.09, Source: sourceSample, Description: "Grocery stores"},
+		{ID: "nature-5", MCC: "6011", CountryID: "*", LandUse: 0.02, WaterUse: 0.00, Pollution: 0.01, ClimateStress: 0.03, Source: sourceSample, Description: "Banks/Financial"},
+		{ID: "nature-6", MCC: "5732", CountryID: "*", LandUse: 0.15, WaterUse: 0.04, Pollution: 0.30, ClimateStress: 0.22, Source: sourceSample, Description: "Electronics"},
+		{ID: "nature-7", MCC: "5651", CountryID: "*", LandUse: 0.70, WaterUse: 0.35, Pollution: 0.25, ClimateStress: 0.20, Source: sourceSample, Description: "Clothing"},
+
+		// Country-specific factors
+		{ID: "nature-8", MCC: "5411", CountryID: "BRA", LandUse: 2.60, WaterUse: 0.20, Pollution: 0.25, ClimateStress: 0.10, Source: sourceSample, Description: "Grocery stores (Brazil)"},
+		{ID: "nature-9", MCC: "5411", CountryID: "GBR", LandUse: 0.95, WaterUse: 0.10, Pollution: 0.18, ClimateStress: 0.08, Source: sourceSample, Description: "Grocery stores (UK)"},
+	}
+
+	for _, f := range factors {
+		repo.factors[factorKey(f.MCC, f.CountryID)] = f
+	}
+
+	return repo
+}
+
+// GetNatureFactor retrieves a nature factor for MCC and country, falling back
+// to the MCC for any country and then the default factor
+func (r *InMemoryNatureFactorRepository) GetNatureFactor(_ context.Context, mcc, countryID string) (*NatureFactor, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, key := range []string{factorKey(mcc, countryID), factorKey(mcc, "*"), factorKey("*", "*")} {
+		if factor, exists := r.factors[key]; exists {
+			return factor, nil
+		}
+	}
+
+	return nil, errors.NewNotFoundError(domainName, "no nature factor found")
+}
+
+// InMemoryCategoryRepository implements CategoryRepository interface
+type InMemoryCategoryRepository struct {
+	mappings map[string]*CategoryMapping // key: normalised category
+	mu       sync.RWMutex
+}
+
+// NewInMemoryCategoryRepository creates a new repository with sample data
+func NewInMemoryCategoryRepository() *InMemoryCategoryRepository {
+	repo := &InMemoryCategoryRepository{
+		mappings: make(map[string]*CategoryMapping),
+	}
+
+	mappings := []*CategoryMapping{
+		{Category: "groceries", MCC: "5411", Description: "Food and household groceries"},
+		{Category: "food", MCC: "5411", Description: "Food and household groceries"},
+		{Category: "restaurant", MCC: "5812", Description: "Meals and catering"},
+		{Category: "electronics", MCC: "5732", Description: "Consumer electronics"},
+		{Category: "clothing", MCC: "5651", Description: "Clothing and apparel"},
+		{Category: "fuel", MCC: "5541", Description: "Vehicle fuel"},
+		{Category: "flights", MCC: "4511", Description: "Air travel"},
+		{Category: "financial", MCC: "6011", Description: "Financial services"},
+	}
+	for _, m := range mappings {
+		repo.mappings[normaliseCategory(m.Category)] = m
+	}
+
+	return repo
+}
+
+// GetMapping retrieves the MCC mapping for an item category
+func (r *InMemoryCategoryRepository) GetMapping(_ context.Context, category string) (*CategoryMapping, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	mapping, exists := r.mappings[normaliseCategory(category)]
+	if !exists {
+		return nil, errors.NewNotFoundError(domainName, "no mapping for category "+category)
+	}
+	return mapping, nil
+}
+
+func normaliseCategory(category string) string {
+	return strings.ToLower(strings.TrimSpace(category))
+}
+
 // InMemoryFootprintRepository implements FootprintRepository interface
 type InMemoryFootprintRepository struct {
 	footprints map[string]*Footprint
@@ -104,6 +291,18 @@ func (r *InMemoryFootprintRepository) Create(_ context.Context, footprint *Footp
 	return nil
 }
 
+// Update replaces an existing footprint
+func (r *InMemoryFootprintRepository) Update(_ context.Context, footprint *Footprint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.footprints[footprint.ID]; !exists {
+		return errors.NewNotFoundError(domainName, "footprint not found")
+	}
+	r.footprints[footprint.ID] = footprint
+	return nil
+}
+
 // GetByID retrieves a footprint by ID
 func (r *InMemoryFootprintRepository) GetByID(_ context.Context, id string) (*Footprint, error) {
 	r.mu.RLock()
@@ -115,3 +314,33 @@ func (r *InMemoryFootprintRepository) GetByID(_ context.Context, id string) (*Fo
 	}
 	return footprint, nil
 }
+
+// ListByCustomer retrieves a customer's footprints, oldest transaction first
+func (r *InMemoryFootprintRepository) ListByCustomer(_ context.Context, filter CustomerFilter) ([]*Footprint, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	footprints := make([]*Footprint, 0)
+	for _, footprint := range r.footprints {
+		if footprint.CustomerID != filter.CustomerID {
+			continue
+		}
+		if filter.OrganisationID != "" && footprint.OrganisationID != filter.OrganisationID {
+			continue
+		}
+		if !filter.From.IsZero() && footprint.TransactionDate.Before(filter.From) {
+			continue
+		}
+		if !filter.To.IsZero() && !footprint.TransactionDate.Before(filter.To) {
+			continue
+		}
+		footprints = append(footprints, footprint)
+	}
+	sort.Slice(footprints, func(i, j int) bool {
+		if !footprints[i].TransactionDate.Equal(footprints[j].TransactionDate) {
+			return footprints[i].TransactionDate.Before(footprints[j].TransactionDate)
+		}
+		return footprints[i].ID < footprints[j].ID
+	})
+	return footprints, nil
+}
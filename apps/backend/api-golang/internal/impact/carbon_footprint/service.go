@@ -5,56 +5,176 @@ import (
 	"fmt"
 	"time"
 
+	"api-golang/internal/shared/errors"
+
 	"github.com/google/uuid"
 )
 
 // DefaultService implements the Service interface
 type DefaultService struct {
-	factorRepo    FactorRepository
+	methods       map[MethodType]CalculationMethod
+	nature        *NatureCalculator
 	footprintRepo FootprintRepository
 }
 
-// NewService creates a new carbon footprint service
-func NewService(factorRepo FactorRepository, footprintRepo FootprintRepository) *DefaultService {
+// NewService creates a new carbon footprint service with the spend-based and
+// activity-based calculation methods and the nature calculator
+func NewService(factorRepo FactorRepository, categoryRepo CategoryRepository, natureFactorRepo NatureFactorRepository, footprintRepo FootprintRepository, airports AirportValidator) *DefaultService {
 	return &DefaultService{
-		factorRepo:    factorRepo,
+		methods: map[MethodType]CalculationMethod{
+			MethodSpend:       NewSpendMethod(factorRepo, categoryRepo),
+			MethodFlight:      NewFlightMethod(airports),
+			MethodFuel:        NewFuelMethod(),
+			MethodElectricity: NewElectricityMethod(),
+		},
+		nature:        NewNatureCalculator(natureFactorRepo),
 		footprintRepo: footprintRepo,
 	}
 }
 
-// Calculate calculates the carbon footprint for a transaction
+// Calculate calculates the carbon footprint for a transaction using the
+// calculation method selected by the input, and stores it
 func (s *DefaultService) Calculate(ctx context.Context, input CalculateInput) (*Footprint, error) {
-	// Get the carbon factor
-	factor, err := s.factorRepo.GetFactor(ctx, input.MCC, input.CountryID)
+	footprint, err := s.Estimate(ctx, input)
 	if err != nil {
-		return nil, fmt.Errorf("getting carbon factor: %w", err)
+		return nil, err
+	}
+	footprint.ID = uuid.New().String()
+
+	// Store the footprint
+	if err := s.footprintRepo.Create(ctx, footprint); err != nil {
+		return nil, fmt.Errorf("storing footprint: %w", err)
+	}
+
+	return footprint, nil
+}
+
+// Estimate calculates the carbon footprint like Calculate, without storing it.
+// The returned footprint has no ID.
+func (s *DefaultService) Estimate(ctx context.Context, input CalculateInput) (*Footprint, error) {
+	methodType := selectMethod(input)
+	method, ok := s.methods[methodType]
+	if !ok {
+		return nil, errors.NewFieldValidationError(domainName, "calculationMethod",
+			fmt.Sprintf("unsupported calculation method %q: must be %s, %s, %s or %s",
+				methodType, MethodSpend, MethodFlight, MethodFuel, MethodElectricity))
+	}
+	if methodType == MethodSpend && input.Activity.hasData() {
+		return nil, errors.NewFieldValidationError(domainName, "calculationMethod",
+			"activity data cannot be used with the spend method")
 	}
 
-	// Calculate carbon footprint: amount * factor
-	carbonKg := input.AmountEUR * factor.Factor
-	carbonGrams := carbonKg * 1000.0
-	carbonOunces := carbonKg * 35.274
+	transactionDate := input.TransactionDate
+	if transactionDate.IsZero() {
+		transactionDate = time.Now()
+	}
 
 	// Create footprint record
 	footprint := &Footprint{
-		ID:               uuid.New().String(),
-		CustomerID:       input.CustomerID,
-		OrganisationID:   input.OrganisationID,
-		MCC:              input.MCC,
-		MerchantCountry: input.CountryID, // Using CountryID as merchant country for now
-		Amount:           input.AmountEUR,
-		Currency:         "EUR",
-		CarbonCo2eGrams:  carbonGrams,
-		CarbonCo2eOunces: carbonOunces,
-		Factor:           factor.Factor,
-		CalculationMethod: "MCC-based calculation",
-		CreatedAt:        time.Now(),
+		CustomerID:        input.CustomerID,
+		OrganisationID:    input.OrganisationID,
+		MCC:               input.MCC,
+		MerchantCountry:   input.CountryID, // Using CountryID as merchant country for now
+		Amount:            input.AmountEUR,
+		Currency:          "EUR",
+		TransactionDate:   transactionDate,
+		CalculationMethod: method.Name(),
+		CreatedAt:         time.Now(),
+	}
+	carbonKg, err := method.Calculate(ctx, input, footprint)
+	if err != nil {
+		return nil, err
 	}
+	footprint.CarbonCo2eGrams = carbonKg * 1000.0
+	footprint.CarbonCo2eOunces = carbonKg * 35.274
+	footprint.CarbonCo2eGramsLow, footprint.CarbonCo2eGramsHigh = uncertaintyRange(footprint.CarbonCo2eGrams, footprint.Uncertainty)
 
-	// Store the footprint
-	if err := s.footprintRepo.Create(ctx, footprint); err != nil {
-		return nil, fmt.Errorf("storing footprint: %w", err)
+	if input.IncludeNature {
+		if err := s.nature.Calculate(ctx, footprint); err != nil {
+			return nil, err
+		}
 	}
 
 	return footprint, nil
 }
+
+// GetByID retrieves a stored footprint
+func (s *DefaultService) GetByID(ctx context.Context, id string) (*Footprint, error) {
+	return s.footprintRepo.GetByID(ctx, id)
+}
+
+// SetCarbonEquivalents stores the localised carbon equivalents on a footprint
+func (s *DefaultService) SetCarbonEquivalents(ctx context.Context, footprintID string, equivalents Equivalents) error {
+	footprint, err := s.footprintRepo.GetByID(ctx, footprintID)
+	if err != nil {
+		return fmt.Errorf("getting footprint: %w", err)
+	}
+	footprint.CarbonEquivalents = &equivalents
+	if err := s.footprintRepo.Update(ctx, footprint); err != nil {
+		return fmt.Errorf("updating footprint: %w", err)
+	}
+	return nil
+}
+
+// ListByCustomer retrieves a customer's stored footprints
+func (s *DefaultService) ListByCustomer(ctx context.Context, filter CustomerFilter) ([]*Footprint, error) {
+	footprints, err := s.footprintRepo.ListByCustomer(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("listing footprints: %w", err)
+	}
+	return footprints, nil
+}
+
+// ErasePersonalData removes the details that describe what a customer bought
+// and where: merchant name and address below country level, flight routes and
+// item names. The amounts, MCC, merchant country and emissions are kept for
+// impact reporting.
+func (s *DefaultService) ErasePersonalData(ctx context.Context, customerID string) (int, error) {
+	footprints, err := s.footprintRepo.ListByCustomer(ctx, CustomerFilter{CustomerID: customerID})
+	if err != nil {
+		return 0, fmt.Errorf("listing footprints: %w", err)
+	}
+
+	for _, footprint := range footprints {
+		erased := *footprint
+		erased.MerchantName, erased.MerchantStreet, erased.MerchantCity = nil, nil, nil
+		erased.MerchantPostalCode, erased.MerchantState = nil, nil
+		if footprint.Activity != nil && footprint.Activity.Flight != nil {
+			activity := *footprint.Activity
+			flight := *activity.Flight
+			flight.Origin, flight.Destination = "", ""
+			activity.Flight = &flight
+			erased.Activity = &activity
+		}
+		if len(footprint.Items) > 0 {
+			erased.Items = make(ItemFootprints, len(footprint.Items))
+			for i, item := range footprint.Items {
+				item.Name = ""
+				erased.Items[i] = item
+			}
+		}
+		if err := s.footprintRepo.Update(ctx, &erased); err != nil {
+			return 0, fmt.Errorf("updating footprint %s: %w", footprint.ID, err)
+		}
+	}
+	return len(footprints), nil
+}
+
+// selectMethod returns the requested calculation method, inferring it from
+// the activity data when not set
+func selectMethod(input CalculateInput) MethodType {
+	if input.Method != "" {
+		return input.Method
+	}
+	if input.Activity != nil {
+		switch {
+		case input.Activity.Flight != nil:
+			return MethodFlight
+		case input.Activity.Fuel != nil:
+			return MethodFuel
+		case input.Activity.Electricity != nil:
+			return MethodElectricity
+		}
+	}
+	return MethodSpend
+}
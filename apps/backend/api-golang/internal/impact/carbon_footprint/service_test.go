@@ -0,0 +1,176 @@
+package carbonfootprint
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"api-golang/internal/platform/airport"
+)
+
+func TestGetFactor_EffectiveAtTransactionDate(t *testing.T) {
+	repo := NewInMemoryFactorRepository()
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		mcc         string
+		countryID   string
+		at          time.Time
+		wantID      string
+		wantVersion int
+	}{
+		{name: "country factor for year", mcc: "5411", countryID: "GBR", at: date(2023, 6, 1), wantID: "8", wantVersion: 1},
+		{name: "later year supersedes", mcc: "5411", countryID: "GBR", at: date(2025, 1, 1), wantID: "9", wantVersion: 1},
+		{name: "latest revision within year", mcc: "5541", countryID: "GBR", at: date(2024, 3, 1), wantID: "14", wantVersion: 2},
+		{name: "before country factor falls back to global", mcc: "5411", countryID: "GBR", at: date(2022, 12, 31), wantID: "4", wantVersion: 1},
+		{name: "country without specific factor", mcc: "5411", countryID: "DEU", at: date(2024, 1, 1), wantID: "4", wantVersion: 1},
+		{name: "unknown MCC uses default", mcc: "9999", countryID: "GBR", at: date(2024, 1, 1), wantID: "default", wantVersion: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			factor, err := repo.GetFactor(ctx, tt.mcc, tt.countryID, tt.at)
+			if err != nil {
+				t.Fatalf("GetFactor failed: %v", err)
+			}
+			if factor.ID != tt.wantID || factor.Version != tt.wantVersion {
+				t.Errorf("GetFactor() = %s v%d, want %s v%d", factor.ID, factor.Version, tt.wantID, tt.wantVersion)
+			}
+		})
+	}
+
+	if _, err := repo.GetFactor(ctx, "9999", "GBR", date(2019, 1, 1)); err == nil {
+		t.Error("Expected not found error before any factor takes effect")
+	}
+}
+
+func TestCalculate_RecordsFactorUsed(t *testing.T) {
+	service := NewService(NewInMemoryFactorRepository(), NewInMemoryCategoryRepository(), NewInMemoryNatureFactorRepository(), NewInMemoryFootprintRepository(), airport.NewService(airport.NewInMemoryRepository()))
+
+	footprint, err := service.Calculate(context.Background(), CalculateInput{
+		OrganisationID:  "org-1",
+		CustomerID:      "cust-1",
+		AmountEUR:       100,
+		MCC:             "5541",
+		CountryID:       "GBR",
+		TransactionDate: date(2023, 9, 1),
+	})
+	if err != nil {
+		t.Fatalf("Calculate failed: %v", err)
+	}
+
+	if footprint.FactorID != "12" || footprint.FactorVersion != 1 || footprint.FactorYear != 2023 {
+		t.Errorf("Expected factor 12 v1 (2023), got %s v%d (%d)", footprint.FactorID, footprint.FactorVersion, footprint.FactorYear)
+	}
+	if footprint.CarbonCo2eGrams != 210000 {
+		t.Errorf("Expected 210000 grams, got %v", footprint.CarbonCo2eGrams)
+	}
+}
+
+func TestCalculate_ItemLevelBreakdown(t *testing.T) {
+	service := NewService(NewInMemoryFactorRepository(), NewInMemoryCategoryRepository(), NewInMemoryNatureFactorRepository(), NewInMemoryFootprintRepository(), airport.NewService(airport.NewInMemoryRepository()))
+
+	footprint, err := service.Calculate(context.Background(), CalculateInput{
+		AmountEUR:       160,
+		MCC:             "5812",
+		CountryID:       "DEU",
+		TransactionDate: date(2024, 5, 1),
+		Items: []ItemInput{
+			{ItemID: "item-1", Category: "Electronics", AmountEUR: 100},
+			{ItemID: "item-2", Category: "groceries", AmountEUR: 50},
+			{ItemID: "item-3", Category: "gift", AmountEUR: 10}, // Unmapped, uses the merchant MCC
+		},
+	})
+	if err != nil {
+		t.Fatalf("Calculate failed: %v", err)
+	}
+
+	wantGrams := []float64{45000, 9000, 3500}
+	if len(footprint.Items) != len(wantGrams) {
+		t.Fatalf("Expected %d items, got %d", len(wantGrams), len(footprint.Items))
+	}
+	for i, want := range wantGrams {
+		if got := footprint.Items[i].CarbonCo2eGrams; math.Abs(got-want) > 0.001 {
+			t.Errorf("Item %d: expected %v grams, got %v", i, want, got)
+		}
+	}
+	if footprint.Items[2].CategoryMatched || footprint.Items[2].MCC != "5812" {
+		t.Errorf("Expected unmapped item to fall back to MCC 5812, got %+v", footprint.Items[2])
+	}
+	if math.Abs(footprint.CarbonCo2eGrams-57500) > 0.001 {
+		t.Errorf("Expected 57500 grams in total, got %v", footprint.CarbonCo2eGrams)
+	}
+	if footprint.FactorID != "" {
+		t.Errorf("Expected no single factor ID for a mixed basket, got %q", footprint.FactorID)
+	}
+}
+
+func TestEstimate_UncertaintyAndDataQuality(t *testing.T) {
+	service := NewService(NewInMemoryFactorRepository(), NewInMemoryCategoryRepository(), NewInMemoryNatureFactorRepository(), NewInMemoryFootprintRepository(), airport.NewService(airport.NewInMemoryRepository()))
+
+	tests := []struct {
+		name        string
+		input       CalculateInput
+		wantQuality DataQuality
+		wantLow     float64
+		wantHigh    float64
+	}{
+		{
+			name:        "exact match",
+			input:       CalculateInput{AmountEUR: 100, MCC: "5411", CountryID: "GBR"},
+			wantQuality: DataQualityExact,
+			wantLow:     12000, // 15000 ±20%
+			wantHigh:    18000,
+		},
+		{
+			name:        "MCC only",
+			input:       CalculateInput{AmountEUR: 100, MCC: "5411", CountryID: "DEU"},
+			wantQuality: DataQualityMCCOnly,
+			wantLow:     11700, // 18000 ±35%
+			wantHigh:    24300,
+		},
+		{
+			name:        "unknown MCC falls back to default",
+			input:       CalculateInput{AmountEUR: 100, MCC: "9999", CountryID: "GBR"},
+			wantQuality: DataQualityDefault,
+		},
+		{
+			name: "worst item tier",
+			input: CalculateInput{AmountEUR: 150, MCC: "5411", CountryID: "GBR", Items: []ItemInput{
+				{ItemID: "item-1", Category: "groceries", AmountEUR: 100}, // 15000 ±20%
+				{ItemID: "item-2", Category: "clothing", AmountEUR: 50},   // 20000 ±35%
+			}},
+			wantQuality: DataQualityMCCOnly,
+			wantLow:     25000,
+			wantHigh:    45000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.input.TransactionDate = date(2024, 6, 1)
+			footprint, err := service.Estimate(context.Background(), tt.input)
+			if err != nil {
+				t.Fatalf("Estimate failed: %v", err)
+			}
+			if footprint.DataQuality != tt.wantQuality {
+				t.Errorf("Expected data quality %s, got %s", tt.wantQuality, footprint.DataQuality)
+			}
+			if footprint.CarbonCo2eGramsLow >= footprint.CarbonCo2eGrams || footprint.CarbonCo2eGramsHigh <= footprint.CarbonCo2eGrams {
+				t.Errorf("Expected low < central < high, got %v < %v < %v", footprint.CarbonCo2eGramsLow, footprint.CarbonCo2eGrams, footprint.CarbonCo2eGramsHigh)
+			}
+			if tt.wantHigh == 0 {
+				return
+			}
+			if math.Abs(footprint.CarbonCo2eGramsLow-tt.wantLow) > 0.001 || math.Abs(footprint.CarbonCo2eGramsHigh-tt.wantHigh) > 0.001 {
+				t.Errorf("Expected range %v-%v, got %v-%v", tt.wantLow, tt.wantHigh, footprint.CarbonCo2eGramsLow, footprint.CarbonCo2eGramsHigh)
+			}
+		})
+	}
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,146 @@
+package carbonfootprint
+
+import (
+	"context"
+	"fmt"
+
+	"api-golang/internal/shared/errors"
+)
+
+// Calculation method names recorded on footprints
+const (
+	calculationMethodMCC         = "MCC-based calculation"
+	calculationMethodItem        = "Item category-based calculation"
+	calculationMethodFlight      = "Flight distance-based calculation"
+	calculationMethodFuel        = "Fuel volume-based calculation"
+	calculationMethodElectricity = "Electricity consumption-based calculation"
+)
+
+// SpendMethod calculates footprints from the amount spent, using the factor
+// for the merchant MCC or, per order item, the factor for the item category
+type SpendMethod struct {
+	factorRepo   FactorRepository
+	categoryRepo CategoryRepository
+}
+
+// NewSpendMethod creates a new spend-based calculation method
+func NewSpendMethod(factorRepo FactorRepository, categoryRepo CategoryRepository) *SpendMethod {
+	return &SpendMethod{
+		factorRepo:   factorRepo,
+		categoryRepo: categoryRepo,
+	}
+}
+
+// Name returns the calculation method name
+func (m *SpendMethod) Name() string {
+	return calculationMethodMCC
+}
+
+// Calculate calculates the footprint as amount x factor. When order items are
+// given each item is calculated with the factor for its category, falling back
+// to the merchant MCC factor for unmapped categories.
+func (m *SpendMethod) Calculate(ctx context.Context, input CalculateInput, footprint *Footprint) (float64, error) {
+	// Get the carbon factor in force on the transaction date
+	factor, err := m.factorRepo.GetFactor(ctx, input.MCC, input.CountryID, footprint.TransactionDate)
+	if err != nil {
+		return 0, fmt.Errorf("getting carbon factor: %w", err)
+	}
+
+	if len(input.Items) == 0 {
+		setFactor(footprint, factor)
+		return input.AmountEUR * factor.Factor, nil
+	}
+
+	items, err := m.calculateItems(ctx, input, factor, footprint)
+	if err != nil {
+		return 0, err
+	}
+	footprint.Items = items
+	footprint.CalculationMethod = calculationMethodItem
+
+	// Item ranges are summed, so the footprint uncertainty is the
+	// emissions-weighted item uncertainty
+	var carbonKg, uncertaintyKg float64
+	footprint.Amount = 0
+	footprint.DataQuality = DataQualityExact
+	singleFactor := true
+	for _, item := range items {
+		itemKg := item.CarbonCo2eGrams / 1000.0
+		footprint.Amount += item.AmountEUR
+		carbonKg += itemKg
+		uncertaintyKg += itemKg * item.Uncertainty
+		footprint.DataQuality = worseQuality(footprint.DataQuality, item.DataQuality)
+		singleFactor = singleFactor && item.FactorID == items[0].FactorID
+	}
+	if footprint.Amount > 0 {
+		footprint.Factor = carbonKg / footprint.Amount
+	}
+	if carbonKg > 0 {
+		footprint.Uncertainty = uncertaintyKg / carbonKg
+	} else {
+		footprint.Uncertainty = items[0].Uncertainty
+	}
+	if singleFactor {
+		footprint.FactorID = items[0].FactorID
+		footprint.FactorVersion = items[0].FactorVersion
+		footprint.FactorYear = items[0].FactorYear
+	}
+	return carbonKg, nil
+}
+
+// calculateItems calculates the footprint of each order item
+func (m *SpendMethod) calculateItems(ctx context.Context, input CalculateInput, mccFactor *CarbonFactor, footprint *Footprint) (ItemFootprints, error) {
+	items := make(ItemFootprints, len(input.Items))
+	for i, item := range input.Items {
+		itemMCC, factor := input.MCC, mccFactor
+		categoryMatched := false
+
+		if item.Category != "" {
+			mapping, err := m.categoryRepo.GetMapping(ctx, item.Category)
+			var domainErr *errors.DomainError
+			switch {
+			case err == nil:
+				itemMCC = mapping.MCC
+				if factor, err = m.factorRepo.GetFactor(ctx, itemMCC, input.CountryID, footprint.TransactionDate); err != nil {
+					return nil, fmt.Errorf("getting carbon factor for item %s: %w", item.ItemID, err)
+				}
+				categoryMatched = true
+			case errors.IsDomainError(err, &domainErr) && domainErr.Code == errors.ErrCodeNotFound:
+				// Unmapped category: use the merchant MCC factor
+			default:
+				return nil, fmt.Errorf("getting category mapping for item %s: %w", item.ItemID, err)
+			}
+		}
+
+		grams := item.AmountEUR * factor.Factor * 1000.0
+		low, high := uncertaintyRange(grams, factor.RelativeUncertainty())
+		items[i] = ItemFootprint{
+			ItemID:              item.ItemID,
+			Name:                item.Name,
+			Category:            item.Category,
+			MCC:                 itemMCC,
+			AmountEUR:           item.AmountEUR,
+			CarbonCo2eGrams:     grams,
+			CarbonCo2eGramsLow:  low,
+			CarbonCo2eGramsHigh: high,
+			Factor:              factor.Factor,
+			FactorID:            factor.ID,
+			FactorVersion:       factor.Version,
+			FactorYear:          factor.EffectiveYear,
+			CategoryMatched:     categoryMatched,
+			DataQuality:         factor.Quality(),
+			Uncertainty:         factor.RelativeUncertainty(),
+		}
+	}
+	return items, nil
+}
+
+// setFactor records the factor used for an MCC-based calculation
+func setFactor(footprint *Footprint, factor *CarbonFactor) {
+	footprint.Factor = factor.Factor
+	footprint.FactorID = factor.ID
+	footprint.FactorVersion = factor.Version
+	footprint.FactorYear = factor.EffectiveYear
+	footprint.DataQuality = factor.Quality()
+	footprint.Uncertainty = factor.RelativeUncertainty()
+}
@@ -0,0 +1,21 @@
+// Package equivalent converts footprints into relatable, localised equivalents
+// (trees, km driven, smartphone charges and so on).
+package equivalent
+
+// Definition is a catalogue entry converting kg CO2e into a relatable unit
+type Definition struct {
+	Key         string  `json:"key"`
+	Factor      float64 `json:"factor"` // Units per kg CO2e
+	Unit        string  `json:"unit"`
+	TemplateKey string  `json:"templateKey"` // Dot-notation key into the locale files
+	Description string  `json:"description"`
+}
+
+// Result is an equivalent rendered for a locale
+type Result struct {
+	Key      string  `json:"key"`
+	Value    float64 `json:"value"`
+	Unit     string  `json:"unit"`
+	Template string  `json:"template"` // Rendered text
+	Locale   string  `json:"locale"`   // Locale the template was rendered in
+}
@@ -0,0 +1,16 @@
+// Package equivalent defines ports for the equivalents sub-domain.
+package equivalent
+
+import "context"
+
+// Repository defines the port for the equivalents catalogue
+type Repository interface {
+	GetAll(ctx context.Context) ([]*Definition, error)
+}
+
+// Service defines the port for equivalents business logic
+type Service interface {
+	// Calculate returns every catalogue equivalent for the footprint, rendered
+	// in the given locale (falling back to the default locale)
+	Calculate(ctx context.Context, carbonKg float64, locale string) ([]Result, error)
+}
@@ -0,0 +1,55 @@
+package equivalent
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// catalogueJSON is the default equivalents catalogue
+//
+//go:embed data/catalogue.json
+var catalogueJSON []byte
+
+// InMemoryRepository implements Repository interface
+type InMemoryRepository struct {
+	definitions []*Definition // Catalogue order
+	mu          sync.RWMutex
+}
+
+// NewInMemoryRepository creates a new repository loaded with the default catalogue
+func NewInMemoryRepository() *InMemoryRepository {
+	repo, err := NewInMemoryRepositoryFromJSON(catalogueJSON)
+	if err != nil {
+		panic(fmt.Sprintf("equivalent: invalid embedded catalogue: %v", err))
+	}
+	return repo
+}
+
+// NewInMemoryRepositoryFromJSON creates a new repository from a catalogue in
+// the same format as data/catalogue.json
+func NewInMemoryRepositoryFromJSON(data []byte) (*InMemoryRepository, error) {
+	var definitions []*Definition
+	if err := json.Unmarshal(data, &definitions); err != nil {
+		return nil, fmt.Errorf("decoding catalogue: %w", err)
+	}
+	for i, d := range definitions {
+		if d == nil || d.Key == "" || d.TemplateKey == "" {
+			return nil, fmt.Errorf("catalogue entry %d: key and templateKey are required", i)
+		}
+		if d.Factor <= 0 {
+			return nil, fmt.Errorf("catalogue entry %s: factor must be positive", d.Key)
+		}
+	}
+	return &InMemoryRepository{definitions: definitions}, nil
+}
+
+// GetAll returns the catalogue in order
+func (r *InMemoryRepository) GetAll(_ context.Context) ([]*Definition, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.definitions, nil
+}
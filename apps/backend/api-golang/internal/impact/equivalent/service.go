@@ -0,0 +1,46 @@
+package equivalent
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bilo-mono/packages/common/service"
+)
+
+// DefaultService implements the Service interface
+type DefaultService struct {
+	service.BaseService[Repository]
+	translator *translator
+}
+
+// NewService creates a new equivalents service
+func NewService(repo Repository) *DefaultService {
+	return &DefaultService{
+		BaseService: service.NewBaseService(repo),
+		translator:  newTranslator(),
+	}
+}
+
+// Calculate converts the footprint into each catalogue equivalent and renders
+// its template in the requested locale
+func (s *DefaultService) Calculate(ctx context.Context, carbonKg float64, locale string) ([]Result, error) {
+	definitions, err := s.Repo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting equivalents catalogue: %w", err)
+	}
+
+	resolved := s.translator.resolveLocale(locale)
+	results := make([]Result, 0, len(definitions))
+	for _, d := range definitions {
+		value := displayValue(carbonKg * d.Factor)
+		results = append(results, Result{
+			Key:      d.Key,
+			Value:    value,
+			Unit:     d.Unit,
+			Template: s.translator.render(resolved, d.TemplateKey, value),
+			Locale:   resolved,
+		})
+	}
+
+	return results, nil
+}
@@ -0,0 +1,109 @@
+package equivalent
+
+import (
+	"context"
+	"testing"
+)
+
+func TestCalculate_LocalisedTemplates(t *testing.T) {
+	service := NewService(NewInMemoryRepository())
+	ctx := context.Background()
+
+	tests := []struct {
+		name       string
+		carbonKg   float64
+		locale     string
+		wantLocale string
+		want       map[string]string
+	}{
+		{
+			name:       "singular",
+			carbonKg:   21,
+			locale:     "en-GB",
+			wantLocale: "en-GB",
+			want: map[string]string{
+				"trees": "That's about what 1 tree absorbs in a year",
+			},
+		},
+		{
+			name:       "plural with decimals",
+			carbonKg:   42,
+			locale:     "en-GB",
+			wantLocale: "en-GB",
+			want: map[string]string{
+				"trees":       "That's about what 2 trees absorb in a year",
+				"flightHours": "That's like 0.5 hours of flying",
+			},
+		},
+		{
+			name:       "german decimal separator",
+			carbonKg:   42,
+			locale:     "de-DE",
+			wantLocale: "de-DE",
+			want: map[string]string{
+				"trees":       "Das entspricht etwa dem, was 2 Bäume in einem Jahr aufnehmen",
+				"flightHours": "Das entspricht 0,5 Flugstunden",
+			},
+		},
+		{
+			name:       "language fallback",
+			carbonKg:   21,
+			locale:     "de-AT",
+			wantLocale: "de-DE",
+			want: map[string]string{
+				"trees": "Das entspricht etwa dem, was 1 Baum in einem Jahr aufnimmt",
+			},
+		},
+		{
+			name:       "unsupported locale uses default",
+			carbonKg:   21,
+			locale:     "ja-JP",
+			wantLocale: DefaultLocale,
+			want: map[string]string{
+				"trees": "That's about what 1 tree absorbs in a year",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := service.Calculate(ctx, tt.carbonKg, tt.locale)
+			if err != nil {
+				t.Fatalf("Calculate failed: %v", err)
+			}
+
+			byKey := make(map[string]Result, len(results))
+			for _, r := range results {
+				byKey[r.Key] = r
+				if r.Locale != tt.wantLocale {
+					t.Errorf("%s: expected locale %s, got %s", r.Key, tt.wantLocale, r.Locale)
+				}
+			}
+			for key, want := range tt.want {
+				if got := byKey[key].Template; got != want {
+					t.Errorf("%s: expected %q, got %q", key, want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestNewInMemoryRepositoryFromJSON(t *testing.T) {
+	repo, err := NewInMemoryRepositoryFromJSON([]byte(`[{"key":"balloons","factor":200,"unit":"balloons","templateKey":"equivalents.balloons"}]`))
+	if err != nil {
+		t.Fatalf("Failed to load catalogue: %v", err)
+	}
+
+	results, err := NewService(repo).Calculate(context.Background(), 1, "en-GB")
+	if err != nil {
+		t.Fatalf("Calculate failed: %v", err)
+	}
+	// No template for the custom entry, so the key is returned
+	if len(results) != 1 || results[0].Value != 200 || results[0].Template != "equivalents.balloons" {
+		t.Errorf("Unexpected results %+v", results)
+	}
+
+	if _, err := NewInMemoryRepositoryFromJSON([]byte(`[{"key":"broken","factor":0,"templateKey":"x"}]`)); err == nil {
+		t.Error("Expected error for non-positive factor")
+	}
+}
@@ -0,0 +1,139 @@
+package equivalent
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"math"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// DefaultLocale is used when a request has no locale or an unsupported one
+const DefaultLocale = "en-GB"
+
+// Plural categories (CLDR names), used as keys under each template key
+const (
+	pluralOne   = "one"
+	pluralOther = "other"
+)
+
+// localeFiles holds the equivalents templates, in the same nested JSON
+// structure as the translations package (packages/ts/translations)
+//
+//go:embed locales/*.json
+var localeFiles embed.FS
+
+// translator renders templates from the embedded locale files
+type translator struct {
+	dictionaries map[string]map[string]interface{} // key: locale
+}
+
+// newTranslator loads the embedded locale files
+func newTranslator() *translator {
+	t := &translator{dictionaries: make(map[string]map[string]interface{})}
+
+	files, err := localeFiles.ReadDir("locales")
+	if err != nil {
+		panic(fmt.Sprintf("equivalent: reading locales: %v", err))
+	}
+	for _, f := range files {
+		data, err := localeFiles.ReadFile(path.Join("locales", f.Name()))
+		if err != nil {
+			panic(fmt.Sprintf("equivalent: reading %s: %v", f.Name(), err))
+		}
+		var dictionary map[string]interface{}
+		if err := json.Unmarshal(data, &dictionary); err != nil {
+			panic(fmt.Sprintf("equivalent: invalid locale file %s: %v", f.Name(), err))
+		}
+		t.dictionaries[strings.TrimSuffix(f.Name(), ".json")] = dictionary
+	}
+
+	return t
+}
+
+// resolveLocale returns the supported locale closest to the requested one:
+// an exact match, then a locale with the same language, then the default
+func (t *translator) resolveLocale(locale string) string {
+	if _, ok := t.dictionaries[locale]; ok {
+		return locale
+	}
+	language, _, _ := strings.Cut(locale, "-")
+	for supported := range t.dictionaries {
+		if l, _, _ := strings.Cut(supported, "-"); language != "" && strings.EqualFold(l, language) {
+			return supported
+		}
+	}
+	return DefaultLocale
+}
+
+// render renders the template for key in a resolved locale, choosing the
+// plural form for count and interpolating {{count}}. It falls back to the
+// default locale, then to the key itself, when the template is missing.
+func (t *translator) render(locale, key string, count float64) string {
+	formatted := formatNumber(locale, count)
+	for _, l := range []string{locale, DefaultLocale} {
+		forms, ok := lookup(t.dictionaries[l], key).(map[string]interface{})
+		if !ok {
+			continue
+		}
+		template, ok := forms[pluralCategory(l, count)].(string)
+		if !ok {
+			if template, ok = forms[pluralOther].(string); !ok {
+				continue
+			}
+		}
+		return strings.ReplaceAll(template, "{{count}}", formatted)
+	}
+	return key
+}
+
+// lookup resolves a dot-notation key in a nested dictionary
+func lookup(dictionary map[string]interface{}, key string) interface{} {
+	var current interface{} = dictionary
+	for _, part := range strings.Split(key, ".") {
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		current = m[part]
+	}
+	return current
+}
+
+// pluralCategory returns the CLDR plural category for a displayed number.
+// Only the one/other distinction is needed for the supported languages.
+func pluralCategory(locale string, n float64) string {
+	language, _, _ := strings.Cut(locale, "-")
+	switch language {
+	case "fr", "pt":
+		if n < 2 {
+			return pluralOne
+		}
+	default:
+		if n == 1 {
+			return pluralOne
+		}
+	}
+	return pluralOther
+}
+
+// displayValue rounds a value to the precision shown to customers
+func displayValue(v float64) float64 {
+	if math.Abs(v) >= 10 {
+		return math.Round(v)
+	}
+	return math.Round(v*10) / 10
+}
+
+// formatNumber formats a display value with the locale's decimal separator
+func formatNumber(locale string, v float64) string {
+	s := strconv.FormatFloat(v, 'f', -1, 64)
+	language, _, _ := strings.Cut(locale, "-")
+	switch language {
+	case "de", "fr", "es", "it", "nl", "pt":
+		s = strings.Replace(s, ".", ",", 1)
+	}
+	return s
+}
@@ -0,0 +1,131 @@
+package apikey
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"api-golang/internal/shared/auth"
+	"api-golang/internal/shared/errors"
+)
+
+// Controller handles HTTP requests for API key management
+type Controller struct {
+	service Service
+}
+
+// NewController creates a new API key controller
+func NewController(service Service) *Controller {
+	return &Controller{service: service}
+}
+
+// HandleAPIKeys handles GET and POST /api/api-keys
+func (c *Controller) HandleAPIKeys(w http.ResponseWriter, r *http.Request) {
+	caller, ok := auth.PrincipalFromContext(r.Context())
+	if !ok {
+		c.writeError(w, http.StatusUnauthorized, errors.ErrCodeUnauthorized, "API key is required")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		keys, err := c.service.ListKeys(r.Context(), caller, r.URL.Query().Get("organisationId"))
+		if err != nil {
+			c.writeServiceError(w, err)
+			return
+		}
+		c.writeJSON(w, http.StatusOK, keys)
+	case http.MethodPost:
+		var input IssueKeyInput
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+			c.writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body: "+err.Error())
+			return
+		}
+		issued, err := c.service.IssueKey(r.Context(), caller, input)
+		if err != nil {
+			c.writeServiceError(w, err)
+			return
+		}
+		c.writeJSON(w, http.StatusCreated, issued)
+	default:
+		c.writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed")
+	}
+}
+
+// HandleAPIKey handles POST /api/api-keys/{id}/rotate and POST /api/api-keys/{id}/revoke
+func (c *Controller) HandleAPIKey(w http.ResponseWriter, r *http.Request) {
+	caller, ok := auth.PrincipalFromContext(r.Context())
+	if !ok {
+		c.writeError(w, http.StatusUnauthorized, errors.ErrCodeUnauthorized, "API key is required")
+		return
+	}
+
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/api-keys/"), "/"), "/")
+	if len(parts) != 2 || parts[0] == "" || (parts[1] != "rotate" && parts[1] != "revoke") {
+		c.writeError(w, http.StatusNotFound, "NOT_FOUND", "Resource not found")
+		return
+	}
+	if r.Method != http.MethodPost {
+		c.writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed")
+		return
+	}
+
+	id := parts[0]
+	if parts[1] == "rotate" {
+		issued, err := c.service.RotateKey(r.Context(), caller, id)
+		if err != nil {
+			c.writeServiceError(w, err)
+			return
+		}
+		c.writeJSON(w, http.StatusCreated, issued)
+		return
+	}
+
+	key, err := c.service.RevokeKey(r.Context(), caller, id)
+	if err != nil {
+		c.writeServiceError(w, err)
+		return
+	}
+	c.writeJSON(w, http.StatusOK, key)
+}
+
+// ErrorResponse represents an error response
+type ErrorResponse struct {
+	Error struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+		Field   string `json:"field,omitempty"` // Request field that failed validation
+	} `json:"error"`
+}
+
+func (c *Controller) writeServiceError(w http.ResponseWriter, err error) {
+	var domainErr *errors.DomainError
+	switch {
+	case errors.IsDomainError(err, &domainErr) && domainErr.Code == errors.ErrCodeValidation:
+		c.writeFieldError(w, http.StatusBadRequest, "VALIDATION_ERROR", domainErr.Field, domainErr.Message)
+	case errors.IsDomainError(err, &domainErr) && domainErr.Code == errors.ErrCodeForbidden:
+		c.writeError(w, http.StatusForbidden, "FORBIDDEN", domainErr.Message)
+	case errors.IsDomainError(err, &domainErr) && domainErr.Code == errors.ErrCodeNotFound:
+		c.writeError(w, http.StatusNotFound, "NOT_FOUND", domainErr.Message)
+	default:
+		c.writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+	}
+}
+
+func (c *Controller) writeError(w http.ResponseWriter, status int, code, message string) {
+	c.writeFieldError(w, status, code, "", message)
+}
+
+func (c *Controller) writeFieldError(w http.ResponseWriter, status int, code, field, message string) {
+	resp := ErrorResponse{}
+	resp.Error.Code = code
+	resp.Error.Message = message
+	resp.Error.Field = field
+	c.writeJSON(w, status, resp)
+}
+
+func (c *Controller) writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
@@ -0,0 +1,72 @@
+// Package apikey handles organisation-scoped API keys.
+package apikey
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"strings"
+	"time"
+)
+
+// API keys have the form ek_<prefix>_<secret>. The prefix identifies the key
+// for lookup and is safe to display; only a SHA-256 hash of the full key is stored.
+const (
+	keyMarker    = "ek"
+	prefixLength = 8
+)
+
+// Entity represents a stored API key. The plaintext key is never stored.
+type Entity struct {
+	ID             string     `json:"id"`
+	OrganisationID string     `json:"organisationId"`
+	Name           string     `json:"name"`
+	Prefix         string     `json:"prefix"` // Identifies the key without revealing it
+	Hash           string     `json:"-"`      // Hex SHA-256 of the full key
+	Scopes         []string   `json:"scopes"`
+	ExpiresAt      time.Time  `json:"expiresAt"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	RevokedAt      *time.Time `json:"revokedAt,omitempty"`
+}
+
+// IsRevoked reports whether the key has been revoked
+func (k *Entity) IsRevoked() bool {
+	return k.RevokedAt != nil
+}
+
+// IsExpired reports whether the key has expired at the given time
+func (k *Entity) IsExpired(now time.Time) bool {
+	return !now.Before(k.ExpiresAt)
+}
+
+// Matches reports whether a presented key hashes to the stored hash
+func (k *Entity) Matches(key string) bool {
+	return subtle.ConstantTimeCompare([]byte(hashKey(key)), []byte(k.Hash)) == 1
+}
+
+// IssuedKey is returned when a key is issued or rotated. Key holds the
+// plaintext and is only ever shown once.
+type IssuedKey struct {
+	*Entity
+	Key string `json:"key"`
+}
+
+// hashKey returns the hex SHA-256 of a key
+func hashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// formatKey builds a key from its prefix and secret
+func formatKey(prefix, secret string) string {
+	return keyMarker + "_" + prefix + "_" + secret
+}
+
+// parseKey returns the prefix of a well-formed key
+func parseKey(key string) (prefix string, ok bool) {
+	parts := strings.SplitN(key, "_", 3)
+	if len(parts) != 3 || parts[0] != keyMarker || len(parts[1]) != prefixLength || parts[2] == "" {
+		return "", false
+	}
+	return parts[1], true
+}
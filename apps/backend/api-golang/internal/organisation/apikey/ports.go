@@ -0,0 +1,44 @@
+package apikey
+
+import (
+	"context"
+	"time"
+
+	"api-golang/internal/organisation/organisation"
+	"api-golang/internal/shared/auth"
+)
+
+// IssueKeyInput is the request to issue a new API key
+type IssueKeyInput struct {
+	OrganisationID string     `json:"organisationId"` // Defaults to the caller's organisation
+	Name           string     `json:"name"`
+	Scopes         []string   `json:"scopes"`
+	ExpiresAt      *time.Time `json:"expiresAt,omitempty"` // Defaults to DefaultKeyLifetime from now
+}
+
+// Repository defines the port for API key data access (driven port)
+type Repository interface {
+	GetByID(ctx context.Context, id string) (*Entity, error)
+	GetByPrefix(ctx context.Context, prefix string) (*Entity, error)
+	ListByOrganisation(ctx context.Context, organisationID string) ([]*Entity, error)
+	// Create returns a validation error on the prefix field when another key
+	// already has the new key's prefix
+	Create(ctx context.Context, key *Entity) error
+	Update(ctx context.Context, key *Entity) error
+}
+
+// OrganisationAccess defines the port for checking that a caller may act for
+// an organisation and that it is active (provided by the organisation domain)
+type OrganisationAccess interface {
+	ValidateOrganisation(ctx context.Context, callerOrgID, orgID string) (*organisation.Entity, error)
+	ValidateStatus(ctx context.Context, org *organisation.Entity) error
+}
+
+// Service defines the port for API key business logic (driving port)
+type Service interface {
+	auth.Authenticator
+	IssueKey(ctx context.Context, caller *auth.Principal, input IssueKeyInput) (*IssuedKey, error)
+	ListKeys(ctx context.Context, caller *auth.Principal, organisationID string) ([]*Entity, error)
+	RotateKey(ctx context.Context, caller *auth.Principal, id string) (*IssuedKey, error)
+	RevokeKey(ctx context.Context, caller *auth.Principal, id string) (*Entity, error)
+}
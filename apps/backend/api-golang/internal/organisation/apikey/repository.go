@@ -0,0 +1,141 @@
+package apikey
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"api-golang/internal/shared/auth"
+	"api-golang/internal/shared/errors"
+)
+
+const domainName = "apikey"
+
+// Development keys seeded by NewDevInMemoryRepository so the API can be
+// exercised locally. They are published in the README and must never be
+// accepted by a deployed server.
+const (
+	DevAPIKey      = "ek_devlocal_acme-bank-local-development-key"    // org-parent-1, every scope
+	DevChildAPIKey = "ek_devchild_acme-ireland-local-development-key" // org-child-1, quotes only
+)
+
+// InMemoryRepository implements Repository interface with in-memory storage
+type InMemoryRepository struct {
+	keys     map[string]*Entity
+	byPrefix map[string]*Entity // Every request authenticates by prefix
+	mu       sync.RWMutex
+}
+
+// NewInMemoryRepository creates a new empty repository
+func NewInMemoryRepository() *InMemoryRepository {
+	return &InMemoryRepository{
+		keys:     make(map[string]*Entity),
+		byPrefix: make(map[string]*Entity),
+	}
+}
+
+// NewDevInMemoryRepository creates a new repository seeded with the
+// development keys, for local use only
+func NewDevInMemoryRepository() *InMemoryRepository {
+	repo := NewInMemoryRepository()
+	repo.seed("key-dev-1", "org-parent-1", DevAPIKey, auth.AllScopes)
+	repo.seed("key-dev-2", "org-child-1", DevChildAPIKey, []string{auth.ScopeQuotesCreate, auth.ScopeQuotesRead, auth.ScopeQuotesAccept})
+
+	return repo
+}
+
+// seed stores a development key that does not expire
+func (r *InMemoryRepository) seed(id, organisationID, key string, scopes []string) {
+	prefix, _ := parseKey(key)
+	r.keys[id] = &Entity{
+		ID:             id,
+		OrganisationID: organisationID,
+		Name:           "Local development",
+		Prefix:         prefix,
+		Hash:           hashKey(key),
+		Scopes:         append([]string(nil), scopes...),
+		ExpiresAt:      time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC),
+		CreatedAt:      time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	r.byPrefix[prefix] = r.keys[id]
+}
+
+// GetByID retrieves an API key by ID
+func (r *InMemoryRepository) GetByID(_ context.Context, id string) (*Entity, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	key, exists := r.keys[id]
+	if !exists {
+		return nil, errors.NewNotFoundError(domainName, "API key not found")
+	}
+	return key, nil
+}
+
+// GetByPrefix retrieves an API key by its prefix
+func (r *InMemoryRepository) GetByPrefix(_ context.Context, prefix string) (*Entity, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	key, exists := r.byPrefix[prefix]
+	if !exists {
+		return nil, errors.NewNotFoundError(domainName, "API key not found")
+	}
+	return key, nil
+}
+
+// ListByOrganisation retrieves an organisation's API keys, newest first
+func (r *InMemoryRepository) ListByOrganisation(_ context.Context, organisationID string) ([]*Entity, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	keys := make([]*Entity, 0)
+	for _, key := range r.keys {
+		if key.OrganisationID == organisationID {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if !keys[i].CreatedAt.Equal(keys[j].CreatedAt) {
+			return keys[i].CreatedAt.After(keys[j].CreatedAt)
+		}
+		return keys[i].ID < keys[j].ID
+	})
+	return keys, nil
+}
+
+// Create stores a new API key
+func (r *InMemoryRepository) Create(_ context.Context, key *Entity) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.keys[key.ID]; exists {
+		return errors.NewValidationError(domainName, "API key already exists")
+	}
+	if _, exists := r.byPrefix[key.Prefix]; exists {
+		return errors.NewFieldValidationError(domainName, "prefix", "API key prefix already in use")
+	}
+
+	r.keys[key.ID] = key
+	r.byPrefix[key.Prefix] = key
+	return nil
+}
+
+// Update updates an existing API key
+func (r *InMemoryRepository) Update(_ context.Context, key *Entity) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, exists := r.keys[key.ID]
+	if !exists {
+		return errors.NewNotFoundError(domainName, "API key not found")
+	}
+	if key.Prefix != existing.Prefix {
+		return errors.NewFieldValidationError(domainName, "prefix", "API key prefix cannot change")
+	}
+
+	r.keys[key.ID] = key
+	r.byPrefix[key.Prefix] = key
+	return nil
+}
@@ -0,0 +1,257 @@
+package apikey
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"api-golang/internal/shared/auth"
+	"api-golang/internal/shared/errors"
+
+	"github.com/bilo-mono/packages/common/service"
+
+	"github.com/google/uuid"
+)
+
+// DefaultKeyLifetime is how long an API key is valid when no expiry is given
+const DefaultKeyLifetime = 365 * 24 * time.Hour
+
+// maxIssueAttempts bounds how often a new key is regenerated after its prefix
+// collides with an existing key's
+const maxIssueAttempts = 5
+
+// DefaultService implements the Service interface
+type DefaultService struct {
+	service.BaseService[Repository]
+	organisations OrganisationAccess
+	now           func() time.Time
+}
+
+// NewService creates a new API key service
+func NewService(repo Repository, organisations OrganisationAccess) *DefaultService {
+	return &DefaultService{
+		BaseService:   service.NewBaseService(repo),
+		organisations: organisations,
+		now:           time.Now,
+	}
+}
+
+// Authenticate resolves a presented API key to the organisation it was issued
+// to. Unknown, revoked and expired keys are rejected as unauthorized, and keys
+// of an organisation that is inactive, or has an inactive ancestor, with an
+// organisation inactive error.
+func (s *DefaultService) Authenticate(ctx context.Context, credential string) (*auth.Principal, error) {
+	prefix, ok := parseKey(credential)
+	if !ok {
+		return nil, errors.NewUnauthorizedError(domainName, "invalid API key")
+	}
+
+	key, err := s.Repo.GetByPrefix(ctx, prefix)
+	if err != nil {
+		var domainErr *errors.DomainError
+		if errors.IsDomainError(err, &domainErr) && domainErr.Code == errors.ErrCodeNotFound {
+			return nil, errors.NewUnauthorizedError(domainName, "invalid API key")
+		}
+		return nil, fmt.Errorf("looking up API key: %w", err)
+	}
+	if !key.Matches(credential) {
+		return nil, errors.NewUnauthorizedError(domainName, "invalid API key")
+	}
+	if key.IsRevoked() {
+		return nil, errors.NewUnauthorizedError(domainName, "API key has been revoked")
+	}
+	if key.IsExpired(s.now()) {
+		return nil, errors.NewUnauthorizedError(domainName, "API key has expired")
+	}
+
+	org, err := s.organisations.ValidateOrganisation(ctx, key.OrganisationID, key.OrganisationID)
+	if err != nil {
+		var domainErr *errors.DomainError
+		if errors.IsDomainError(err, &domainErr) && domainErr.Code == errors.ErrCodeNotFound {
+			return nil, errors.NewUnauthorizedError(domainName, "API key organisation no longer exists")
+		}
+		return nil, fmt.Errorf("getting API key organisation: %w", err)
+	}
+	if err := s.organisations.ValidateStatus(ctx, org); err != nil {
+		return nil, err
+	}
+
+	return &auth.Principal{
+		OrganisationID: key.OrganisationID,
+		CredentialType: auth.CredentialAPIKey,
+		CredentialID:   key.ID,
+		Scopes:         key.Scopes,
+		Product:        auth.ProductAPI,
+	}, nil
+}
+
+// IssueKey issues a new API key for the caller's organisation or one of its
+// descendants. A key can only be granted scopes the caller holds.
+func (s *DefaultService) IssueKey(ctx context.Context, caller *auth.Principal, input IssueKeyInput) (*IssuedKey, error) {
+	if input.OrganisationID == "" {
+		input.OrganisationID = caller.OrganisationID
+	}
+	if _, err := s.organisations.ValidateOrganisation(ctx, caller.OrganisationID, input.OrganisationID); err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return nil, errors.NewFieldValidationError(domainName, "name", "name is required")
+	}
+	if len(input.Scopes) == 0 {
+		return nil, errors.NewFieldValidationError(domainName, "scopes", "at least one scope is required")
+	}
+	for _, scope := range input.Scopes {
+		if !auth.IsValidScope(scope) {
+			return nil, errors.NewFieldValidationError(domainName, "scopes", fmt.Sprintf("unknown scope %q", scope))
+		}
+		if !caller.HasScope(scope) {
+			return nil, errors.NewFieldValidationError(domainName, "scopes", fmt.Sprintf("cannot grant scope %s the caller does not hold", scope))
+		}
+	}
+
+	now := s.now()
+	expiresAt := now.Add(DefaultKeyLifetime)
+	if input.ExpiresAt != nil {
+		if !input.ExpiresAt.After(now) {
+			return nil, errors.NewFieldValidationError(domainName, "expiresAt", "expiresAt must be in the future")
+		}
+		expiresAt = *input.ExpiresAt
+	}
+
+	return s.issue(ctx, &Entity{
+		OrganisationID: input.OrganisationID,
+		Name:           name,
+		Scopes:         append([]string(nil), input.Scopes...),
+		ExpiresAt:      expiresAt.UTC(),
+		CreatedAt:      now.UTC(),
+	})
+}
+
+// ListKeys lists the API keys of the caller's organisation or one of its descendants
+func (s *DefaultService) ListKeys(ctx context.Context, caller *auth.Principal, organisationID string) ([]*Entity, error) {
+	if organisationID == "" {
+		organisationID = caller.OrganisationID
+	}
+	if _, err := s.organisations.ValidateOrganisation(ctx, caller.OrganisationID, organisationID); err != nil {
+		return nil, err
+	}
+
+	keys, err := s.Repo.ListByOrganisation(ctx, organisationID)
+	if err != nil {
+		return nil, fmt.Errorf("listing API keys: %w", err)
+	}
+	return keys, nil
+}
+
+// RotateKey issues a replacement key with the same organisation, name, scopes
+// and lifetime, and revokes the old key immediately
+func (s *DefaultService) RotateKey(ctx context.Context, caller *auth.Principal, id string) (*IssuedKey, error) {
+	old, err := s.getAccessibleKey(ctx, caller, id)
+	if err != nil {
+		return nil, err
+	}
+	if old.IsRevoked() {
+		return nil, errors.NewValidationError(domainName, "cannot rotate a revoked API key")
+	}
+
+	now := s.now()
+	issued, err := s.issue(ctx, &Entity{
+		OrganisationID: old.OrganisationID,
+		Name:           old.Name,
+		Scopes:         append([]string(nil), old.Scopes...),
+		ExpiresAt:      now.Add(old.ExpiresAt.Sub(old.CreatedAt)).UTC(),
+		CreatedAt:      now.UTC(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.revoke(ctx, old); err != nil {
+		return nil, err
+	}
+	return issued, nil
+}
+
+// RevokeKey revokes an API key. Revoking an already revoked key is a no-op.
+func (s *DefaultService) RevokeKey(ctx context.Context, caller *auth.Principal, id string) (*Entity, error) {
+	key, err := s.getAccessibleKey(ctx, caller, id)
+	if err != nil {
+		return nil, err
+	}
+	if key.IsRevoked() {
+		return key, nil
+	}
+	return s.revoke(ctx, key)
+}
+
+// getAccessibleKey retrieves a key belonging to the caller's organisation or
+// one of its descendants. Other organisations' keys are reported as not found.
+func (s *DefaultService) getAccessibleKey(ctx context.Context, caller *auth.Principal, id string) (*Entity, error) {
+	key, err := s.Repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("getting API key: %w", err)
+	}
+	if _, err := s.organisations.ValidateOrganisation(ctx, caller.OrganisationID, key.OrganisationID); err != nil {
+		var domainErr *errors.DomainError
+		if errors.IsDomainError(err, &domainErr) && domainErr.Code == errors.ErrCodeForbidden {
+			return nil, errors.NewNotFoundError(domainName, "API key not found")
+		}
+		return nil, err
+	}
+	return key, nil
+}
+
+// issue generates the key material for a new key and stores it. Prefixes are
+// only 32 bits, so a prefix already in use is regenerated a few times.
+func (s *DefaultService) issue(ctx context.Context, key *Entity) (*IssuedKey, error) {
+	for attempt := 1; ; attempt++ {
+		prefix, secret, err := generateKeyMaterial()
+		if err != nil {
+			return nil, errors.NewInternalError(domainName, "generating API key", err)
+		}
+		plaintext := formatKey(prefix, secret)
+
+		key.ID = uuid.New().String()
+		key.Prefix = prefix
+		key.Hash = hashKey(plaintext)
+		err = s.Repo.Create(ctx, key)
+		if err == nil {
+			return &IssuedKey{Entity: key, Key: plaintext}, nil
+		}
+		var domainErr *errors.DomainError
+		if attempt < maxIssueAttempts && errors.IsDomainError(err, &domainErr) && domainErr.Field == "prefix" {
+			continue
+		}
+		return nil, fmt.Errorf("storing API key: %w", err)
+	}
+}
+
+// revoke stores a revoked copy of the key
+func (s *DefaultService) revoke(ctx context.Context, key *Entity) (*Entity, error) {
+	revoked := *key
+	revokedAt := s.now().UTC()
+	revoked.RevokedAt = &revokedAt
+	if err := s.Repo.Update(ctx, &revoked); err != nil {
+		return nil, fmt.Errorf("revoking API key: %w", err)
+	}
+	return &revoked, nil
+}
+
+// generateKeyMaterial returns a random hex prefix and a random 256-bit secret
+func generateKeyMaterial() (prefix, secret string, err error) {
+	prefixBytes := make([]byte, prefixLength/2)
+	if _, err := rand.Read(prefixBytes); err != nil {
+		return "", "", err
+	}
+	secretBytes := make([]byte, 32)
+	if _, err := rand.Read(secretBytes); err != nil {
+		return "", "", err
+	}
+	return hex.EncodeToString(prefixBytes), base64.RawURLEncoding.EncodeToString(secretBytes), nil
+}
@@ -0,0 +1,232 @@
+package apikey
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"api-golang/internal/finance/currency"
+	"api-golang/internal/organisation/organisation"
+	"api-golang/internal/platform/country"
+	"api-golang/internal/platform/mcc"
+	"api-golang/internal/shared/auth"
+	"api-golang/internal/shared/errors"
+	"api-golang/internal/shared/types"
+)
+
+func setupService() *DefaultService {
+	return NewService(NewDevInMemoryRepository(), setupOrganisations())
+}
+
+func setupOrganisations() *organisation.DefaultService {
+	return organisation.NewService(
+		organisation.NewInMemoryRepository(),
+		country.NewService(country.NewInMemoryRepository()),
+		currency.NewService(currency.NewInMemoryRepository()),
+		mcc.NewService(mcc.NewInMemoryRepository()),
+	)
+}
+
+// deactivate marks an organisation inactive through the repository, since no
+// caller can deactivate a top-level organisation through the service
+func deactivate(ctx context.Context, orgService *organisation.DefaultService, id string) error {
+	org, err := orgService.GetOrganisation(ctx, id)
+	if err != nil {
+		return err
+	}
+	org.Status = types.OrganisationStatus{Value: organisation.StatusInactive}
+	return orgService.Repo.Update(ctx, org)
+}
+
+func parentCaller() *auth.Principal {
+	return &auth.Principal{OrganisationID: "org-parent-1", CredentialID: "key-dev-1", Scopes: auth.AllScopes}
+}
+
+func assertErrorCode(t *testing.T, err error, code string) {
+	t.Helper()
+	var domainErr *errors.DomainError
+	if !errors.IsDomainError(err, &domainErr) || domainErr.Code != code {
+		t.Fatalf("Expected %s error, got %v", code, err)
+	}
+}
+
+func TestAuthenticate_DevKey(t *testing.T) {
+	svc := setupService()
+
+	principal, err := svc.Authenticate(context.Background(), DevAPIKey)
+	if err != nil {
+		t.Fatalf("Authenticate failed: %v", err)
+	}
+	if principal.OrganisationID != "org-parent-1" || !principal.HasScope(auth.ScopeQuotesCreate) {
+		t.Errorf("Unexpected principal %+v", principal)
+	}
+
+	for _, credential := range []string{"", "not-a-key", "ek_devlocal_wrong-secret", "ek_unknown1_secret"} {
+		_, err := svc.Authenticate(context.Background(), credential)
+		assertErrorCode(t, err, errors.ErrCodeUnauthorized)
+	}
+	// Only the development repository knows the published keys
+	plain := NewService(NewInMemoryRepository(), svc.organisations)
+	_, err = plain.Authenticate(context.Background(), DevAPIKey)
+	assertErrorCode(t, err, errors.ErrCodeUnauthorized)
+}
+
+func TestAuthenticate_InactiveOrganisation(t *testing.T) {
+	for _, tt := range []struct {
+		name         string
+		deactivateID string
+	}{
+		{name: "Inactive organisation", deactivateID: "org-child-1"},
+		{name: "Inactive ancestor", deactivateID: "org-parent-1"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			orgService := setupOrganisations()
+			svc := NewService(NewDevInMemoryRepository(), orgService)
+			ctx := context.Background()
+
+			if err := deactivate(ctx, orgService, tt.deactivateID); err != nil {
+				t.Fatalf("Deactivating organisation failed: %v", err)
+			}
+			_, err := svc.Authenticate(ctx, DevChildAPIKey)
+			assertErrorCode(t, err, errors.ErrCodeOrganisationInactive)
+		})
+	}
+}
+
+func TestIssueKey(t *testing.T) {
+	svc := setupService()
+	ctx := context.Background()
+
+	issued, err := svc.IssueKey(ctx, parentCaller(), IssueKeyInput{
+		OrganisationID: "org-child-1",
+		Name:           "Checkout",
+		Scopes:         []string{auth.ScopeQuotesCreate},
+	})
+	if err != nil {
+		t.Fatalf("IssueKey failed: %v", err)
+	}
+	if issued.Hash == issued.Key || issued.Hash != hashKey(issued.Key) {
+		t.Error("Expected only the hash of the key to be stored")
+	}
+
+	principal, err := svc.Authenticate(ctx, issued.Key)
+	if err != nil {
+		t.Fatalf("Authenticate failed: %v", err)
+	}
+	if principal.OrganisationID != "org-child-1" || principal.CredentialID != issued.ID {
+		t.Errorf("Unexpected principal %+v", principal)
+	}
+	if principal.HasScope(auth.ScopeAPIKeysManage) {
+		t.Error("Expected the key to only hold the granted scopes")
+	}
+
+	// The key expires after the default lifetime
+	svc.now = func() time.Time { return issued.CreatedAt.Add(DefaultKeyLifetime) }
+	_, err = svc.Authenticate(ctx, issued.Key)
+	assertErrorCode(t, err, errors.ErrCodeUnauthorized)
+}
+
+// collidingRepository rejects the first few new keys as prefix collisions
+type collidingRepository struct {
+	*InMemoryRepository
+	collisions int
+}
+
+func (r *collidingRepository) Create(ctx context.Context, key *Entity) error {
+	if r.collisions > 0 {
+		r.collisions--
+		return errors.NewFieldValidationError(domainName, "prefix", "API key prefix already in use")
+	}
+	return r.InMemoryRepository.Create(ctx, key)
+}
+
+func TestIssueKey_PrefixCollision(t *testing.T) {
+	ctx := context.Background()
+	input := IssueKeyInput{OrganisationID: "org-child-1", Name: "Checkout", Scopes: []string{auth.ScopeQuotesCreate}}
+
+	// A colliding prefix is regenerated
+	repo := &collidingRepository{InMemoryRepository: NewInMemoryRepository(), collisions: maxIssueAttempts - 1}
+	svc := NewService(repo, setupOrganisations())
+	issued, err := svc.IssueKey(ctx, parentCaller(), input)
+	if err != nil {
+		t.Fatalf("IssueKey failed: %v", err)
+	}
+	if _, err := svc.Authenticate(ctx, issued.Key); err != nil {
+		t.Errorf("Authenticate failed: %v", err)
+	}
+
+	// ...but not forever
+	repo.collisions = maxIssueAttempts
+	_, err = svc.IssueKey(ctx, parentCaller(), input)
+	assertErrorCode(t, err, errors.ErrCodeValidation)
+}
+
+func TestIssueKey_Validation(t *testing.T) {
+	svc := setupService()
+	ctx := context.Background()
+	past := time.Now().Add(-time.Hour)
+	quotesOnly := &auth.Principal{OrganisationID: "org-parent-1", Scopes: []string{auth.ScopeQuotesCreate, auth.ScopeAPIKeysManage}}
+
+	tests := []struct {
+		name   string
+		caller *auth.Principal
+		input  IssueKeyInput
+		code   string
+	}{
+		{name: "Missing name", caller: parentCaller(), input: IssueKeyInput{Scopes: []string{auth.ScopeQuotesCreate}}, code: errors.ErrCodeValidation},
+		{name: "No scopes", caller: parentCaller(), input: IssueKeyInput{Name: "k"}, code: errors.ErrCodeValidation},
+		{name: "Unknown scope", caller: parentCaller(), input: IssueKeyInput{Name: "k", Scopes: []string{"admin"}}, code: errors.ErrCodeValidation},
+		{name: "Scope the caller lacks", caller: quotesOnly, input: IssueKeyInput{Name: "k", Scopes: []string{auth.ScopeOrganisationsManage}}, code: errors.ErrCodeValidation},
+		{name: "Expiry in the past", caller: parentCaller(), input: IssueKeyInput{Name: "k", Scopes: []string{auth.ScopeQuotesCreate}, ExpiresAt: &past}, code: errors.ErrCodeValidation},
+		{
+			name:   "Organisation outside the caller's hierarchy",
+			caller: &auth.Principal{OrganisationID: "org-child-1", Scopes: auth.AllScopes},
+			input:  IssueKeyInput{OrganisationID: "org-parent-1", Name: "k", Scopes: []string{auth.ScopeQuotesCreate}},
+			code:   errors.ErrCodeForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.IssueKey(ctx, tt.caller, tt.input)
+			assertErrorCode(t, err, tt.code)
+		})
+	}
+}
+
+func TestRotateAndRevokeKey(t *testing.T) {
+	svc := setupService()
+	ctx := context.Background()
+
+	issued, err := svc.IssueKey(ctx, parentCaller(), IssueKeyInput{Name: "Server", Scopes: []string{auth.ScopeQuotesRead}})
+	if err != nil {
+		t.Fatalf("IssueKey failed: %v", err)
+	}
+
+	rotated, err := svc.RotateKey(ctx, parentCaller(), issued.ID)
+	if err != nil {
+		t.Fatalf("RotateKey failed: %v", err)
+	}
+	if rotated.ID == issued.ID || rotated.Name != "Server" || rotated.Scopes[0] != auth.ScopeQuotesRead {
+		t.Errorf("Unexpected rotated key %+v", rotated.Entity)
+	}
+	_, err = svc.Authenticate(ctx, issued.Key)
+	assertErrorCode(t, err, errors.ErrCodeUnauthorized)
+	if _, err := svc.Authenticate(ctx, rotated.Key); err != nil {
+		t.Fatalf("Expected rotated key to authenticate: %v", err)
+	}
+
+	// Keys of other organisations are not visible to a child organisation
+	_, err = svc.RevokeKey(ctx, &auth.Principal{OrganisationID: "org-child-1", Scopes: auth.AllScopes}, rotated.ID)
+	assertErrorCode(t, err, errors.ErrCodeNotFound)
+
+	revoked, err := svc.RevokeKey(ctx, parentCaller(), rotated.ID)
+	if err != nil {
+		t.Fatalf("RevokeKey failed: %v", err)
+	}
+	if !revoked.IsRevoked() {
+		t.Error("Expected key to be revoked")
+	}
+	_, err = svc.Authenticate(ctx, rotated.Key)
+	assertErrorCode(t, err, errors.ErrCodeUnauthorized)
+}
@@ -0,0 +1,157 @@
+package customer
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"api-golang/internal/shared/auth"
+	"api-golang/internal/shared/errors"
+)
+
+// Controller handles HTTP requests for customer management
+type Controller struct {
+	service       Service
+	organisations OrganisationAccess
+}
+
+// NewController creates a new customer controller
+func NewController(service Service, organisations OrganisationAccess) *Controller {
+	return &Controller{service: service, organisations: organisations}
+}
+
+// HandleCustomers handles GET /api/customers?organisationId={id}&country={code}
+// organisationId defaults to the caller's organisation.
+func (c *Controller) HandleCustomers(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		c.writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed")
+		return
+	}
+
+	caller, ok := auth.PrincipalFromContext(r.Context())
+	if !ok {
+		c.writeError(w, http.StatusUnauthorized, errors.ErrCodeUnauthorized, "API key is required")
+		return
+	}
+
+	query := r.URL.Query()
+	filter := ListFilter{OrganisationID: query.Get("organisationId"), CountryCode: query.Get("country")}
+	if filter.OrganisationID == "" {
+		filter.OrganisationID = caller.OrganisationID
+	}
+	if _, err := c.organisations.ValidateOrganisation(r.Context(), caller.OrganisationID, filter.OrganisationID); err != nil {
+		c.writeServiceError(w, err)
+		return
+	}
+
+	customers, err := c.service.ListCustomers(r.Context(), filter)
+	if err != nil {
+		c.writeServiceError(w, err)
+		return
+	}
+	c.writeJSON(w, http.StatusOK, customers)
+}
+
+// HandleCustomer handles GET and PATCH /api/customers/{id}
+func (c *Controller) HandleCustomer(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/customers/"), "/"), "/")
+	id := parts[0]
+	if id == "" {
+		c.writeError(w, http.StatusBadRequest, "MISSING_FIELD", "Customer ID is required")
+		return
+	}
+	if len(parts) != 1 {
+		c.writeError(w, http.StatusNotFound, "NOT_FOUND", "Resource not found")
+		return
+	}
+
+	caller, ok := auth.PrincipalFromContext(r.Context())
+	if !ok {
+		c.writeError(w, http.StatusUnauthorized, errors.ErrCodeUnauthorized, "API key is required")
+		return
+	}
+	customer, ok := c.getAccessibleCustomer(w, r, caller, id)
+	if !ok {
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		c.writeJSON(w, http.StatusOK, customer)
+	case http.MethodPatch:
+		var input UpdateCustomerInput
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+			c.writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body: "+err.Error())
+			return
+		}
+		updated, err := c.service.UpdateCustomer(r.Context(), id, input)
+		if err != nil {
+			c.writeServiceError(w, err)
+			return
+		}
+		c.writeJSON(w, http.StatusOK, updated)
+	default:
+		c.writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed")
+	}
+}
+
+// getAccessibleCustomer retrieves a customer of the caller's organisation or
+// one of its descendants, writing a not found error for any other customer
+func (c *Controller) getAccessibleCustomer(w http.ResponseWriter, r *http.Request, caller *auth.Principal, id string) (*Entity, bool) {
+	customer, err := c.service.GetCustomer(r.Context(), id)
+	if err != nil {
+		c.writeServiceError(w, err)
+		return nil, false
+	}
+	if _, err := c.organisations.ValidateOrganisation(r.Context(), caller.OrganisationID, customer.OrganisationID); err != nil {
+		var domainErr *errors.DomainError
+		if errors.IsDomainError(err, &domainErr) && domainErr.Code == errors.ErrCodeForbidden {
+			c.writeError(w, http.StatusNotFound, "NOT_FOUND", "Customer not found")
+			return nil, false
+		}
+		c.writeServiceError(w, err)
+		return nil, false
+	}
+	return customer, true
+}
+
+// ErrorResponse represents an error response
+type ErrorResponse struct {
+	Error struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+		Field   string `json:"field,omitempty"` // Request field that failed validation
+	} `json:"error"`
+}
+
+func (c *Controller) writeServiceError(w http.ResponseWriter, err error) {
+	var domainErr *errors.DomainError
+	switch {
+	case errors.IsDomainError(err, &domainErr) && domainErr.Code == errors.ErrCodeValidation:
+		c.writeFieldError(w, http.StatusBadRequest, "VALIDATION_ERROR", domainErr.Field, domainErr.Message)
+	case errors.IsDomainError(err, &domainErr) && domainErr.Code == errors.ErrCodeForbidden:
+		c.writeError(w, http.StatusForbidden, "FORBIDDEN", domainErr.Message)
+	case errors.IsDomainError(err, &domainErr) && domainErr.Code == errors.ErrCodeNotFound:
+		c.writeError(w, http.StatusNotFound, "NOT_FOUND", domainErr.Message)
+	default:
+		c.writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+	}
+}
+
+func (c *Controller) writeError(w http.ResponseWriter, status int, code, message string) {
+	c.writeFieldError(w, status, code, "", message)
+}
+
+func (c *Controller) writeFieldError(w http.ResponseWriter, status int, code, field, message string) {
+	resp := ErrorResponse{}
+	resp.Error.Code = code
+	resp.Error.Message = message
+	resp.Error.Field = field
+	c.writeJSON(w, status, resp)
+}
+
+func (c *Controller) writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
@@ -8,17 +8,18 @@ import (
 // Entity represents a stored customer in the system
 // This is the internal representation, different from the quote request customer object
 type Entity struct {
-	ID             string    `json:"id"`
-	OrganisationID string    `json:"organisationId"`
-	Reference      string    `json:"reference"` // Client's internal reference for the customer
-	Email          *string   `json:"email,omitempty"`
-	Name           *string   `json:"name,omitempty"`
-	PostalCode     *string   `json:"postalCode,omitempty"`
-	City           *string   `json:"city,omitempty"`
-	State          *string   `json:"state,omitempty"`
-	CountryCode    string    `json:"countryCode"` // ISO 3166-1 alpha-3 (3 chars)
-	CreatedAt      time.Time `json:"createdAt"`
-	UpdatedAt      time.Time `json:"updatedAt"`
+	ID             string     `json:"id"`
+	OrganisationID string     `json:"organisationId"`
+	Reference      string     `json:"reference"` // Client's internal reference for the customer, empty once erased
+	Email          *string    `json:"email,omitempty"`
+	Name           *string    `json:"name,omitempty"`
+	PostalCode     *string    `json:"postalCode,omitempty"`
+	City           *string    `json:"city,omitempty"`
+	State          *string    `json:"state,omitempty"`
+	CountryCode    string     `json:"countryCode"`        // ISO 3166-1 alpha-3 (3 chars)
+	ErasedAt       *time.Time `json:"erasedAt,omitempty"` // Set when the customer's personal data was erased
+	CreatedAt      time.Time  `json:"createdAt"`
+	UpdatedAt      time.Time  `json:"updatedAt"`
 }
 
 // QuoteCustomer represents the customer object used in quote requests
@@ -1,7 +1,20 @@
 // Package customer defines ports (interfaces) for the customer sub-domain.
 package customer
 
-import "context"
+import (
+	"context"
+
+	"api-golang/internal/organisation/organisation"
+	"api-golang/internal/platform/country"
+)
+
+// UpdatePolicy controls how GetOrCreateCustomer treats the details of a returning customer
+type UpdatePolicy string
+
+const (
+	UpdatePolicyKeep     UpdatePolicy = ""         // Keep the stored details
+	UpdatePolicyOnChange UpdatePolicy = "onChange" // Replace stored details that differ from the input
+)
 
 // CreateCustomerInput represents input for creating/finding a customer
 type CreateCustomerInput struct {
@@ -13,16 +26,56 @@ type CreateCustomerInput struct {
 	State          *string
 	PostalCode     *string
 	City           *string
+	UpdatePolicy   UpdatePolicy // What to do when a returning customer's details differ
+}
+
+// UpdateCustomerInput represents a partial update; nil fields are left unchanged
+// and empty strings clear optional fields. Changing the country clears the
+// state, postal code and city unless new ones are given.
+type UpdateCustomerInput struct {
+	Email       *string `json:"email,omitempty"`
+	Name        *string `json:"name,omitempty"`
+	CountryCode *string `json:"countryCode,omitempty"`
+	State       *string `json:"state,omitempty"`
+	PostalCode  *string `json:"postalCode,omitempty"`
+	City        *string `json:"city,omitempty"`
+}
+
+// ListFilter narrows a customer listing
+type ListFilter struct {
+	OrganisationID string // Required
+	CountryCode    string // Optional ISO-3 country code
 }
 
 // Repository defines the port for customer data access (driven adapter)
 type Repository interface {
 	GetByID(ctx context.Context, id string) (*Entity, error)
 	GetByReference(ctx context.Context, organisationID, reference string) (*Entity, error)
+	List(ctx context.Context, filter ListFilter) ([]*Entity, error)
 	Create(ctx context.Context, customer *Entity) error
+	Update(ctx context.Context, customer *Entity) error
+}
+
+// CountryValidator defines the port for address validation (provided by the country domain)
+type CountryValidator interface {
+	GetCountryByCode(ctx context.Context, code string) (*country.Entity, error)
+	ValidateSubdivision(ctx context.Context, countryCode, subdivision string) (*country.Subdivision, error)
+	NormalisePostalCode(ctx context.Context, countryCode, postalCode string) (string, error)
+}
+
+// OrganisationAccess defines the port for checking that a caller may act for
+// an organisation (provided by the organisation domain)
+type OrganisationAccess interface {
+	ValidateOrganisation(ctx context.Context, callerOrgID, orgID string) (*organisation.Entity, error)
 }
 
 // Service defines the port for customer business logic (driving port)
 type Service interface {
+	GetCustomer(ctx context.Context, id string) (*Entity, error)
+	ListCustomers(ctx context.Context, filter ListFilter) ([]*Entity, error)
 	GetOrCreateCustomer(ctx context.Context, input CreateCustomerInput) (*Entity, error)
+	UpdateCustomer(ctx context.Context, id string, input UpdateCustomerInput) (*Entity, error)
+	// EraseCustomer removes a customer's personal data, keeping the record and
+	// its country for tax reporting
+	EraseCustomer(ctx context.Context, id string) (*Entity, error)
 }
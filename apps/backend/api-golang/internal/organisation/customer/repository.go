@@ -2,6 +2,7 @@ package customer
 
 import (
 	"context"
+	"sort"
 	"sync"
 
 	"api-golang/internal/shared/errors"
@@ -70,3 +71,47 @@ func (r *InMemoryRepository) Create(_ context.Context, customer *Entity) error {
 	}
 	return nil
 }
+
+// List retrieves an organisation's customers, oldest first
+func (r *InMemoryRepository) List(_ context.Context, filter ListFilter) ([]*Entity, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	customers := make([]*Entity, 0)
+	for _, customer := range r.customers {
+		if customer.OrganisationID != filter.OrganisationID {
+			continue
+		}
+		if filter.CountryCode != "" && customer.CountryCode != filter.CountryCode {
+			continue
+		}
+		customers = append(customers, customer)
+	}
+	sort.Slice(customers, func(i, j int) bool {
+		if !customers[i].CreatedAt.Equal(customers[j].CreatedAt) {
+			return customers[i].CreatedAt.Before(customers[j].CreatedAt)
+		}
+		return customers[i].ID < customers[j].ID
+	})
+	return customers, nil
+}
+
+// Update updates an existing customer
+func (r *InMemoryRepository) Update(_ context.Context, customer *Entity) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, exists := r.customers[customer.ID]
+	if !exists {
+		return errors.NewNotFoundError(domainName, "customer not found")
+	}
+
+	if existing.Reference != "" {
+		delete(r.referenceKeys, existing.OrganisationID+":"+existing.Reference)
+	}
+	r.customers[customer.ID] = customer
+	if customer.Reference != "" {
+		r.referenceKeys[customer.OrganisationID+":"+customer.Reference] = customer.ID
+	}
+	return nil
+}
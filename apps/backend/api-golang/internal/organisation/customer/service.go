@@ -3,6 +3,7 @@ package customer
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"api-golang/internal/shared/errors"
@@ -15,22 +16,52 @@ import (
 // DefaultService implements the Service interface
 type DefaultService struct {
 	service.BaseService[Repository]
+	countries CountryValidator
 }
 
 // NewService creates a new customer service
-func NewService(repo Repository) *DefaultService {
+func NewService(repo Repository, countries CountryValidator) *DefaultService {
 	return &DefaultService{
 		BaseService: service.NewBaseService(repo),
+		countries:   countries,
 	}
 }
 
-// GetOrCreateCustomer finds an existing customer or creates a new one
+// GetCustomer retrieves a customer by ID
+func (s *DefaultService) GetCustomer(ctx context.Context, id string) (*Entity, error) {
+	customer, err := s.Repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("getting customer: %w", err)
+	}
+	return customer, nil
+}
+
+// ListCustomers retrieves an organisation's customers
+func (s *DefaultService) ListCustomers(ctx context.Context, filter ListFilter) ([]*Entity, error) {
+	if filter.OrganisationID == "" {
+		return nil, errors.NewFieldValidationError(domainName, "organisationId", "organisationId is required")
+	}
+	filter.CountryCode = strings.ToUpper(filter.CountryCode)
+
+	customers, err := s.Repo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("listing customers: %w", err)
+	}
+	return customers, nil
+}
+
+// GetOrCreateCustomer finds an existing customer or creates a new one. With
+// UpdatePolicyOnChange, a returning customer's stored details are updated
+// when the input differs, e.g. after they move country.
 func (s *DefaultService) GetOrCreateCustomer(ctx context.Context, input CreateCustomerInput) (*Entity, error) {
 	// Try to find existing customer by reference
 	if input.Reference != "" {
 		existing, err := s.Repo.GetByReference(ctx, input.OrganisationID, input.Reference)
 		if err == nil {
-			return existing, nil
+			if input.UpdatePolicy != UpdatePolicyOnChange {
+				return existing, nil
+			}
+			return s.updateOnChange(ctx, existing, input)
 		}
 		// If error is not "not found", return it
 		var domainErr *errors.DomainError
@@ -61,3 +92,150 @@ func (s *DefaultService) GetOrCreateCustomer(ctx context.Context, input CreateCu
 
 	return customer, nil
 }
+
+// UpdateCustomer validates and applies a partial update to a customer
+func (s *DefaultService) UpdateCustomer(ctx context.Context, id string, input UpdateCustomerInput) (*Entity, error) {
+	existing, err := s.Repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("getting customer: %w", err)
+	}
+	if existing.ErasedAt != nil {
+		return nil, errors.NewValidationError(domainName, "customer has been erased")
+	}
+	updated := *existing
+
+	if input.CountryCode != nil {
+		c, err := s.countries.GetCountryByCode(ctx, strings.ToUpper(*input.CountryCode))
+		if err != nil {
+			var domainErr *errors.DomainError
+			if errors.IsDomainError(err, &domainErr) && domainErr.Code == errors.ErrCodeNotFound {
+				return nil, errors.NewFieldValidationError(domainName, "countryCode",
+					fmt.Sprintf("unknown country code: %s", *input.CountryCode))
+			}
+			return nil, fmt.Errorf("getting country %s: %w", *input.CountryCode, err)
+		}
+		if c.ISO3Code != updated.CountryCode {
+			// The old location details belong to the old country
+			updated.CountryCode = c.ISO3Code
+			updated.State, updated.PostalCode, updated.City = nil, nil, nil
+		}
+	}
+	if input.State != nil {
+		updated.State = emptyToNil(input.State)
+	}
+	if input.PostalCode != nil {
+		updated.PostalCode = emptyToNil(input.PostalCode)
+	}
+	if input.City != nil {
+		updated.City = emptyToNil(input.City)
+	}
+	if input.Email != nil {
+		updated.Email = emptyToNil(input.Email)
+	}
+	if input.Name != nil {
+		updated.Name = emptyToNil(input.Name)
+	}
+
+	if updated.State != nil {
+		sub, err := s.countries.ValidateSubdivision(ctx, updated.CountryCode, *updated.State)
+		if err != nil {
+			return nil, fieldError("state", err)
+		}
+		if sub != nil {
+			// Store the local code the tax rates are keyed by, e.g. "CA" for "California"
+			state := sub.LocalCode()
+			updated.State = &state
+		}
+	}
+	if updated.PostalCode != nil {
+		postalCode, err := s.countries.NormalisePostalCode(ctx, updated.CountryCode, *updated.PostalCode)
+		if err != nil {
+			return nil, fieldError("postalCode", err)
+		}
+		updated.PostalCode = &postalCode
+	}
+
+	updated.UpdatedAt = time.Now()
+	if err := s.Repo.Update(ctx, &updated); err != nil {
+		return nil, fmt.Errorf("updating customer: %w", err)
+	}
+	return &updated, nil
+}
+
+// EraseCustomer removes a customer's reference, contact details, city and
+// postal code. The record keeps the country and state so that quotes and
+// footprints still resolve to a customer in the right tax jurisdiction; the
+// client's reference is released, so a later quote with it creates a new
+// customer.
+func (s *DefaultService) EraseCustomer(ctx context.Context, id string) (*Entity, error) {
+	existing, err := s.Repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("getting customer: %w", err)
+	}
+
+	now := time.Now()
+	erased := *existing
+	erased.Reference = ""
+	erased.Email, erased.Name = nil, nil
+	erased.PostalCode, erased.City = nil, nil
+	erased.ErasedAt = &now
+	erased.UpdatedAt = now
+	if err := s.Repo.Update(ctx, &erased); err != nil {
+		return nil, fmt.Errorf("updating customer: %w", err)
+	}
+	return &erased, nil
+}
+
+// updateOnChange stores the input details of a returning customer that differ
+// from the stored ones. A new country replaces the whole stored location.
+func (s *DefaultService) updateOnChange(ctx context.Context, existing *Entity, input CreateCustomerInput) (*Entity, error) {
+	updated := *existing
+	changed := false
+
+	if input.CountryCode != "" && input.CountryCode != updated.CountryCode {
+		updated.CountryCode = input.CountryCode
+		updated.State, updated.PostalCode, updated.City = input.State, input.PostalCode, input.City
+		changed = true
+	}
+	for _, field := range []struct {
+		stored **string
+		input  *string
+	}{
+		{&updated.State, input.State},
+		{&updated.PostalCode, input.PostalCode},
+		{&updated.City, input.City},
+		{&updated.Email, input.Email},
+		{&updated.Name, input.Name},
+	} {
+		if field.input != nil && (*field.stored == nil || **field.stored != *field.input) {
+			*field.stored = field.input
+			changed = true
+		}
+	}
+
+	if !changed {
+		return existing, nil
+	}
+	updated.UpdatedAt = time.Now()
+	if err := s.Repo.Update(ctx, &updated); err != nil {
+		return nil, fmt.Errorf("updating customer: %w", err)
+	}
+	return &updated, nil
+}
+
+// fieldError attaches the request field to a validation error from another domain
+func fieldError(field string, err error) error {
+	var domainErr *errors.DomainError
+	if errors.IsDomainError(err, &domainErr) && domainErr.Code == errors.ErrCodeValidation {
+		return errors.NewFieldValidationError(domainName, field, domainErr.Message)
+	}
+	return fmt.Errorf("%s: %w", field, err)
+}
+
+// emptyToNil clears optional fields set to an empty string
+func emptyToNil(s *string) *string {
+	if s == nil || *s == "" {
+		return nil
+	}
+	return s
+}
@@ -0,0 +1,142 @@
+package customer
+
+import (
+	"context"
+	"testing"
+
+	"api-golang/internal/platform/country"
+	"api-golang/internal/shared/errors"
+)
+
+func setupService() *DefaultService {
+	return NewService(NewInMemoryRepository(), country.NewService(country.NewInMemoryRepository()))
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func TestGetOrCreateCustomer_UpdatePolicy(t *testing.T) {
+	svc := setupService()
+	ctx := context.Background()
+
+	created, err := svc.GetOrCreateCustomer(ctx, CreateCustomerInput{
+		OrganisationID: "org-1",
+		Reference:      "cust-1",
+		CountryCode:    "GBR",
+		PostalCode:     stringPtr("M1 1AD"),
+		City:           stringPtr("Manchester"),
+	})
+	if err != nil {
+		t.Fatalf("GetOrCreateCustomer failed: %v", err)
+	}
+
+	// By default the stored details are kept
+	moved := CreateCustomerInput{OrganisationID: "org-1", Reference: "cust-1", CountryCode: "FRA", City: stringPtr("Paris")}
+	kept, err := svc.GetOrCreateCustomer(ctx, moved)
+	if err != nil {
+		t.Fatalf("GetOrCreateCustomer failed: %v", err)
+	}
+	if kept.ID != created.ID || kept.CountryCode != "GBR" {
+		t.Errorf("Expected stored GBR customer, got %+v", kept)
+	}
+
+	// With update-on-change a new country replaces the whole location
+	moved.UpdatePolicy = UpdatePolicyOnChange
+	updated, err := svc.GetOrCreateCustomer(ctx, moved)
+	if err != nil {
+		t.Fatalf("GetOrCreateCustomer failed: %v", err)
+	}
+	if updated.ID != created.ID || updated.CountryCode != "FRA" || updated.PostalCode != nil || *updated.City != "Paris" {
+		t.Errorf("Expected customer moved to Paris, got %+v", updated)
+	}
+	stored, _ := svc.GetCustomer(ctx, created.ID)
+	if stored.CountryCode != "FRA" {
+		t.Errorf("Expected the move to be stored, got %s", stored.CountryCode)
+	}
+}
+
+func TestUpdateCustomer(t *testing.T) {
+	svc := setupService()
+	ctx := context.Background()
+
+	created, err := svc.GetOrCreateCustomer(ctx, CreateCustomerInput{
+		OrganisationID: "org-1",
+		Reference:      "cust-1",
+		CountryCode:    "GBR",
+		PostalCode:     stringPtr("M1 1AD"),
+		Email:          stringPtr("old@example.com"),
+	})
+	if err != nil {
+		t.Fatalf("GetOrCreateCustomer failed: %v", err)
+	}
+
+	updated, err := svc.UpdateCustomer(ctx, created.ID, UpdateCustomerInput{
+		CountryCode: stringPtr("us"),
+		State:       stringPtr("CA"),
+		PostalCode:  stringPtr("94105"),
+		Email:       stringPtr(""),
+	})
+	if err != nil {
+		t.Fatalf("UpdateCustomer failed: %v", err)
+	}
+	if updated.CountryCode != "USA" || *updated.State != "CA" || *updated.PostalCode != "94105" || updated.Email != nil {
+		t.Errorf("Unexpected update %+v", updated)
+	}
+
+	for _, state := range []string{"California", "US-CA", "ca"} {
+		updated, err := svc.UpdateCustomer(ctx, created.ID, UpdateCustomerInput{State: stringPtr(state)})
+		if err != nil {
+			t.Fatalf("UpdateCustomer with state %q failed: %v", state, err)
+		}
+		if *updated.State != "CA" {
+			t.Errorf("Expected state %q stored as CA, got %q", state, *updated.State)
+		}
+	}
+
+	for _, tt := range []struct {
+		name  string
+		input UpdateCustomerInput
+		field string
+	}{
+		{name: "Unknown country", input: UpdateCustomerInput{CountryCode: stringPtr("XXX")}, field: "countryCode"},
+		{name: "Unknown state", input: UpdateCustomerInput{State: stringPtr("ZZ")}, field: "state"},
+		{name: "Invalid postal code", input: UpdateCustomerInput{PostalCode: stringPtr("ABC")}, field: "postalCode"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.UpdateCustomer(ctx, created.ID, tt.input)
+			var domainErr *errors.DomainError
+			if !errors.IsDomainError(err, &domainErr) || domainErr.Field != tt.field {
+				t.Fatalf("Expected validation error on %s, got %v", tt.field, err)
+			}
+		})
+	}
+}
+
+func TestListCustomers(t *testing.T) {
+	svc := setupService()
+	ctx := context.Background()
+
+	for _, input := range []CreateCustomerInput{
+		{OrganisationID: "org-1", Reference: "a", CountryCode: "GBR"},
+		{OrganisationID: "org-1", Reference: "b", CountryCode: "FRA"},
+		{OrganisationID: "org-2", Reference: "c", CountryCode: "GBR"},
+	} {
+		if _, err := svc.GetOrCreateCustomer(ctx, input); err != nil {
+			t.Fatalf("GetOrCreateCustomer failed: %v", err)
+		}
+	}
+
+	customers, err := svc.ListCustomers(ctx, ListFilter{OrganisationID: "org-1"})
+	if err != nil {
+		t.Fatalf("ListCustomers failed: %v", err)
+	}
+	if len(customers) != 2 {
+		t.Errorf("Expected 2 customers for org-1, got %d", len(customers))
+	}
+
+	customers, _ = svc.ListCustomers(ctx, ListFilter{OrganisationID: "org-1", CountryCode: "gbr"})
+	if len(customers) != 1 || customers[0].Reference != "a" {
+		t.Errorf("Expected only customer a, got %+v", customers)
+	}
+}
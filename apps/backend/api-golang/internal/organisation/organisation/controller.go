@@ -0,0 +1,216 @@
+package organisation
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"api-golang/internal/shared/auth"
+	"api-golang/internal/shared/errors"
+)
+
+// Controller handles HTTP requests for organisation management
+type Controller struct {
+	service Service
+}
+
+// NewController creates a new organisation controller
+func NewController(service Service) *Controller {
+	return &Controller{service: service}
+}
+
+// DeactivateRequest is the optional body of a deactivation request
+type DeactivateRequest struct {
+	Message *string `json:"message,omitempty"` // Shown as the organisation status message
+}
+
+// HandleOrganisations handles GET and POST /api/organisations
+// Callers can only see and manage their own organisation and its descendants.
+func (c *Controller) HandleOrganisations(w http.ResponseWriter, r *http.Request) {
+	caller, ok := auth.PrincipalFromContext(r.Context())
+	if !ok {
+		c.writeError(w, http.StatusUnauthorized, errors.ErrCodeUnauthorized, "API key is required")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		c.handleList(w, r, caller)
+	case http.MethodPost:
+		c.handleCreate(w, r, caller)
+	default:
+		c.writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed")
+	}
+}
+
+// HandleOrganisation handles /api/organisations/{id}, /api/organisations/{id}/children
+// and /api/organisations/{id}/deactivate|reactivate
+func (c *Controller) HandleOrganisation(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/organisations/"), "/"), "/")
+	id := parts[0]
+	if id == "" {
+		c.writeError(w, http.StatusBadRequest, "MISSING_FIELD", "Organisation ID is required")
+		return
+	}
+
+	caller, ok := auth.PrincipalFromContext(r.Context())
+	if !ok {
+		c.writeError(w, http.StatusUnauthorized, errors.ErrCodeUnauthorized, "API key is required")
+		return
+	}
+	org, err := c.service.ValidateOrganisation(r.Context(), caller.OrganisationID, id)
+	if err != nil {
+		c.writeServiceError(w, err)
+		return
+	}
+
+	switch {
+	case len(parts) == 1 && r.Method == http.MethodGet:
+		c.writeJSON(w, http.StatusOK, org)
+	case len(parts) == 1 && r.Method == http.MethodPatch:
+		c.handleUpdate(w, r, caller, id)
+	case len(parts) == 2 && parts[1] == "children" && r.Method == http.MethodGet:
+		children, err := c.service.ListOrganisations(r.Context(), ListFilter{ParentOrganisationID: &id})
+		if err != nil {
+			c.writeServiceError(w, err)
+			return
+		}
+		c.writeJSON(w, http.StatusOK, children)
+	case len(parts) == 2 && parts[1] == "deactivate" && r.Method == http.MethodPost:
+		c.handleDeactivate(w, r, caller, id)
+	case len(parts) == 2 && parts[1] == "reactivate" && r.Method == http.MethodPost:
+		c.handleReactivate(w, r, caller, id)
+	case len(parts) == 1 || (len(parts) == 2 && (parts[1] == "children" || parts[1] == "deactivate" || parts[1] == "reactivate")):
+		c.writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed")
+	default:
+		c.writeError(w, http.StatusNotFound, "NOT_FOUND", "Resource not found")
+	}
+}
+
+// handleList handles GET /api/organisations?parentId={id}&status={status}
+// parentId defaults to the caller's organisation.
+func (c *Controller) handleList(w http.ResponseWriter, r *http.Request, caller *auth.Principal) {
+	query := r.URL.Query()
+	parentID := query.Get("parentId")
+	if parentID == "" {
+		parentID = caller.OrganisationID
+	}
+	if _, err := c.service.ValidateOrganisation(r.Context(), caller.OrganisationID, parentID); err != nil {
+		c.writeServiceError(w, err)
+		return
+	}
+	filter := ListFilter{ParentOrganisationID: &parentID, Status: query.Get("status")}
+
+	orgs, err := c.service.ListOrganisations(r.Context(), filter)
+	if err != nil {
+		c.writeServiceError(w, err)
+		return
+	}
+	c.writeJSON(w, http.StatusOK, orgs)
+}
+
+// handleCreate handles POST /api/organisations
+// New organisations are created under the caller's organisation unless another
+// parent within the caller's hierarchy is given.
+func (c *Controller) handleCreate(w http.ResponseWriter, r *http.Request, caller *auth.Principal) {
+	var input CreateOrganisationInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		c.writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body: "+err.Error())
+		return
+	}
+
+	org, err := c.service.CreateOrganisation(r.Context(), caller.OrganisationID, input)
+	if err != nil {
+		c.writeServiceError(w, err)
+		return
+	}
+	c.writeJSON(w, http.StatusCreated, org)
+}
+
+// handleUpdate handles PATCH /api/organisations/{id}
+// A new parent must be within the caller's hierarchy, and only an ancestor can
+// move an organisation or change its billing.
+func (c *Controller) handleUpdate(w http.ResponseWriter, r *http.Request, caller *auth.Principal, id string) {
+	var input UpdateOrganisationInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		c.writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body: "+err.Error())
+		return
+	}
+
+	org, err := c.service.UpdateOrganisation(r.Context(), caller.OrganisationID, id, input)
+	if err != nil {
+		c.writeServiceError(w, err)
+		return
+	}
+	c.writeJSON(w, http.StatusOK, org)
+}
+
+// handleDeactivate handles POST /api/organisations/{id}/deactivate
+// Only an ancestor can deactivate an organisation.
+func (c *Controller) handleDeactivate(w http.ResponseWriter, r *http.Request, caller *auth.Principal, id string) {
+	var req DeactivateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		c.writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body: "+err.Error())
+		return
+	}
+
+	org, err := c.service.DeactivateOrganisation(r.Context(), caller.OrganisationID, id, req.Message)
+	if err != nil {
+		c.writeServiceError(w, err)
+		return
+	}
+	c.writeJSON(w, http.StatusOK, org)
+}
+
+// handleReactivate handles POST /api/organisations/{id}/reactivate
+// Only an ancestor can reactivate an organisation.
+func (c *Controller) handleReactivate(w http.ResponseWriter, r *http.Request, caller *auth.Principal, id string) {
+	org, err := c.service.ReactivateOrganisation(r.Context(), caller.OrganisationID, id)
+	if err != nil {
+		c.writeServiceError(w, err)
+		return
+	}
+	c.writeJSON(w, http.StatusOK, org)
+}
+
+// ErrorResponse represents an error response
+type ErrorResponse struct {
+	Error struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+		Field   string `json:"field,omitempty"` // Request field that failed validation
+	} `json:"error"`
+}
+
+func (c *Controller) writeServiceError(w http.ResponseWriter, err error) {
+	var domainErr *errors.DomainError
+	switch {
+	case errors.IsDomainError(err, &domainErr) && domainErr.Code == errors.ErrCodeValidation:
+		c.writeFieldError(w, http.StatusBadRequest, "VALIDATION_ERROR", domainErr.Field, domainErr.Message)
+	case errors.IsDomainError(err, &domainErr) && domainErr.Code == errors.ErrCodeForbidden:
+		c.writeError(w, http.StatusForbidden, "FORBIDDEN", domainErr.Message)
+	case errors.IsDomainError(err, &domainErr) && domainErr.Code == errors.ErrCodeNotFound:
+		c.writeError(w, http.StatusNotFound, "NOT_FOUND", "Organisation not found")
+	default:
+		c.writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+	}
+}
+
+func (c *Controller) writeError(w http.ResponseWriter, status int, code, message string) {
+	c.writeFieldError(w, status, code, "", message)
+}
+
+func (c *Controller) writeFieldError(w http.ResponseWriter, status int, code, field, message string) {
+	resp := ErrorResponse{}
+	resp.Error.Code = code
+	resp.Error.Message = message
+	resp.Error.Field = field
+	c.writeJSON(w, status, resp)
+}
+
+func (c *Controller) writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
@@ -5,6 +5,18 @@ import (
 	"api-golang/internal/shared/types"
 )
 
+// Organisation status values
+const (
+	StatusActive   = "active"
+	StatusInactive = "inactive"
+)
+
+// Calculation types an organisation can enable
+const (
+	CalculationTypeCarbon = "carbon"
+	CalculationTypeNature = "nature"
+)
+
 // Entity represents an organisation in the system (matches Ekko API v3 schema)
 // See: https://docs.ekko.earth/v3/reference/get_organisations-organisationid
 type Entity struct {
@@ -28,7 +40,7 @@ type Entity struct {
 
 // IsActive checks if the organisation is active
 func (e *Entity) IsActive() bool {
-	return e.Status.Value == "active"
+	return e.Status.Value == StatusActive
 }
 
 // IsChildOf checks if this organisation is a child of another organisation
@@ -44,6 +56,16 @@ func (e *Entity) GetMCC() string {
 	return ""
 }
 
+// HasCalculationType checks if the organisation has a calculation type enabled
+func (e *Entity) HasCalculationType(calculationType string) bool {
+	for _, t := range e.CalculationTypes {
+		if t == calculationType {
+			return true
+		}
+	}
+	return false
+}
+
 // HasImpactPartner checks if the organisation has access to a specific impact partner
 func (e *Entity) HasImpactPartner(partnerID string) bool {
 	for _, p := range e.ImpactPartners {
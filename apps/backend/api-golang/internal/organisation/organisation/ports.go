@@ -1,16 +1,95 @@
 // Package organisation defines ports (interfaces) for the organisation sub-domain.
 package organisation
 
-import "context"
+import (
+	"context"
+
+	"api-golang/internal/platform/country"
+	"api-golang/internal/platform/mcc"
+	"api-golang/internal/shared/types"
+)
+
+// CreateOrganisationInput represents input for creating an organisation
+type CreateOrganisationInput struct {
+	ParentOrganisationID  *string                           `json:"parentOrganisationId,omitempty"`
+	OrganisationReference *string                           `json:"organisationReference,omitempty"`
+	TradingName           string                            `json:"tradingName"`
+	LegalName             string                            `json:"legalName"`
+	Address               types.Address                     `json:"address"`
+	CurrencyCode          string                            `json:"currencyCode"`
+	Website               *string                           `json:"website,omitempty"`
+	Billing               *types.BillingConfig              `json:"billing,omitempty"`
+	MCC                   *string                           `json:"mcc,omitempty"`
+	RelativeProfitShare   float64                           `json:"relativeProfitShare"`
+	ServiceFeePercentage  float64                           `json:"serviceFeePercentage"`
+	ImpactPartners        []types.OrganisationImpactPartner `json:"impactPartners,omitempty"` // Inherited from the parent when empty
+	CalculationTypes      []string                          `json:"calculationTypes,omitempty"`
+}
+
+// UpdateOrganisationInput represents a partial update; nil fields are left unchanged.
+// Only a parent organisation can change ParentOrganisationID or the billing fields.
+type UpdateOrganisationInput struct {
+	ParentOrganisationID  *string                            `json:"parentOrganisationId,omitempty"`
+	OrganisationReference *string                            `json:"organisationReference,omitempty"`
+	TradingName           *string                            `json:"tradingName,omitempty"`
+	LegalName             *string                            `json:"legalName,omitempty"`
+	Address               *types.Address                     `json:"address,omitempty"`
+	CurrencyCode          *string                            `json:"currencyCode,omitempty"`
+	Website               *string                            `json:"website,omitempty"`
+	Billing               *types.BillingConfig               `json:"billing,omitempty"`
+	MCC                   *string                            `json:"mcc,omitempty"`
+	RelativeProfitShare   *float64                           `json:"relativeProfitShare,omitempty"`
+	ServiceFeePercentage  *float64                           `json:"serviceFeePercentage,omitempty"`
+	ImpactPartners        *[]types.OrganisationImpactPartner `json:"impactPartners,omitempty"`
+	CalculationTypes      *[]string                          `json:"calculationTypes,omitempty"`
+}
+
+// ListFilter narrows an organisation listing
+type ListFilter struct {
+	ParentOrganisationID *string // Direct children of this organisation; empty string for top-level organisations
+	Status               string  // active or inactive
+}
 
 // Repository defines the port for organisation data access (driven adapter)
 type Repository interface {
 	GetByID(ctx context.Context, id string) (*Entity, error)
 	GetChildren(ctx context.Context, parentID string) ([]*Entity, error)
+	// GetAncestors returns the ancestors of an organisation, nearest first, up
+	// to maxDepth levels (0 for no limit). Cycles end the path.
+	GetAncestors(ctx context.Context, id string, maxDepth int) ([]*Entity, error)
+	List(ctx context.Context, filter ListFilter) ([]*Entity, error)
+	Create(ctx context.Context, org *Entity) error
+	Update(ctx context.Context, org *Entity) error
+}
+
+// CountryValidator defines the port for address validation (provided by the country domain)
+type CountryValidator interface {
+	GetCountryByCode(ctx context.Context, code string) (*country.Entity, error)
+	ValidateSubdivision(ctx context.Context, countryCode, subdivision string) (*country.Subdivision, error)
+	NormalisePostalCode(ctx context.Context, countryCode, postalCode string) (string, error)
+}
+
+// CurrencyValidator defines the port for currency validation (provided by the currency domain)
+type CurrencyValidator interface {
+	ValidateCurrency(ctx context.Context, code string) error
+}
+
+// MCCValidator defines the port for merchant category code validation (provided by the MCC registry)
+type MCCValidator interface {
+	ValidateMCC(ctx context.Context, code string) (*mcc.Entity, error)
 }
 
 // Service defines the port for organisation business logic (driving port)
 type Service interface {
-	ValidateOrganisation(ctx context.Context, headerOrgID, bodyOrgID string) (*Entity, error)
+	ValidateOrganisation(ctx context.Context, callerOrgID, orgID string) (*Entity, error)
+	// ListAccessibleOrganisationIDs returns the caller's organisation and the
+	// descendants it may act for
+	ListAccessibleOrganisationIDs(ctx context.Context, callerOrgID string) ([]string, error)
+	ValidateStatus(ctx context.Context, org *Entity) error
 	GetOrganisation(ctx context.Context, id string) (*Entity, error)
+	ListOrganisations(ctx context.Context, filter ListFilter) ([]*Entity, error)
+	CreateOrganisation(ctx context.Context, callerOrgID string, input CreateOrganisationInput) (*Entity, error)
+	UpdateOrganisation(ctx context.Context, callerOrgID, id string, input UpdateOrganisationInput) (*Entity, error)
+	DeactivateOrganisation(ctx context.Context, callerOrgID, id string, message *string) (*Entity, error)
+	ReactivateOrganisation(ctx context.Context, callerOrgID, id string) (*Entity, error)
 }
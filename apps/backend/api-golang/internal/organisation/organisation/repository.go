@@ -2,6 +2,7 @@ package organisation
 
 import (
 	"context"
+	"sort"
 	"sync"
 
 	"api-golang/internal/shared/errors"
@@ -26,6 +27,7 @@ func NewInMemoryRepository() *InMemoryRepository {
 	parentID := "org-parent-1"
 	mcc6011 := "6011" // Banks
 	serviceFee := 0.05
+	parentVATNumber := "GB123456789"
 
 	repo.organisations["org-parent-1"] = &Entity{
 		OrganisationID:       "org-parent-1",
@@ -40,6 +42,12 @@ func NewInMemoryRepository() *InMemoryRepository {
 			PostalCode:  "EC1A 1BB",
 			CountryCode: "GBR",
 		},
+		Billing: &types.BillingConfig{
+			CompanyRegistrationNumber: "01234567",
+			CurrencyCode:              "EUR",
+			Email:                     "billing@acmebank.example",
+			TaxNumber:                 &parentVATNumber,
+		},
 		ServiceFeePercentage: serviceFee,
 		Status: types.OrganisationStatus{
 			Value: "active",
@@ -126,3 +134,82 @@ func (r *InMemoryRepository) GetChildren(_ context.Context, parentID string) ([]
 	}
 	return children, nil
 }
+
+// GetAncestors retrieves the ancestors of an organisation, nearest first, up to maxDepth levels
+func (r *InMemoryRepository) GetAncestors(_ context.Context, id string, maxDepth int) ([]*Entity, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	org, exists := r.organisations[id]
+	if !exists {
+		return nil, errors.NewNotFoundError(domainName, "organisation not found")
+	}
+
+	ancestors := make([]*Entity, 0)
+	visited := map[string]bool{id: true}
+	for org.ParentOrganisationID != nil && (maxDepth <= 0 || len(ancestors) < maxDepth) {
+		parent, exists := r.organisations[*org.ParentOrganisationID]
+		if !exists || visited[parent.OrganisationID] {
+			break
+		}
+		visited[parent.OrganisationID] = true
+		ancestors = append(ancestors, parent)
+		org = parent
+	}
+	return ancestors, nil
+}
+
+// List retrieves organisations matching the filter, sorted by trading name
+func (r *InMemoryRepository) List(_ context.Context, filter ListFilter) ([]*Entity, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	orgs := make([]*Entity, 0, len(r.organisations))
+	for _, org := range r.organisations {
+		if filter.ParentOrganisationID != nil {
+			parentID := ""
+			if org.ParentOrganisationID != nil {
+				parentID = *org.ParentOrganisationID
+			}
+			if parentID != *filter.ParentOrganisationID {
+				continue
+			}
+		}
+		if filter.Status != "" && org.Status.Value != filter.Status {
+			continue
+		}
+		orgs = append(orgs, org)
+	}
+
+	sort.Slice(orgs, func(i, j int) bool {
+		if orgs[i].TradingName != orgs[j].TradingName {
+			return orgs[i].TradingName < orgs[j].TradingName
+		}
+		return orgs[i].OrganisationID < orgs[j].OrganisationID
+	})
+	return orgs, nil
+}
+
+// Create stores a new organisation
+func (r *InMemoryRepository) Create(_ context.Context, org *Entity) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.organisations[org.OrganisationID]; exists {
+		return errors.NewValidationError(domainName, "organisation already exists")
+	}
+	r.organisations[org.OrganisationID] = org
+	return nil
+}
+
+// Update replaces a stored organisation
+func (r *InMemoryRepository) Update(_ context.Context, org *Entity) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.organisations[org.OrganisationID]; !exists {
+		return errors.NewNotFoundError(domainName, "organisation not found")
+	}
+	r.organisations[org.OrganisationID] = org
+	return nil
+}
@@ -3,46 +3,130 @@ package organisation
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"api-golang/internal/shared/errors"
+	"api-golang/internal/shared/types"
 
 	"github.com/bilo-mono/packages/common/service"
+
+	"github.com/google/uuid"
 )
 
+// DefaultMaxHierarchyDepth is how many levels below an organisation it may act
+// for by default (a bank acting for its regions' merchants uses two of them)
+const DefaultMaxHierarchyDepth = 3
+
 // DefaultService implements the Service interface
 type DefaultService struct {
 	service.BaseService[Repository]
+	countries  CountryValidator
+	currencies CurrencyValidator
+	mccs       MCCValidator
+	maxDepth   int
 }
 
 // NewService creates a new organisation service
-func NewService(repo Repository) *DefaultService {
+func NewService(repo Repository, countries CountryValidator, currencies CurrencyValidator, mccs MCCValidator) *DefaultService {
 	return &DefaultService{
 		BaseService: service.NewBaseService(repo),
+		countries:   countries,
+		currencies:  currencies,
+		mccs:        mccs,
+		maxDepth:    DefaultMaxHierarchyDepth,
 	}
 }
 
-// ValidateOrganisation validates organisation access based on header and body org IDs
-// If headerOrgID == bodyOrgID, access is allowed
-// If bodyOrgID is a child of headerOrgID, access is allowed
-// Otherwise, access is forbidden
-func (s *DefaultService) ValidateOrganisation(ctx context.Context, headerOrgID, bodyOrgID string) (*Entity, error) {
-	// If they match, simply return the organisation
-	if headerOrgID == bodyOrgID {
-		return s.GetOrganisation(ctx, bodyOrgID)
+// WithMaxHierarchyDepth sets how many levels below the caller's organisation
+// ValidateOrganisation allows access to
+func (s *DefaultService) WithMaxHierarchyDepth(depth int) *DefaultService {
+	s.maxDepth = depth
+	return s
+}
+
+// ValidateOrganisation checks that the authenticated caller's organisation may
+// act for orgID: either it is orgID itself, or one of orgID's ancestors within
+// the maximum hierarchy depth. Otherwise access is forbidden.
+func (s *DefaultService) ValidateOrganisation(ctx context.Context, callerOrgID, orgID string) (*Entity, error) {
+	// The caller may always act for its own organisation
+	if callerOrgID == orgID {
+		return s.GetOrganisation(ctx, orgID)
 	}
 
-	// Check if body org is a child of header org
-	bodyOrg, err := s.Repo.GetByID(ctx, bodyOrgID)
+	org, err := s.Repo.GetByID(ctx, orgID)
 	if err != nil {
 		return nil, fmt.Errorf("validating organisation: %w", err)
 	}
 
-	if !bodyOrg.IsChildOf(headerOrgID) {
-		return nil, errors.NewForbiddenError(domainName,
-			fmt.Sprintf("organisation %s is not a child of %s", bodyOrgID, headerOrgID))
+	// Check if the caller's organisation is among the organisation's ancestors
+	ancestors, err := s.Repo.GetAncestors(ctx, orgID, s.maxDepth)
+	if err != nil {
+		return nil, fmt.Errorf("getting ancestors of %s: %w", orgID, err)
+	}
+	for _, ancestor := range ancestors {
+		if ancestor.OrganisationID == callerOrgID {
+			return org, nil
+		}
+	}
+
+	return nil, errors.NewForbiddenError(domainName,
+		fmt.Sprintf("organisation %s is not a descendant of %s within %d levels", orgID, callerOrgID, s.maxDepth))
+}
+
+// ListAccessibleOrganisationIDs returns the caller's organisation followed by
+// its descendants within the maximum hierarchy depth, i.e. every organisation
+// ValidateOrganisation allows the caller to act for
+func (s *DefaultService) ListAccessibleOrganisationIDs(ctx context.Context, callerOrgID string) ([]string, error) {
+	if _, err := s.GetOrganisation(ctx, callerOrgID); err != nil {
+		return nil, err
 	}
 
-	return bodyOrg, nil
+	ids := []string{callerOrgID}
+	seen := map[string]bool{callerOrgID: true}
+	level := []string{callerOrgID}
+	for depth := 0; depth < s.maxDepth && len(level) > 0; depth++ {
+		var next []string
+		for _, parentID := range level {
+			children, err := s.Repo.GetChildren(ctx, parentID)
+			if err != nil {
+				return nil, fmt.Errorf("getting children of %s: %w", parentID, err)
+			}
+			for _, child := range children {
+				if seen[child.OrganisationID] {
+					continue
+				}
+				seen[child.OrganisationID] = true
+				ids = append(ids, child.OrganisationID)
+				next = append(next, child.OrganisationID)
+			}
+		}
+		level = next
+	}
+	return ids, nil
+}
+
+// ValidateStatus checks that an organisation and all of its ancestors are
+// active. The error carries the status message of the inactive organisation.
+func (s *DefaultService) ValidateStatus(ctx context.Context, org *Entity) error {
+	ancestors, err := s.Repo.GetAncestors(ctx, org.OrganisationID, 0)
+	if err != nil {
+		return fmt.Errorf("getting ancestors of %s: %w", org.OrganisationID, err)
+	}
+
+	for _, o := range append([]*Entity{org}, ancestors...) {
+		if o.IsActive() {
+			continue
+		}
+		message := fmt.Sprintf("organisation %s is %s", o.OrganisationID, o.Status.Value)
+		if o.OrganisationID != org.OrganisationID {
+			message = fmt.Sprintf("parent organisation %s is %s", o.OrganisationID, o.Status.Value)
+		}
+		if o.Status.Message != nil && *o.Status.Message != "" {
+			message += ": " + *o.Status.Message
+		}
+		return errors.NewOrganisationInactiveError(domainName, message)
+	}
+	return nil
 }
 
 // GetOrganisation retrieves an organisation by ID
@@ -53,3 +137,359 @@ func (s *DefaultService) GetOrganisation(ctx context.Context, id string) (*Entit
 	}
 	return org, nil
 }
+
+// ListOrganisations retrieves organisations matching the filter
+func (s *DefaultService) ListOrganisations(ctx context.Context, filter ListFilter) ([]*Entity, error) {
+	orgs, err := s.Repo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("listing organisations: %w", err)
+	}
+	return orgs, nil
+}
+
+// CreateOrganisation validates and stores a new, active organisation under the
+// caller's organisation, or under another parent the caller may act for.
+// Impact partners are inherited from the parent when none are given.
+func (s *DefaultService) CreateOrganisation(ctx context.Context, callerOrgID string, input CreateOrganisationInput) (*Entity, error) {
+	if input.ParentOrganisationID == nil || *input.ParentOrganisationID == "" {
+		input.ParentOrganisationID = &callerOrgID
+	}
+	if err := s.validateParentAccess(ctx, callerOrgID, *input.ParentOrganisationID); err != nil {
+		return nil, err
+	}
+
+	org := &Entity{
+		OrganisationID:        uuid.New().String(),
+		ParentOrganisationID:  emptyToNil(input.ParentOrganisationID),
+		OrganisationReference: input.OrganisationReference,
+		TradingName:           strings.TrimSpace(input.TradingName),
+		LegalName:             strings.TrimSpace(input.LegalName),
+		Address:               input.Address,
+		CurrencyCode:          input.CurrencyCode,
+		Website:               input.Website,
+		Billing:               input.Billing,
+		MCC:                   emptyToNil(input.MCC),
+		RelativeProfitShare:   input.RelativeProfitShare,
+		ServiceFeePercentage:  input.ServiceFeePercentage,
+		Status:                types.OrganisationStatus{Value: StatusActive},
+		ImpactPartners:        input.ImpactPartners,
+		CalculationTypes:      input.CalculationTypes,
+	}
+	if len(org.CalculationTypes) == 0 {
+		org.CalculationTypes = []string{CalculationTypeCarbon}
+	}
+
+	if org.TradingName == "" {
+		return nil, errors.NewFieldValidationError(domainName, "tradingName", "tradingName is required")
+	}
+	if org.LegalName == "" {
+		return nil, errors.NewFieldValidationError(domainName, "legalName", "legalName is required")
+	}
+	if err := s.validateAddress(ctx, &org.Address); err != nil {
+		return nil, err
+	}
+	if err := s.validateCurrency(ctx, "currencyCode", org.CurrencyCode); err != nil {
+		return nil, err
+	}
+	if err := s.validateDetails(ctx, org); err != nil {
+		return nil, err
+	}
+
+	parent, err := s.validateParent(ctx, org)
+	if err != nil {
+		return nil, err
+	}
+	if parent != nil && len(org.ImpactPartners) == 0 {
+		org.ImpactPartners = append([]types.OrganisationImpactPartner(nil), parent.ImpactPartners...)
+	}
+
+	if err := s.Repo.Create(ctx, org); err != nil {
+		return nil, fmt.Errorf("creating organisation: %w", err)
+	}
+	return org, nil
+}
+
+// UpdateOrganisation applies a partial update. Only the fields being changed
+// are validated, and a parent change is checked for cycles. An organisation
+// can edit its own details, but only an ancestor can change what it is billed
+// or move it.
+func (s *DefaultService) UpdateOrganisation(ctx context.Context, callerOrgID, id string, input UpdateOrganisationInput) (*Entity, error) {
+	existing, err := s.ValidateOrganisation(ctx, callerOrgID, id)
+	if err != nil {
+		return nil, err
+	}
+	if callerOrgID == id && (input.ParentOrganisationID != nil || changesBilling(input)) {
+		return nil, errors.NewForbiddenError(domainName,
+			"only a parent organisation can move an organisation or change its billing, impact partners or calculation types")
+	}
+	if input.ParentOrganisationID != nil {
+		if *input.ParentOrganisationID == "" {
+			return nil, errors.NewForbiddenError(domainName, "cannot move an organisation to the top level")
+		}
+		if err := s.validateParentAccess(ctx, callerOrgID, *input.ParentOrganisationID); err != nil {
+			return nil, err
+		}
+	}
+	org := *existing
+
+	if input.TradingName != nil {
+		if org.TradingName = strings.TrimSpace(*input.TradingName); org.TradingName == "" {
+			return nil, errors.NewFieldValidationError(domainName, "tradingName", "tradingName cannot be empty")
+		}
+	}
+	if input.LegalName != nil {
+		if org.LegalName = strings.TrimSpace(*input.LegalName); org.LegalName == "" {
+			return nil, errors.NewFieldValidationError(domainName, "legalName", "legalName cannot be empty")
+		}
+	}
+	if input.Address != nil {
+		org.Address = *input.Address
+		if err := s.validateAddress(ctx, &org.Address); err != nil {
+			return nil, err
+		}
+	}
+	if input.CurrencyCode != nil {
+		org.CurrencyCode = *input.CurrencyCode
+		if err := s.validateCurrency(ctx, "currencyCode", org.CurrencyCode); err != nil {
+			return nil, err
+		}
+	}
+	if input.OrganisationReference != nil {
+		org.OrganisationReference = emptyToNil(input.OrganisationReference)
+	}
+	if input.Website != nil {
+		org.Website = emptyToNil(input.Website)
+	}
+	if input.Billing != nil {
+		org.Billing = input.Billing
+	}
+	if input.MCC != nil {
+		org.MCC = emptyToNil(input.MCC)
+	}
+	if input.RelativeProfitShare != nil {
+		org.RelativeProfitShare = *input.RelativeProfitShare
+	}
+	if input.ServiceFeePercentage != nil {
+		org.ServiceFeePercentage = *input.ServiceFeePercentage
+	}
+	if input.ImpactPartners != nil {
+		org.ImpactPartners = *input.ImpactPartners
+	}
+	if input.CalculationTypes != nil {
+		org.CalculationTypes = *input.CalculationTypes
+	}
+	if input.Billing != nil || input.MCC != nil || input.RelativeProfitShare != nil ||
+		input.ServiceFeePercentage != nil || input.CalculationTypes != nil {
+		if err := s.validateDetails(ctx, &org); err != nil {
+			return nil, err
+		}
+	}
+	if input.ParentOrganisationID != nil {
+		org.ParentOrganisationID = emptyToNil(input.ParentOrganisationID)
+		if _, err := s.validateParent(ctx, &org); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.Repo.Update(ctx, &org); err != nil {
+		return nil, fmt.Errorf("updating organisation: %w", err)
+	}
+	return &org, nil
+}
+
+// DeactivateOrganisation marks a descendant of the caller's organisation
+// inactive, with an optional message explaining why. Child organisations are
+// left unchanged. An organisation cannot deactivate itself: its keys would stop
+// working and only an ancestor could reactivate it.
+func (s *DefaultService) DeactivateOrganisation(ctx context.Context, callerOrgID, id string, message *string) (*Entity, error) {
+	return s.setStatus(ctx, callerOrgID, id, types.OrganisationStatus{Value: StatusInactive, Message: emptyToNil(message)})
+}
+
+// ReactivateOrganisation marks a descendant of the caller's organisation
+// active again and clears its status message
+func (s *DefaultService) ReactivateOrganisation(ctx context.Context, callerOrgID, id string) (*Entity, error) {
+	return s.setStatus(ctx, callerOrgID, id, types.OrganisationStatus{Value: StatusActive})
+}
+
+// setStatus changes the status of a descendant of the caller's organisation
+func (s *DefaultService) setStatus(ctx context.Context, callerOrgID, id string, status types.OrganisationStatus) (*Entity, error) {
+	if callerOrgID == id {
+		return nil, errors.NewForbiddenError(domainName, "an organisation cannot change its own status")
+	}
+	existing, err := s.ValidateOrganisation(ctx, callerOrgID, id)
+	if err != nil {
+		return nil, err
+	}
+	org := *existing
+	org.Status = status
+
+	if err := s.Repo.Update(ctx, &org); err != nil {
+		return nil, fmt.Errorf("updating organisation status: %w", err)
+	}
+	return &org, nil
+}
+
+// changesBilling reports whether an update touches the fields that decide what
+// an organisation is billed
+func changesBilling(input UpdateOrganisationInput) bool {
+	return input.Billing != nil || input.RelativeProfitShare != nil || input.ServiceFeePercentage != nil ||
+		input.ImpactPartners != nil || input.CalculationTypes != nil
+}
+
+// validateAddress checks the required address fields, the state and the postal
+// code, and stores the country as ISO-3, the state as its local code and the
+// postal code in canonical form
+func (s *DefaultService) validateAddress(ctx context.Context, address *types.Address) error {
+	if strings.TrimSpace(address.Line1) == "" {
+		return errors.NewFieldValidationError(domainName, "address.line1", "address.line1 is required")
+	}
+	if strings.TrimSpace(address.City) == "" {
+		return errors.NewFieldValidationError(domainName, "address.city", "address.city is required")
+	}
+	if address.CountryCode == "" {
+		return errors.NewFieldValidationError(domainName, "address.countryCode", "address.countryCode is required")
+	}
+
+	c, err := s.countries.GetCountryByCode(ctx, strings.ToUpper(address.CountryCode))
+	if err != nil {
+		var domainErr *errors.DomainError
+		if errors.IsDomainError(err, &domainErr) && domainErr.Code == errors.ErrCodeNotFound {
+			return errors.NewFieldValidationError(domainName, "address.countryCode",
+				fmt.Sprintf("unknown country code: %s", address.CountryCode))
+		}
+		return fmt.Errorf("getting country %s: %w", address.CountryCode, err)
+	}
+	address.CountryCode = c.ISO3Code
+
+	if address.State != nil && *address.State != "" {
+		sub, err := s.countries.ValidateSubdivision(ctx, address.CountryCode, *address.State)
+		if err != nil {
+			return fieldError("address.state", err)
+		}
+		if sub != nil {
+			// Store the local code the tax rates are keyed by, e.g. "CA" for "California"
+			state := sub.LocalCode()
+			address.State = &state
+		}
+	}
+	if address.PostalCode != "" {
+		postalCode, err := s.countries.NormalisePostalCode(ctx, address.CountryCode, address.PostalCode)
+		if err != nil {
+			return fieldError("address.postalCode", err)
+		}
+		address.PostalCode = postalCode
+	}
+	return nil
+}
+
+// validateCurrency checks a currency code field
+func (s *DefaultService) validateCurrency(ctx context.Context, field, code string) error {
+	if code == "" {
+		return errors.NewFieldValidationError(domainName, field, field+" is required")
+	}
+	if err := s.currencies.ValidateCurrency(ctx, code); err != nil {
+		return fieldError(field, err)
+	}
+	return nil
+}
+
+// validateDetails checks the billing currency, MCC, percentages and calculation types
+func (s *DefaultService) validateDetails(ctx context.Context, org *Entity) error {
+	if org.Billing != nil {
+		if err := s.validateCurrency(ctx, "billing.currencyCode", org.Billing.CurrencyCode); err != nil {
+			return err
+		}
+	}
+	if org.MCC != nil {
+		if _, err := s.mccs.ValidateMCC(ctx, *org.MCC); err != nil {
+			return fieldError("mcc", err)
+		}
+	}
+	if org.RelativeProfitShare < 0 || org.RelativeProfitShare > 1 {
+		return errors.NewFieldValidationError(domainName, "relativeProfitShare", "relativeProfitShare must be between 0 and 1")
+	}
+	if org.ServiceFeePercentage < 0 || org.ServiceFeePercentage > 1 {
+		return errors.NewFieldValidationError(domainName, "serviceFeePercentage", "serviceFeePercentage must be between 0 and 1")
+	}
+	for _, t := range org.CalculationTypes {
+		if t != CalculationTypeCarbon && t != CalculationTypeNature {
+			return errors.NewFieldValidationError(domainName, "calculationTypes",
+				fmt.Sprintf("unknown calculation type %q: must be %s or %s", t, CalculationTypeCarbon, CalculationTypeNature))
+		}
+	}
+	// Every quote is priced from its carbon footprint, so carbon cannot be left out
+	if !org.HasCalculationType(CalculationTypeCarbon) {
+		return errors.NewFieldValidationError(domainName, "calculationTypes",
+			fmt.Sprintf("calculationTypes must include %s", CalculationTypeCarbon))
+	}
+	return nil
+}
+
+// validateParentAccess checks that the caller may act for a new parent
+// organisation, reporting an unknown parent as a validation error
+func (s *DefaultService) validateParentAccess(ctx context.Context, callerOrgID, parentID string) error {
+	if _, err := s.ValidateOrganisation(ctx, callerOrgID, parentID); err != nil {
+		var domainErr *errors.DomainError
+		if errors.IsDomainError(err, &domainErr) && domainErr.Code == errors.ErrCodeNotFound {
+			return errors.NewFieldValidationError(domainName, "parentOrganisationId",
+				fmt.Sprintf("parent organisation %s not found", parentID))
+		}
+		return err
+	}
+	return nil
+}
+
+// validateParent checks that the parent exists and is active, and that the
+// organisation is not among its own ancestors. It returns the parent, or nil
+// for a top-level organisation.
+func (s *DefaultService) validateParent(ctx context.Context, org *Entity) (*Entity, error) {
+	if org.ParentOrganisationID == nil {
+		return nil, nil
+	}
+
+	parentID := *org.ParentOrganisationID
+	parent, err := s.Repo.GetByID(ctx, parentID)
+	if err != nil {
+		var domainErr *errors.DomainError
+		if errors.IsDomainError(err, &domainErr) && domainErr.Code == errors.ErrCodeNotFound {
+			return nil, errors.NewFieldValidationError(domainName, "parentOrganisationId",
+				fmt.Sprintf("parent organisation %s not found", parentID))
+		}
+		return nil, fmt.Errorf("getting parent organisation: %w", err)
+	}
+	if !parent.IsActive() {
+		return nil, errors.NewFieldValidationError(domainName, "parentOrganisationId",
+			fmt.Sprintf("parent organisation %s is inactive", parentID))
+	}
+
+	// The organisation must not be the parent or one of the parent's ancestors
+	ancestors, err := s.Repo.GetAncestors(ctx, parentID, 0)
+	if err != nil {
+		return nil, fmt.Errorf("getting ancestors of %s: %w", parentID, err)
+	}
+	for _, ancestor := range append([]*Entity{parent}, ancestors...) {
+		if ancestor.OrganisationID == org.OrganisationID {
+			return nil, errors.NewFieldValidationError(domainName, "parentOrganisationId",
+				fmt.Sprintf("organisation %s cannot be a descendant of itself", org.OrganisationID))
+		}
+	}
+
+	return parent, nil
+}
+
+// fieldError attaches the request field to a validation error from another domain
+func fieldError(field string, err error) error {
+	var domainErr *errors.DomainError
+	if errors.IsDomainError(err, &domainErr) && domainErr.Code == errors.ErrCodeValidation {
+		return errors.NewFieldValidationError(domainName, field, domainErr.Message)
+	}
+	return fmt.Errorf("%s: %w", field, err)
+}
+
+// emptyToNil treats an empty string as unset
+func emptyToNil(s *string) *string {
+	if s == nil || *s == "" {
+		return nil
+	}
+	return s
+}
@@ -0,0 +1,398 @@
+package organisation
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"api-golang/internal/finance/currency"
+	"api-golang/internal/platform/country"
+	"api-golang/internal/platform/mcc"
+	"api-golang/internal/shared/errors"
+	"api-golang/internal/shared/types"
+)
+
+func setupService() *DefaultService {
+	return NewService(
+		NewInMemoryRepository(),
+		country.NewService(country.NewInMemoryRepository()),
+		currency.NewService(currency.NewInMemoryRepository()),
+		mcc.NewService(mcc.NewInMemoryRepository()),
+	)
+}
+
+func validInput() CreateOrganisationInput {
+	parentID := "org-parent-1"
+	mcc5411 := "5411"
+	return CreateOrganisationInput{
+		ParentOrganisationID: &parentID,
+		TradingName:          "Acme Grocers",
+		LegalName:            "Acme Grocers Ltd",
+		Address: types.Address{
+			Line1:       "1 Market Street",
+			City:        "Manchester",
+			PostalCode:  "m11ad",
+			CountryCode: "GB",
+		},
+		CurrencyCode:         "GBP",
+		MCC:                  &mcc5411,
+		ServiceFeePercentage: 0.05,
+	}
+}
+
+func TestCreateOrganisation(t *testing.T) {
+	service := setupService()
+	ctx := context.Background()
+
+	org, err := service.CreateOrganisation(ctx, "org-parent-1", validInput())
+	if err != nil {
+		t.Fatalf("CreateOrganisation failed: %v", err)
+	}
+
+	if org.OrganisationID == "" || !org.IsActive() || !org.IsChildOf("org-parent-1") {
+		t.Errorf("Expected an active child of org-parent-1, got %+v", org)
+	}
+	if org.Address.CountryCode != "GBR" || org.Address.PostalCode != "M1 1AD" {
+		t.Errorf("Expected normalised address, got %s %s", org.Address.CountryCode, org.Address.PostalCode)
+	}
+	if len(org.ImpactPartners) != 2 {
+		t.Errorf("Expected impact partners inherited from the parent, got %v", org.ImpactPartners)
+	}
+	if !org.HasCalculationType(CalculationTypeCarbon) {
+		t.Errorf("Expected carbon calculation type by default, got %v", org.CalculationTypes)
+	}
+
+	children, err := service.ListOrganisations(ctx, ListFilter{ParentOrganisationID: stringPtr("org-parent-1")})
+	if err != nil {
+		t.Fatalf("ListOrganisations failed: %v", err)
+	}
+	if len(children) != 3 {
+		t.Errorf("Expected 3 children of org-parent-1, got %d", len(children))
+	}
+}
+
+func TestCreateOrganisation_NormalisesState(t *testing.T) {
+	service := setupService()
+	ctx := context.Background()
+
+	for _, state := range []string{"California", "US-CA", "ca"} {
+		t.Run(state, func(t *testing.T) {
+			input := validInput()
+			input.Address = types.Address{
+				Line1:       "1 Market Street",
+				City:        "Los Angeles",
+				State:       stringPtr(state),
+				PostalCode:  "90001",
+				CountryCode: "US",
+			}
+
+			org, err := service.CreateOrganisation(ctx, "org-parent-1", input)
+			if err != nil {
+				t.Fatalf("CreateOrganisation failed: %v", err)
+			}
+			if org.Address.State == nil || *org.Address.State != "CA" {
+				t.Errorf("Expected state CA, got %v", org.Address.State)
+			}
+		})
+	}
+}
+
+func TestCreateOrganisation_Validation(t *testing.T) {
+	service := setupService()
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		modify    func(*CreateOrganisationInput)
+		wantField string
+	}{
+		{name: "Missing trading name", modify: func(in *CreateOrganisationInput) { in.TradingName = " " }, wantField: "tradingName"},
+		{name: "Unknown country", modify: func(in *CreateOrganisationInput) { in.Address.CountryCode = "XX" }, wantField: "address.countryCode"},
+		{name: "Invalid postal code", modify: func(in *CreateOrganisationInput) { in.Address.PostalCode = "12345" }, wantField: "address.postalCode"},
+		{name: "Unsupported currency", modify: func(in *CreateOrganisationInput) { in.CurrencyCode = "XYZ" }, wantField: "currencyCode"},
+		{name: "Unknown MCC", modify: func(in *CreateOrganisationInput) { in.MCC = stringPtr("5410") }, wantField: "mcc"},
+		{name: "Unknown parent", modify: func(in *CreateOrganisationInput) { in.ParentOrganisationID = stringPtr("org-missing") }, wantField: "parentOrganisationId"},
+		{name: "Service fee out of range", modify: func(in *CreateOrganisationInput) { in.ServiceFeePercentage = 5 }, wantField: "serviceFeePercentage"},
+		{name: "Unknown calculation type", modify: func(in *CreateOrganisationInput) { in.CalculationTypes = []string{"water"} }, wantField: "calculationTypes"},
+		{name: "Calculation types without carbon", modify: func(in *CreateOrganisationInput) { in.CalculationTypes = []string{"nature"} }, wantField: "calculationTypes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := validInput()
+			tt.modify(&input)
+
+			_, err := service.CreateOrganisation(ctx, "org-parent-1", input)
+			var domainErr *errors.DomainError
+			if !errors.IsDomainError(err, &domainErr) || domainErr.Code != errors.ErrCodeValidation {
+				t.Fatalf("Expected validation error, got %v", err)
+			}
+			if domainErr.Field != tt.wantField {
+				t.Errorf("Expected field %s, got %q", tt.wantField, domainErr.Field)
+			}
+		})
+	}
+}
+
+func TestUpdateOrganisation_ParentCycle(t *testing.T) {
+	service := setupService()
+	ctx := context.Background()
+
+	// org-parent-1 -> org-child-1 -> grandchild
+	input := validInput()
+	input.ParentOrganisationID = stringPtr("org-child-1")
+	grandchild, err := service.CreateOrganisation(ctx, "org-parent-1", input)
+	if err != nil {
+		t.Fatalf("CreateOrganisation failed: %v", err)
+	}
+
+	cycles := []struct {
+		id       string
+		parentID string
+	}{
+		{id: "org-child-1", parentID: grandchild.OrganisationID}, // Organisation under its own child
+		{id: "org-child-1", parentID: "org-child-1"},             // Organisation under itself
+	}
+	for _, tt := range cycles {
+		_, err := service.UpdateOrganisation(ctx, "org-parent-1", tt.id, UpdateOrganisationInput{ParentOrganisationID: stringPtr(tt.parentID)})
+		var domainErr *errors.DomainError
+		if !errors.IsDomainError(err, &domainErr) || domainErr.Field != "parentOrganisationId" {
+			t.Errorf("Expected cycle to be rejected moving %s under %s, got %v", tt.id, tt.parentID, err)
+		}
+	}
+
+	// Moving the grandchild under its sibling's parent is fine, to the top level is not
+	moved, err := service.UpdateOrganisation(ctx, "org-parent-1", grandchild.OrganisationID, UpdateOrganisationInput{ParentOrganisationID: stringPtr("org-child-2")})
+	if err != nil {
+		t.Fatalf("UpdateOrganisation failed: %v", err)
+	}
+	if moved.ParentOrganisationID == nil || *moved.ParentOrganisationID != "org-child-2" {
+		t.Errorf("Expected parent org-child-2, got %v", moved.ParentOrganisationID)
+	}
+	_, err = service.UpdateOrganisation(ctx, "org-parent-1", grandchild.OrganisationID, UpdateOrganisationInput{ParentOrganisationID: stringPtr("")})
+	assertErrorCode(t, err, errors.ErrCodeForbidden)
+}
+
+func TestUpdateOrganisation_OwnOrganisation(t *testing.T) {
+	service := setupService()
+	ctx := context.Background()
+
+	// An organisation can edit its own details...
+	updated, err := service.UpdateOrganisation(ctx, "org-child-1", "org-child-1", UpdateOrganisationInput{TradingName: stringPtr("Child Trading")})
+	if err != nil {
+		t.Fatalf("UpdateOrganisation failed: %v", err)
+	}
+	if updated.TradingName != "Child Trading" {
+		t.Errorf("Expected trading name Child Trading, got %s", updated.TradingName)
+	}
+
+	// ...but not re-bill or move itself
+	fee := 0.01
+	for name, input := range map[string]UpdateOrganisationInput{
+		"service fee":       {ServiceFeePercentage: &fee},
+		"impact partners":   {ImpactPartners: &[]types.OrganisationImpactPartner{}},
+		"calculation types": {CalculationTypes: &[]string{"carbon"}},
+		"parent":            {ParentOrganisationID: stringPtr("org-child-2")},
+	} {
+		_, err := service.UpdateOrganisation(ctx, "org-child-1", "org-child-1", input)
+		var domainErr *errors.DomainError
+		if !errors.IsDomainError(err, &domainErr) || domainErr.Code != errors.ErrCodeForbidden {
+			t.Errorf("Expected %s change to be forbidden, got %v", name, err)
+		}
+	}
+
+	// Its parent can
+	updated, err = service.UpdateOrganisation(ctx, "org-parent-1", "org-child-1", UpdateOrganisationInput{ServiceFeePercentage: &fee})
+	if err != nil {
+		t.Fatalf("UpdateOrganisation failed: %v", err)
+	}
+	if updated.ServiceFeePercentage != fee {
+		t.Errorf("Expected service fee %v, got %v", fee, updated.ServiceFeePercentage)
+	}
+}
+
+func TestUpdateOrganisation_CalculationTypes(t *testing.T) {
+	service := setupService()
+	ctx := context.Background()
+
+	for _, calculationTypes := range [][]string{{}, {"nature"}} {
+		_, err := service.UpdateOrganisation(ctx, "org-parent-1", "org-child-1", UpdateOrganisationInput{CalculationTypes: &calculationTypes})
+		var domainErr *errors.DomainError
+		if !errors.IsDomainError(err, &domainErr) || domainErr.Field != "calculationTypes" {
+			t.Errorf("Expected %v to be rejected, got %v", calculationTypes, err)
+		}
+	}
+
+	updated, err := service.UpdateOrganisation(ctx, "org-parent-1", "org-child-1", UpdateOrganisationInput{CalculationTypes: &[]string{"carbon", "nature"}})
+	if err != nil {
+		t.Fatalf("UpdateOrganisation failed: %v", err)
+	}
+	if !updated.HasCalculationType(CalculationTypeNature) {
+		t.Errorf("Expected nature calculation type, got %v", updated.CalculationTypes)
+	}
+}
+
+func TestDeactivateOrganisation(t *testing.T) {
+	service := setupService()
+	ctx := context.Background()
+
+	org, err := service.DeactivateOrganisation(ctx, "org-parent-1", "org-child-2", stringPtr("Contract ended"))
+	if err != nil {
+		t.Fatalf("DeactivateOrganisation failed: %v", err)
+	}
+	if org.IsActive() || org.Status.Message == nil || *org.Status.Message != "Contract ended" {
+		t.Errorf("Expected inactive status with message, got %+v", org.Status)
+	}
+
+	// Inactive organisations cannot take new children
+	input := validInput()
+	input.ParentOrganisationID = stringPtr("org-child-2")
+	if _, err := service.CreateOrganisation(ctx, "org-parent-1", input); err == nil {
+		t.Error("Expected error creating a child of an inactive organisation")
+	}
+
+	inactive, err := service.ListOrganisations(ctx, ListFilter{Status: StatusInactive})
+	if err != nil {
+		t.Fatalf("ListOrganisations failed: %v", err)
+	}
+	if len(inactive) != 1 || inactive[0].OrganisationID != "org-child-2" {
+		t.Errorf("Expected only org-child-2 to be inactive, got %d organisations", len(inactive))
+	}
+
+	org, err = service.ReactivateOrganisation(ctx, "org-parent-1", "org-child-2")
+	if err != nil {
+		t.Fatalf("ReactivateOrganisation failed: %v", err)
+	}
+	if !org.IsActive() || org.Status.Message != nil {
+		t.Errorf("Expected active status without message, got %+v", org.Status)
+	}
+}
+
+func TestDeactivateOrganisation_Forbidden(t *testing.T) {
+	service := setupService()
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		callerID string
+		id       string
+	}{
+		{name: "Own organisation", callerID: "org-child-1", id: "org-child-1"},
+		{name: "Top-level organisation", callerID: "org-parent-1", id: "org-parent-1"},
+		{name: "Sibling", callerID: "org-child-1", id: "org-child-2"},
+		{name: "Parent", callerID: "org-child-1", id: "org-parent-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.DeactivateOrganisation(ctx, tt.callerID, tt.id, nil)
+			assertErrorCode(t, err, errors.ErrCodeForbidden)
+			_, err = service.ReactivateOrganisation(ctx, tt.callerID, tt.id)
+			assertErrorCode(t, err, errors.ErrCodeForbidden)
+		})
+	}
+}
+
+func TestValidateOrganisation_AncestorChain(t *testing.T) {
+	service := setupService()
+	ctx := context.Background()
+
+	// org-parent-1 (bank) -> org-child-1 (region) -> merchant -> store
+	input := validInput()
+	input.ParentOrganisationID = stringPtr("org-child-1")
+	merchant, err := service.CreateOrganisation(ctx, "org-parent-1", input)
+	if err != nil {
+		t.Fatalf("CreateOrganisation failed: %v", err)
+	}
+	input.ParentOrganisationID = &merchant.OrganisationID
+	store, err := service.CreateOrganisation(ctx, "org-parent-1", input)
+	if err != nil {
+		t.Fatalf("CreateOrganisation failed: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		maxDepth int
+		header   string
+		body     string
+		wantErr  bool
+	}{
+		{name: "Same organisation", maxDepth: 1, header: store.OrganisationID, body: store.OrganisationID},
+		{name: "Direct child", maxDepth: 1, header: "org-parent-1", body: "org-child-1"},
+		{name: "Grandchild", maxDepth: 2, header: "org-parent-1", body: merchant.OrganisationID},
+		{name: "Great-grandchild", maxDepth: 3, header: "org-parent-1", body: store.OrganisationID},
+		{name: "Beyond maximum depth", maxDepth: 2, header: "org-parent-1", body: store.OrganisationID, wantErr: true},
+		{name: "Sibling branch", maxDepth: 3, header: "org-child-2", body: merchant.OrganisationID, wantErr: true},
+		{name: "Ancestor of caller", maxDepth: 3, header: merchant.OrganisationID, body: "org-parent-1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service.WithMaxHierarchyDepth(tt.maxDepth)
+			org, err := service.ValidateOrganisation(ctx, tt.header, tt.body)
+			if tt.wantErr {
+				var domainErr *errors.DomainError
+				if !errors.IsDomainError(err, &domainErr) || domainErr.Code != errors.ErrCodeForbidden {
+					t.Errorf("Expected forbidden error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ValidateOrganisation failed: %v", err)
+			}
+			if org.OrganisationID != tt.body {
+				t.Errorf("Expected organisation %s, got %s", tt.body, org.OrganisationID)
+			}
+		})
+	}
+}
+
+func TestListAccessibleOrganisationIDs(t *testing.T) {
+	service := setupService()
+	ctx := context.Background()
+
+	// org-parent-1 (bank) -> org-child-1 (region) -> merchant
+	input := validInput()
+	input.ParentOrganisationID = stringPtr("org-child-1")
+	merchant, err := service.CreateOrganisation(ctx, "org-parent-1", input)
+	if err != nil {
+		t.Fatalf("CreateOrganisation failed: %v", err)
+	}
+
+	ids, err := service.ListAccessibleOrganisationIDs(ctx, "org-parent-1")
+	if err != nil {
+		t.Fatalf("ListAccessibleOrganisationIDs failed: %v", err)
+	}
+	if len(ids) != 4 || ids[0] != "org-parent-1" || !slices.Contains(ids, merchant.OrganisationID) {
+		t.Errorf("Expected the parent, both children and the merchant, got %v", ids)
+	}
+
+	service.WithMaxHierarchyDepth(1)
+	ids, err = service.ListAccessibleOrganisationIDs(ctx, "org-parent-1")
+	if err != nil {
+		t.Fatalf("ListAccessibleOrganisationIDs failed: %v", err)
+	}
+	if len(ids) != 3 || slices.Contains(ids, merchant.OrganisationID) {
+		t.Errorf("Expected the merchant to be beyond the maximum depth, got %v", ids)
+	}
+
+	ids, err = service.ListAccessibleOrganisationIDs(ctx, "org-child-2")
+	if err != nil {
+		t.Fatalf("ListAccessibleOrganisationIDs failed: %v", err)
+	}
+	if len(ids) != 1 || ids[0] != "org-child-2" {
+		t.Errorf("Expected only org-child-2, got %v", ids)
+	}
+}
+
+func assertErrorCode(t *testing.T, err error, code string) {
+	t.Helper()
+	var domainErr *errors.DomainError
+	if !errors.IsDomainError(err, &domainErr) || domainErr.Code != code {
+		t.Fatalf("Expected %s error, got %v", code, err)
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,86 @@
+package session
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"api-golang/internal/shared/auth"
+	"api-golang/internal/shared/errors"
+)
+
+// Controller handles HTTP requests for browser SDK sessions
+type Controller struct {
+	service Service
+}
+
+// NewController creates a new session controller
+func NewController(service Service) *Controller {
+	return &Controller{service: service}
+}
+
+// HandleCreateSession handles POST /api/sessions
+func (c *Controller) HandleCreateSession(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		c.writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed")
+		return
+	}
+
+	caller, ok := auth.PrincipalFromContext(r.Context())
+	if !ok {
+		c.writeError(w, http.StatusUnauthorized, errors.ErrCodeUnauthorized, "API key is required")
+		return
+	}
+
+	var input CreateSessionInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		c.writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body: "+err.Error())
+		return
+	}
+
+	session, err := c.service.CreateSession(r.Context(), caller, input)
+	if err != nil {
+		var domainErr *errors.DomainError
+		switch {
+		case errors.IsDomainError(err, &domainErr) && domainErr.Code == errors.ErrCodeValidation:
+			c.writeFieldError(w, http.StatusBadRequest, "VALIDATION_ERROR", domainErr.Field, domainErr.Message)
+		case errors.IsDomainError(err, &domainErr) && domainErr.Code == errors.ErrCodeForbidden:
+			c.writeError(w, http.StatusForbidden, "FORBIDDEN", domainErr.Message)
+		case errors.IsDomainError(err, &domainErr) && domainErr.Code == errors.ErrCodeOrganisationInactive:
+			c.writeError(w, http.StatusForbidden, errors.ErrCodeOrganisationInactive, domainErr.Message)
+		case errors.IsDomainError(err, &domainErr) && domainErr.Code == errors.ErrCodeNotFound:
+			c.writeError(w, http.StatusNotFound, "NOT_FOUND", "Organisation not found")
+		default:
+			c.writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		}
+		return
+	}
+
+	c.writeJSON(w, http.StatusCreated, session)
+}
+
+// ErrorResponse represents an error response
+type ErrorResponse struct {
+	Error struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+		Field   string `json:"field,omitempty"` // Request field that failed validation
+	} `json:"error"`
+}
+
+func (c *Controller) writeError(w http.ResponseWriter, status int, code, message string) {
+	c.writeFieldError(w, status, code, "", message)
+}
+
+func (c *Controller) writeFieldError(w http.ResponseWriter, status int, code, field, message string) {
+	resp := ErrorResponse{}
+	resp.Error.Code = code
+	resp.Error.Message = message
+	resp.Error.Field = field
+	c.writeJSON(w, status, resp)
+}
+
+func (c *Controller) writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
@@ -0,0 +1,26 @@
+// Package session issues short-lived session tokens for browser SDKs.
+package session
+
+import (
+	"time"
+
+	"api-golang/internal/shared/auth"
+)
+
+// DefaultTTL is how long a session token is valid
+const DefaultTTL = 15 * time.Minute
+
+// Scopes lists the scopes every session token is restricted to
+var Scopes = []string{auth.ScopeQuotesCreate, auth.ScopeQuotesAccept}
+
+// Entity is an issued session. Sessions are not stored: the token carries
+// the signed claims and is verified on each request.
+type Entity struct {
+	ID                string    `json:"id"`
+	Token             string    `json:"token"`
+	OrganisationID    string    `json:"organisationId"`
+	CustomerReference string    `json:"customerReference"`
+	Product           string    `json:"product"`
+	Scopes            []string  `json:"scopes"`
+	ExpiresAt         time.Time `json:"expiresAt"`
+}
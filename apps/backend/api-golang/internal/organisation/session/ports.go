@@ -0,0 +1,32 @@
+package session
+
+import (
+	"context"
+
+	"api-golang/internal/organisation/organisation"
+	"api-golang/internal/shared/auth"
+)
+
+// CreateSessionInput is the request to create a session for a browser SDK
+type CreateSessionInput struct {
+	OrganisationID    string `json:"organisationId"` // Defaults to the caller's organisation
+	CustomerReference string `json:"customerReference"`
+	Product           string `json:"product"` // e.g. checkout-sdk
+}
+
+// OrganisationAccess defines the port for checking that a caller may act for
+// an active organisation (provided by the organisation domain)
+type OrganisationAccess interface {
+	ValidateOrganisation(ctx context.Context, callerOrgID, orgID string) (*organisation.Entity, error)
+	ValidateStatus(ctx context.Context, org *organisation.Entity) error
+}
+
+// TokenSigner defines the port for signing session tokens
+type TokenSigner interface {
+	Sign(claims auth.SessionClaims) (string, error)
+}
+
+// Service defines the port for session business logic (driving port)
+type Service interface {
+	CreateSession(ctx context.Context, caller *auth.Principal, input CreateSessionInput) (*Entity, error)
+}
@@ -0,0 +1,92 @@
+package session
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"api-golang/internal/shared/auth"
+	"api-golang/internal/shared/errors"
+
+	"github.com/google/uuid"
+)
+
+const domainName = "session"
+
+// DefaultService implements the Service interface
+type DefaultService struct {
+	organisations OrganisationAccess
+	signer        TokenSigner
+	ttl           time.Duration
+	now           func() time.Time
+}
+
+// NewService creates a new session service
+func NewService(organisations OrganisationAccess, signer TokenSigner) *DefaultService {
+	return &DefaultService{
+		organisations: organisations,
+		signer:        signer,
+		ttl:           DefaultTTL,
+		now:           time.Now,
+	}
+}
+
+// CreateSession exchanges an API key for a session token restricted to one
+// active organisation, one customer and the quote create and accept scopes.
+// Session tokens cannot be used to create further sessions.
+func (s *DefaultService) CreateSession(ctx context.Context, caller *auth.Principal, input CreateSessionInput) (*Entity, error) {
+	if caller.IsSession() {
+		return nil, errors.NewForbiddenError(domainName, "sessions can only be created with an API key")
+	}
+	for _, scope := range Scopes {
+		if !caller.HasScope(scope) {
+			return nil, errors.NewForbiddenError(domainName, "API key is missing the "+scope+" scope")
+		}
+	}
+
+	if input.OrganisationID == "" {
+		input.OrganisationID = caller.OrganisationID
+	}
+	org, err := s.organisations.ValidateOrganisation(ctx, caller.OrganisationID, input.OrganisationID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.organisations.ValidateStatus(ctx, org); err != nil {
+		return nil, err
+	}
+
+	customerReference := strings.TrimSpace(input.CustomerReference)
+	if customerReference == "" {
+		return nil, errors.NewFieldValidationError(domainName, "customerReference", "customerReference is required")
+	}
+	if !slices.Contains(auth.SessionProducts, input.Product) {
+		return nil, errors.NewFieldValidationError(domainName, "product",
+			fmt.Sprintf("product must be one of %s", strings.Join(auth.SessionProducts, ", ")))
+	}
+
+	now := s.now()
+	session := &Entity{
+		ID:                uuid.New().String(),
+		OrganisationID:    org.OrganisationID,
+		CustomerReference: customerReference,
+		Product:           input.Product,
+		Scopes:            append([]string(nil), Scopes...),
+		ExpiresAt:         now.Add(s.ttl).UTC().Truncate(time.Second),
+	}
+	session.Token, err = s.signer.Sign(auth.SessionClaims{
+		ID:                session.ID,
+		OrganisationID:    session.OrganisationID,
+		CustomerReference: session.CustomerReference,
+		Product:           session.Product,
+		Scopes:            session.Scopes,
+		IssuedAt:          now.Unix(),
+		ExpiresAt:         session.ExpiresAt.Unix(),
+	})
+	if err != nil {
+		return nil, errors.NewInternalError(domainName, "signing session token", err)
+	}
+
+	return session, nil
+}
@@ -0,0 +1,90 @@
+package session
+
+import (
+	"context"
+	"testing"
+
+	"api-golang/internal/finance/currency"
+	"api-golang/internal/organisation/organisation"
+	"api-golang/internal/platform/country"
+	"api-golang/internal/platform/mcc"
+	"api-golang/internal/shared/auth"
+	"api-golang/internal/shared/errors"
+)
+
+func setupService(t *testing.T) (*DefaultService, *auth.TokenSigner) {
+	t.Helper()
+	orgService := organisation.NewService(
+		organisation.NewInMemoryRepository(),
+		country.NewService(country.NewInMemoryRepository()),
+		currency.NewService(currency.NewInMemoryRepository()),
+		mcc.NewService(mcc.NewInMemoryRepository()),
+	)
+	signer, err := auth.NewTokenSigner([]byte("0123456789abcdef0123456789abcdef"))
+	if err != nil {
+		t.Fatalf("NewTokenSigner failed: %v", err)
+	}
+	return NewService(orgService, signer), signer
+}
+
+func apiKeyCaller() *auth.Principal {
+	return &auth.Principal{OrganisationID: "org-parent-1", CredentialType: auth.CredentialAPIKey, Scopes: auth.AllScopes, Product: auth.ProductAPI}
+}
+
+func TestCreateSession(t *testing.T) {
+	svc, signer := setupService(t)
+	ctx := context.Background()
+
+	session, err := svc.CreateSession(ctx, apiKeyCaller(), CreateSessionInput{
+		OrganisationID:    "org-child-1",
+		CustomerReference: "cust-123",
+		Product:           auth.ProductCheckoutSDK,
+	})
+	if err != nil {
+		t.Fatalf("CreateSession failed: %v", err)
+	}
+
+	principal, err := signer.Authenticate(ctx, session.Token)
+	if err != nil {
+		t.Fatalf("Authenticate failed: %v", err)
+	}
+	if principal.OrganisationID != "org-child-1" || principal.CustomerReference != "cust-123" || principal.Product != auth.ProductCheckoutSDK {
+		t.Errorf("Unexpected principal %+v", principal)
+	}
+	if !principal.HasScope(auth.ScopeQuotesCreate) || !principal.HasScope(auth.ScopeQuotesAccept) || principal.HasScope(auth.ScopeQuotesRead) {
+		t.Errorf("Expected only the quote create and accept scopes, got %v", principal.Scopes)
+	}
+}
+
+func TestCreateSession_Validation(t *testing.T) {
+	svc, _ := setupService(t)
+	ctx := context.Background()
+	valid := CreateSessionInput{CustomerReference: "cust-123", Product: auth.ProductCheckoutSDK}
+
+	sessionCaller := &auth.Principal{OrganisationID: "org-parent-1", CredentialType: auth.CredentialSession, Scopes: Scopes}
+	readOnlyCaller := &auth.Principal{OrganisationID: "org-parent-1", CredentialType: auth.CredentialAPIKey, Scopes: []string{auth.ScopeQuotesCreate}}
+	childCaller := &auth.Principal{OrganisationID: "org-child-1", CredentialType: auth.CredentialAPIKey, Scopes: auth.AllScopes}
+
+	tests := []struct {
+		name   string
+		caller *auth.Principal
+		input  CreateSessionInput
+		code   string
+	}{
+		{name: "Session caller", caller: sessionCaller, input: valid, code: errors.ErrCodeForbidden},
+		{name: "Caller without accept scope", caller: readOnlyCaller, input: valid, code: errors.ErrCodeForbidden},
+		{name: "Organisation outside hierarchy", caller: childCaller, input: CreateSessionInput{OrganisationID: "org-parent-1", CustomerReference: "c", Product: auth.ProductCheckoutSDK}, code: errors.ErrCodeForbidden},
+		{name: "Missing customer", caller: apiKeyCaller(), input: CreateSessionInput{Product: auth.ProductCheckoutSDK}, code: errors.ErrCodeValidation},
+		{name: "API product", caller: apiKeyCaller(), input: CreateSessionInput{CustomerReference: "c", Product: auth.ProductAPI}, code: errors.ErrCodeValidation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.CreateSession(ctx, tt.caller, tt.input)
+			var domainErr *errors.DomainError
+			if !errors.IsDomainError(err, &domainErr) || domainErr.Code != tt.code {
+				t.Fatalf("Expected %s error, got %v", tt.code, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,13 @@
+// Package airport handles the IATA airport reference data.
+package airport
+
+// Entity represents an airport with scheduled passenger service
+type Entity struct {
+	IATACode    string  `json:"iataCode"` // 3-letter IATA location code, e.g. "LHR"
+	ICAOCode    string  `json:"icaoCode"` // 4-letter ICAO location indicator, e.g. "EGLL"
+	Name        string  `json:"name"`
+	City        string  `json:"city"`        // City served
+	CountryCode string  `json:"countryCode"` // ISO 3166-1 alpha-3
+	Latitude    float64 `json:"latitude"`    // Decimal degrees
+	Longitude   float64 `json:"longitude"`   // Decimal degrees
+}
@@ -0,0 +1,16 @@
+// Package airport defines ports for the airport sub-domain.
+package airport
+
+import "context"
+
+// Repository defines the port for airport data access
+type Repository interface {
+	GetAll(ctx context.Context) ([]*Entity, error)
+	GetByCode(ctx context.Context, iataCode string) (*Entity, error)
+}
+
+// Service defines the port for airport business logic
+type Service interface {
+	GetAirport(ctx context.Context, iataCode string) (*Entity, error)
+	ValidateAirport(ctx context.Context, iataCode string) (*Entity, error)
+}
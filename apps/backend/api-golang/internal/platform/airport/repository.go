@@ -0,0 +1,80 @@
+package airport
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+
+	"api-golang/internal/shared/errors"
+)
+
+const domainName = "airport"
+
+// airportsJSON lists airports with scheduled passenger service by IATA code,
+// with their ICAO code, city, ISO 3166-1 alpha-3 country and WGS 84 coordinates
+//
+//go:embed data/airports.json
+var airportsJSON []byte
+
+// InMemoryRepository implements Repository interface
+type InMemoryRepository struct {
+	airports []*Entity // Sorted by IATA code
+	byCode   map[string]*Entity
+}
+
+// NewInMemoryRepository creates a new repository loaded with the embedded airport dataset
+func NewInMemoryRepository() *InMemoryRepository {
+	var airports []*Entity
+	if err := json.Unmarshal(airportsJSON, &airports); err != nil {
+		panic(fmt.Sprintf("airport: invalid embedded dataset: %v", err))
+	}
+
+	repo := &InMemoryRepository{
+		airports: airports,
+		byCode:   make(map[string]*Entity, len(airports)),
+	}
+	for _, a := range airports {
+		if !isWellFormed(a.IATACode) {
+			panic(fmt.Sprintf("airport: invalid IATA code %q in embedded dataset", a.IATACode))
+		}
+		if a.Latitude < -90 || a.Latitude > 90 || a.Longitude < -180 || a.Longitude > 180 {
+			panic(fmt.Sprintf("airport: invalid coordinates for %s in embedded dataset", a.IATACode))
+		}
+		if _, exists := repo.byCode[a.IATACode]; exists {
+			panic(fmt.Sprintf("airport: duplicate IATA code %s in embedded dataset", a.IATACode))
+		}
+		repo.byCode[a.IATACode] = a
+	}
+
+	return repo
+}
+
+// GetAll retrieves all airports, sorted by IATA code
+func (r *InMemoryRepository) GetAll(_ context.Context) ([]*Entity, error) {
+	airports := make([]*Entity, len(r.airports))
+	copy(airports, r.airports)
+	return airports, nil
+}
+
+// GetByCode retrieves an airport by IATA code
+func (r *InMemoryRepository) GetByCode(_ context.Context, iataCode string) (*Entity, error) {
+	a, exists := r.byCode[iataCode]
+	if !exists {
+		return nil, errors.NewNotFoundError(domainName, fmt.Sprintf("airport not found: %s", iataCode))
+	}
+	return a, nil
+}
+
+// isWellFormed reports whether a code is three uppercase letters
+func isWellFormed(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for _, c := range code {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,52 @@
+package airport
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"api-golang/internal/shared/errors"
+
+	"github.com/bilo-mono/packages/common/service"
+)
+
+// DefaultService implements the Service interface
+type DefaultService struct {
+	service.BaseService[Repository]
+}
+
+// NewService creates a new airport service
+func NewService(repo Repository) *DefaultService {
+	return &DefaultService{
+		BaseService: service.NewBaseService(repo),
+	}
+}
+
+// GetAirport retrieves an airport by IATA code, case-insensitive
+func (s *DefaultService) GetAirport(ctx context.Context, iataCode string) (*Entity, error) {
+	code := strings.ToUpper(strings.TrimSpace(iataCode))
+	a, err := s.Repo.GetByCode(ctx, code)
+	if err != nil {
+		return nil, fmt.Errorf("getting airport %s: %w", code, err)
+	}
+	return a, nil
+}
+
+// ValidateAirport checks that an IATA code is in the dataset, returning a
+// validation error for malformed or unknown codes
+func (s *DefaultService) ValidateAirport(ctx context.Context, iataCode string) (*Entity, error) {
+	code := strings.ToUpper(strings.TrimSpace(iataCode))
+	if !isWellFormed(code) {
+		return nil, errors.NewValidationError(domainName, fmt.Sprintf("%q is not a valid IATA airport code: must be 3 letters", iataCode))
+	}
+
+	a, err := s.Repo.GetByCode(ctx, code)
+	if err != nil {
+		var domainErr *errors.DomainError
+		if errors.IsDomainError(err, &domainErr) && domainErr.Code == errors.ErrCodeNotFound {
+			return nil, errors.NewValidationError(domainName, fmt.Sprintf("unknown airport %q", iataCode))
+		}
+		return nil, fmt.Errorf("getting airport %s: %w", code, err)
+	}
+	return a, nil
+}
@@ -0,0 +1,67 @@
+package airport
+
+import (
+	"context"
+	"testing"
+
+	"api-golang/internal/shared/errors"
+)
+
+func TestGetAirport_FullDataset(t *testing.T) {
+	service := NewService(NewInMemoryRepository())
+	ctx := context.Background()
+
+	airports, err := service.Repo.GetAll(ctx)
+	if err != nil {
+		t.Fatalf("GetAll failed: %v", err)
+	}
+	for i := 1; i < len(airports); i++ {
+		if airports[i-1].IATACode >= airports[i].IATACode {
+			t.Fatalf("Expected airports sorted by IATA code, got %s before %s", airports[i-1].IATACode, airports[i].IATACode)
+		}
+	}
+
+	airport, err := service.GetAirport(ctx, "lhr")
+	if err != nil {
+		t.Fatalf("GetAirport failed: %v", err)
+	}
+	if airport.ICAOCode != "EGLL" || airport.CountryCode != "GBR" || airport.City != "London" {
+		t.Errorf("Unexpected airport for LHR: %+v", airport)
+	}
+}
+
+func TestValidateAirport(t *testing.T) {
+	service := NewService(NewInMemoryRepository())
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		code     string
+		wantCode string
+		wantErr  bool
+	}{
+		{name: "Known code", code: "JFK", wantCode: "JFK"},
+		{name: "Lower case with spaces", code: " cdg ", wantCode: "CDG"},
+		{name: "Malformed code", code: "LHR1", wantErr: true},
+		{name: "Unknown code", code: "QQQ", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			airport, err := service.ValidateAirport(ctx, tt.code)
+			if tt.wantErr {
+				var domainErr *errors.DomainError
+				if !errors.IsDomainError(err, &domainErr) || domainErr.Code != errors.ErrCodeValidation {
+					t.Errorf("Expected validation error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ValidateAirport failed: %v", err)
+			}
+			if airport.IATACode != tt.wantCode {
+				t.Errorf("Expected %s, got %s", tt.wantCode, airport.IATACode)
+			}
+		})
+	}
+}
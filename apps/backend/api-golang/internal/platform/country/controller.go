@@ -0,0 +1,100 @@
+package country
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"api-golang/internal/shared/errors"
+)
+
+// Controller handles HTTP requests for countries
+type Controller struct {
+	service Service
+}
+
+// NewController creates a new country controller
+func NewController(service Service) *Controller {
+	return &Controller{service: service}
+}
+
+// HandleGetAll handles GET /api/countries
+func (c *Controller) HandleGetAll(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		c.writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed")
+		return
+	}
+
+	countries, err := c.service.GetAllCountries(r.Context())
+	if err != nil {
+		c.writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		return
+	}
+
+	c.writeJSON(w, http.StatusOK, countries)
+}
+
+// HandleGetByCode handles GET /api/countries/{code} and GET /api/countries/{code}/subdivisions
+func (c *Controller) HandleGetByCode(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		c.writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed")
+		return
+	}
+
+	// Extract code and optional sub-resource from path
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/countries/"), "/"), "/")
+	code := strings.ToUpper(parts[0])
+	if code == "" {
+		c.writeError(w, http.StatusBadRequest, "MISSING_FIELD", "Country code is required")
+		return
+	}
+
+	ctx := r.Context()
+	switch {
+	case len(parts) == 1:
+		country, err := c.service.GetCountryByCode(ctx, code)
+		if err != nil {
+			c.writeServiceError(w, err)
+			return
+		}
+		c.writeJSON(w, http.StatusOK, country)
+	case len(parts) == 2 && parts[1] == "subdivisions":
+		subdivisions, err := c.service.GetSubdivisions(ctx, code)
+		if err != nil {
+			c.writeServiceError(w, err)
+			return
+		}
+		c.writeJSON(w, http.StatusOK, subdivisions)
+	default:
+		c.writeError(w, http.StatusNotFound, "NOT_FOUND", "Resource not found")
+	}
+}
+
+// ErrorResponse represents an error response
+type ErrorResponse struct {
+	Error struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func (c *Controller) writeServiceError(w http.ResponseWriter, err error) {
+	if strings.Contains(err.Error(), errors.ErrCodeNotFound) {
+		c.writeError(w, http.StatusNotFound, "NOT_FOUND", err.Error())
+		return
+	}
+	c.writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+}
+
+func (c *Controller) writeError(w http.ResponseWriter, status int, code, message string) {
+	resp := ErrorResponse{}
+	resp.Error.Code = code
+	resp.Error.Message = message
+	c.writeJSON(w, status, resp)
+}
+
+func (c *Controller) writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
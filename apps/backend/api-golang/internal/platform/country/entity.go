@@ -1,9 +1,12 @@
 // Package country handles country-related lookups.
 package country
 
+import "strings"
+
 // Entity represents a country
 // Matches Country data model
 // See: https://www.notion.so/ekko-earth/Currency-and-country-2b7f93807de480d1a1accf1400743413
+// Sales tax rates are held by the salestax domain, not per country.
 type Entity struct {
 	ISO3Code   string `json:"iso3Code"`           // Required - ISO 3166-1 alpha-3
 	Name       string `json:"name"`               // Required
@@ -14,9 +17,24 @@ type Entity struct {
 	ISONumeric string `json:"isoNumeric,omitempty"`
 
 	// Legacy fields for backward compatibility
-	ID       string  `json:"id,omitempty"`       // Can map to ISO3Code
-	Code     string  `json:"code,omitempty"`     // Can map to ISO2Code or ISO3Code
-	Currency string  `json:"currency,omitempty"` // Not in data model but useful
-	TaxRate  float64 `json:"taxRate,omitempty"`  // Not in data model but useful
-	IsEU     bool    `json:"isEU,omitempty"`     // Not in data model but useful
+	ID       string `json:"id,omitempty"`       // Can map to ISO3Code
+	Code     string `json:"code,omitempty"`     // Can map to ISO2Code or ISO3Code
+	Currency string `json:"currency,omitempty"` // ISO 4217 code of the main currency
+	IsEU     bool   `json:"isEU,omitempty"`     // EU member state
+}
+
+// Subdivision represents an ISO 3166-2 country subdivision
+type Subdivision struct {
+	Code       string `json:"code"` // ISO 3166-2 code, e.g. "US-CA"
+	Name       string `json:"name"`
+	Type       string `json:"type"`                 // State, Province, Region, ...
+	ParentCode string `json:"parentCode,omitempty"` // Enclosing subdivision, e.g. "GB-SCT"
+}
+
+// LocalCode returns the subdivision code without the country prefix (e.g. "CA" for "US-CA")
+func (s Subdivision) LocalCode() string {
+	if _, local, found := strings.Cut(s.Code, "-"); found {
+		return local
+	}
+	return s.Code
 }
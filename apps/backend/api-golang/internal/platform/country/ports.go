@@ -5,11 +5,17 @@ import "context"
 
 // Repository defines the port for country data access
 type Repository interface {
+	GetAll(ctx context.Context) ([]*Entity, error)
 	GetByCode(ctx context.Context, code string) (*Entity, error)
 	GetByID(ctx context.Context, id string) (*Entity, error)
+	GetSubdivisions(ctx context.Context, countryCode string) ([]Subdivision, error)
 }
 
 // Service defines the port for country business logic
 type Service interface {
+	GetAllCountries(ctx context.Context) ([]*Entity, error)
 	GetCountryByCode(ctx context.Context, code string) (*Entity, error)
+	GetSubdivisions(ctx context.Context, countryCode string) ([]Subdivision, error)
+	ValidateSubdivision(ctx context.Context, countryCode, subdivision string) (*Subdivision, error)
+	NormalisePostalCode(ctx context.Context, countryCode, postalCode string) (string, error)
 }
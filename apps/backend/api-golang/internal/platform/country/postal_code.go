@@ -0,0 +1,111 @@
+package country
+
+import (
+	"regexp"
+	"strings"
+)
+
+// postalCodeFormat describes a country's postal code format.
+// pattern is matched against the compact form (upper case, no spaces or
+// hyphens); format rebuilds the canonical display form from it.
+type postalCodeFormat struct {
+	pattern *regexp.Regexp
+	format  func(compact string) string
+	example string
+}
+
+// compact returns the code unchanged
+func compact(code string) string {
+	return code
+}
+
+// splitAt inserts sep after the first n characters
+func splitAt(n int, sep string) func(string) string {
+	return func(code string) string {
+		if len(code) <= n {
+			return code
+		}
+		return code[:n] + sep + code[n:]
+	}
+}
+
+// splitBeforeLast inserts sep before the last n characters
+func splitBeforeLast(n int, sep string) func(string) string {
+	return func(code string) string {
+		if len(code) <= n {
+			return code
+		}
+		return code[:len(code)-n] + sep + code[len(code)-n:]
+	}
+}
+
+// postalCodeFormats maps ISO 3166-1 alpha-2 codes to postal code formats.
+// Countries not listed here are only trimmed and upper-cased.
+var postalCodeFormats = map[string]postalCodeFormat{
+	"AT": {regexp.MustCompile(`^[0-9]{4}$`), compact, "1010"},
+	"AU": {regexp.MustCompile(`^[0-9]{4}$`), compact, "2000"},
+	"BE": {regexp.MustCompile(`^[0-9]{4}$`), compact, "1000"},
+	"BG": {regexp.MustCompile(`^[0-9]{4}$`), compact, "1000"},
+	"BR": {regexp.MustCompile(`^[0-9]{8}$`), splitAt(5, "-"), "01310-100"},
+	"CA": {regexp.MustCompile(`^[ABCEGHJ-NPRSTVXY][0-9][ABCEGHJ-NPRSTV-Z][0-9][ABCEGHJ-NPRSTV-Z][0-9]$`), splitAt(3, " "), "K1A 0B1"},
+	"CH": {regexp.MustCompile(`^[0-9]{4}$`), compact, "8001"},
+	"CN": {regexp.MustCompile(`^[0-9]{6}$`), compact, "100000"},
+	"CY": {regexp.MustCompile(`^[0-9]{4}$`), compact, "1010"},
+	"CZ": {regexp.MustCompile(`^[0-9]{5}$`), splitAt(3, " "), "110 00"},
+	"DE": {regexp.MustCompile(`^[0-9]{5}$`), compact, "10115"},
+	"DK": {regexp.MustCompile(`^[0-9]{4}$`), compact, "1050"},
+	"EE": {regexp.MustCompile(`^[0-9]{5}$`), compact, "10111"},
+	"ES": {regexp.MustCompile(`^(0[1-9]|[1-4][0-9]|5[0-2])[0-9]{3}$`), compact, "28001"},
+	"FI": {regexp.MustCompile(`^[0-9]{5}$`), compact, "00100"},
+	"FR": {regexp.MustCompile(`^[0-9]{5}$`), compact, "75001"},
+	"GB": {regexp.MustCompile(`^([A-Z]{1,2}[0-9][A-Z0-9]?[0-9][A-Z]{2}|GIR0AA)$`), splitBeforeLast(3, " "), "EC1A 1BB"},
+	"GR": {regexp.MustCompile(`^[0-9]{5}$`), splitAt(3, " "), "105 57"},
+	"HR": {regexp.MustCompile(`^[0-9]{5}$`), compact, "10000"},
+	"HU": {regexp.MustCompile(`^[0-9]{4}$`), compact, "1011"},
+	"IE": {regexp.MustCompile(`^[AC-FHKNPRTV-Y][0-9]{2}[0-9AC-FHKNPRTV-Y]{4}$|^D6W[0-9AC-FHKNPRTV-Y]{4}$`), splitAt(3, " "), "D02 X285"},
+	"IN": {regexp.MustCompile(`^[1-9][0-9]{5}$`), compact, "110001"},
+	"IT": {regexp.MustCompile(`^[0-9]{5}$`), compact, "00118"},
+	"JP": {regexp.MustCompile(`^[0-9]{7}$`), splitAt(3, "-"), "100-0001"},
+	"LT": {regexp.MustCompile(`^(LT)?[0-9]{5}$`), func(code string) string { return "LT-" + strings.TrimPrefix(code, "LT") }, "LT-01100"},
+	"LU": {regexp.MustCompile(`^(L)?[0-9]{4}$`), func(code string) string { return strings.TrimPrefix(code, "L") }, "1009"},
+	"LV": {regexp.MustCompile(`^(LV)?[0-9]{4}$`), func(code string) string { return "LV-" + strings.TrimPrefix(code, "LV") }, "LV-1050"},
+	"MT": {regexp.MustCompile(`^[A-Z]{3}[0-9]{4}$`), splitAt(3, " "), "VLT 1117"},
+	"MX": {regexp.MustCompile(`^[0-9]{5}$`), compact, "06000"},
+	"NL": {regexp.MustCompile(`^[1-9][0-9]{3}[A-Z]{2}$`), splitAt(4, " "), "1012 AB"},
+	"NO": {regexp.MustCompile(`^[0-9]{4}$`), compact, "0150"},
+	"NZ": {regexp.MustCompile(`^[0-9]{4}$`), compact, "6011"},
+	"PL": {regexp.MustCompile(`^[0-9]{5}$`), splitAt(2, "-"), "00-950"},
+	"PT": {regexp.MustCompile(`^[0-9]{7}$`), splitAt(4, "-"), "1000-001"},
+	"RO": {regexp.MustCompile(`^[0-9]{6}$`), compact, "010011"},
+	"SE": {regexp.MustCompile(`^[0-9]{5}$`), splitAt(3, " "), "111 22"},
+	"SG": {regexp.MustCompile(`^[0-9]{6}$`), compact, "018956"},
+	"SI": {regexp.MustCompile(`^[0-9]{4}$`), compact, "1000"},
+	"SK": {regexp.MustCompile(`^[0-9]{5}$`), splitAt(3, " "), "811 01"},
+	"US": {regexp.MustCompile(`^[0-9]{5}([0-9]{4})?$`), splitAt(5, "-"), "90001"},
+	"ZA": {regexp.MustCompile(`^[0-9]{4}$`), compact, "0001"},
+}
+
+// compactPostalCode upper-cases a postal code and strips spaces and hyphens
+func compactPostalCode(postalCode string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '\t':
+			return -1
+		}
+		return r
+	}, strings.ToUpper(strings.TrimSpace(postalCode)))
+}
+
+// normalisePostalCode returns the canonical form of a postal code for a country
+// (ISO 3166-1 alpha-2), and false if the code is malformed for that country.
+func normalisePostalCode(iso2Code, postalCode string) (string, bool) {
+	code := compactPostalCode(postalCode)
+	format, exists := postalCodeFormats[iso2Code]
+	if !exists {
+		return strings.ToUpper(strings.TrimSpace(postalCode)), code != ""
+	}
+	if !format.pattern.MatchString(code) {
+		return "", false
+	}
+	return format.format(code), true
+}
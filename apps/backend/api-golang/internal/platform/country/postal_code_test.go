@@ -0,0 +1,50 @@
+package country
+
+import (
+	"context"
+	"testing"
+)
+
+func TestNormalisePostalCode(t *testing.T) {
+	service := NewService(NewInMemoryRepository())
+	ctx := context.Background()
+
+	tests := []struct {
+		country    string
+		postalCode string
+		want       string
+		wantErr    bool
+	}{
+		{country: "GBR", postalCode: "ec1a1bb", want: "EC1A 1BB"},
+		{country: "GBR", postalCode: "EC1A 1BB", want: "EC1A 1BB"},
+		{country: "GB", postalCode: "EC1A-1BB", want: "EC1A 1BB"},
+		{country: "GBR", postalCode: "EC1A", wantErr: true},
+		{country: "USA", postalCode: "90001", want: "90001"},
+		{country: "USA", postalCode: "900011234", want: "90001-1234"},
+		{country: "USA", postalCode: "9000", wantErr: true},
+		{country: "NLD", postalCode: "1012ab", want: "1012 AB"},
+		{country: "CAN", postalCode: "k1a0b1", want: "K1A 0B1"},
+		{country: "IRL", postalCode: "d02x285", want: "D02 X285"},
+		{country: "POL", postalCode: "00950", want: "00-950"},
+		{country: "ARE", postalCode: " dubai ", want: "DUBAI"}, // No known format
+		{country: "XXX", postalCode: "12345", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.country+"/"+tt.postalCode, func(t *testing.T) {
+			got, err := service.NormalisePostalCode(ctx, tt.country, tt.postalCode)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected validation error, got %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NormalisePostalCode failed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("NormalisePostalCode() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,7 +2,10 @@ package country
 
 import (
 	"context"
+	_ "embed"
+	"encoding/json"
 	"fmt"
+	"sort"
 	"sync"
 
 	"api-golang/internal/shared/errors"
@@ -10,41 +13,73 @@ import (
 
 const domainName = "country"
 
+// countriesJSON is the ISO 3166-1 country list with ISO 3166-2 subdivisions
+// (generated from the Debian iso-codes package, regions follow UN M49),
+// with each country's main ISO 4217 currency and EU membership
+//
+//go:embed data/countries.json
+var countriesJSON []byte
+
 // InMemoryRepository implements Repository interface
 type InMemoryRepository struct {
+	countries       []*Entity          // Sorted by name
 	countriesByCode map[string]*Entity // Supports both 2-letter and 3-letter codes
 	countriesByID   map[string]*Entity
+	subdivisions    map[string][]Subdivision // key: ISO3 code
 	mu              sync.RWMutex
 }
 
-// NewInMemoryRepository creates a new repository with sample data
+// countryRecord is a country entry in the embedded dataset
+type countryRecord struct {
+	Entity
+	Subdivisions []Subdivision `json:"subdivisions"`
+}
+
+// NewInMemoryRepository creates a new repository loaded with the embedded ISO 3166 dataset
 func NewInMemoryRepository() *InMemoryRepository {
+	var records []countryRecord
+	if err := json.Unmarshal(countriesJSON, &records); err != nil {
+		panic(fmt.Sprintf("country: invalid embedded dataset: %v", err))
+	}
+
 	repo := &InMemoryRepository{
+		countries:       make([]*Entity, 0, len(records)),
 		countriesByCode: make(map[string]*Entity),
 		countriesByID:   make(map[string]*Entity),
+		subdivisions:    make(map[string][]Subdivision),
 	}
 
-	// Seed with sample data - include both ISO2 and ISO3 codes
-	countries := []*Entity{
-		{ID: "1", Code: "GB", ISO2Code: "GB", ISO3Code: "GBR", Name: "United Kingdom", Currency: "GBP", TaxRate: 0.20, IsEU: false},
-		{ID: "2", Code: "IE", ISO2Code: "IE", ISO3Code: "IRL", Name: "Ireland", Currency: "EUR", TaxRate: 0.23, IsEU: true},
-		{ID: "3", Code: "DE", ISO2Code: "DE", ISO3Code: "DEU", Name: "Germany", Currency: "EUR", TaxRate: 0.19, IsEU: true},
-		{ID: "4", Code: "FR", ISO2Code: "FR", ISO3Code: "FRA", Name: "France", Currency: "EUR", TaxRate: 0.20, IsEU: true},
-		{ID: "5", Code: "US", ISO2Code: "US", ISO3Code: "USA", Name: "United States", Currency: "USD", TaxRate: 0.0, IsEU: false},
-		{ID: "6", Code: "NL", ISO2Code: "NL", ISO3Code: "NLD", Name: "Netherlands", Currency: "EUR", TaxRate: 0.21, IsEU: true},
-		{ID: "7", Code: "ES", ISO2Code: "ES", ISO3Code: "ESP", Name: "Spain", Currency: "EUR", TaxRate: 0.21, IsEU: true},
-	}
+	for i := range records {
+		c := &records[i].Entity
+		c.ID = c.ISO3Code
+		c.Code = c.ISO2Code
+		c.Status = "active"
 
-	for _, c := range countries {
+		repo.countries = append(repo.countries, c)
 		// Index by both 2-letter and 3-letter codes
 		repo.countriesByCode[c.ISO2Code] = c
 		repo.countriesByCode[c.ISO3Code] = c
 		repo.countriesByID[c.ID] = c
+		repo.subdivisions[c.ISO3Code] = records[i].Subdivisions
 	}
 
+	sort.Slice(repo.countries, func(i, j int) bool {
+		return repo.countries[i].Name < repo.countries[j].Name
+	})
+
 	return repo
 }
 
+// GetAll retrieves all countries, sorted by name
+func (r *InMemoryRepository) GetAll(_ context.Context) ([]*Entity, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	countries := make([]*Entity, len(r.countries))
+	copy(countries, r.countries)
+	return countries, nil
+}
+
 // GetByCode retrieves a country by ISO code (supports both 2-letter and 3-letter codes)
 func (r *InMemoryRepository) GetByCode(_ context.Context, code string) (*Entity, error) {
 	r.mu.RLock()
@@ -68,3 +103,18 @@ func (r *InMemoryRepository) GetByID(_ context.Context, id string) (*Entity, err
 	}
 	return country, nil
 }
+
+// GetSubdivisions retrieves the ISO 3166-2 subdivisions of a country
+func (r *InMemoryRepository) GetSubdivisions(ctx context.Context, countryCode string) ([]Subdivision, error) {
+	country, err := r.GetByCode(ctx, countryCode)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	subdivisions := make([]Subdivision, len(r.subdivisions[country.ISO3Code]))
+	copy(subdivisions, r.subdivisions[country.ISO3Code])
+	return subdivisions, nil
+}
@@ -3,10 +3,22 @@ package country
 import (
 	"context"
 	"fmt"
+	"strings"
+
+	"api-golang/internal/shared/errors"
 
 	"github.com/bilo-mono/packages/common/service"
 )
 
+// strictSubdivisionCountries are the countries whose state must be a known
+// ISO 3166-2 subdivision, because sales tax is set per state or province.
+// Elsewhere addresses commonly use regions, counties or cities that are not
+// ISO 3166-2 names (e.g. "Greater London"), so unmatched values are kept as given.
+var strictSubdivisionCountries = map[string]bool{
+	"USA": true,
+	"CAN": true,
+}
+
 // DefaultService implements the Service interface
 type DefaultService struct {
 	service.BaseService[Repository]
@@ -27,3 +39,81 @@ func (s *DefaultService) GetCountryByCode(ctx context.Context, code string) (*En
 	}
 	return country, nil
 }
+
+// GetAllCountries retrieves all countries, sorted by name
+func (s *DefaultService) GetAllCountries(ctx context.Context) ([]*Entity, error) {
+	countries, err := s.Repo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting countries: %w", err)
+	}
+	return countries, nil
+}
+
+// GetSubdivisions retrieves the ISO 3166-2 subdivisions of a country
+func (s *DefaultService) GetSubdivisions(ctx context.Context, countryCode string) ([]Subdivision, error) {
+	subdivisions, err := s.Repo.GetSubdivisions(ctx, countryCode)
+	if err != nil {
+		return nil, fmt.Errorf("getting subdivisions for %s: %w", countryCode, err)
+	}
+	return subdivisions, nil
+}
+
+// ValidateSubdivision checks that a state/subdivision value exists in a country.
+// The value may be the full ISO 3166-2 code ("US-CA"), the local code ("CA")
+// or the subdivision name, all case-insensitive. Only the US and Canada reject
+// values that match no subdivision; elsewhere, and for countries without
+// ISO 3166-2 subdivisions, nil is returned and the value is kept as given.
+func (s *DefaultService) ValidateSubdivision(ctx context.Context, countryCode, subdivision string) (*Subdivision, error) {
+	subdivisions, err := s.Repo.GetSubdivisions(ctx, countryCode)
+	if err != nil {
+		var domainErr *errors.DomainError
+		if errors.IsDomainError(err, &domainErr) && domainErr.Code == errors.ErrCodeNotFound {
+			return nil, errors.NewValidationError(domainName, fmt.Sprintf("unknown country code: %s", countryCode))
+		}
+		return nil, fmt.Errorf("getting subdivisions for %s: %w", countryCode, err)
+	}
+	if len(subdivisions) == 0 {
+		return nil, nil
+	}
+
+	value := strings.TrimSpace(subdivision)
+	for i := range subdivisions {
+		sub := &subdivisions[i]
+		if strings.EqualFold(sub.Code, value) || strings.EqualFold(sub.LocalCode(), value) || strings.EqualFold(sub.Name, value) {
+			return sub, nil
+		}
+	}
+
+	country, err := s.Repo.GetByCode(ctx, countryCode)
+	if err != nil {
+		return nil, fmt.Errorf("getting country by code %s: %w", countryCode, err)
+	}
+	if !strictSubdivisionCountries[country.ISO3Code] {
+		return nil, nil
+	}
+	return nil, errors.NewValidationError(domainName,
+		fmt.Sprintf("%q is not a valid subdivision of %s", subdivision, countryCode))
+}
+
+// NormalisePostalCode validates a postal code against the country's format and
+// returns it in canonical form (e.g. "ec1a1bb" becomes "EC1A 1BB" for GBR)
+func (s *DefaultService) NormalisePostalCode(ctx context.Context, countryCode, postalCode string) (string, error) {
+	country, err := s.Repo.GetByCode(ctx, countryCode)
+	if err != nil {
+		var domainErr *errors.DomainError
+		if errors.IsDomainError(err, &domainErr) && domainErr.Code == errors.ErrCodeNotFound {
+			return "", errors.NewValidationError(domainName, fmt.Sprintf("unknown country code: %s", countryCode))
+		}
+		return "", fmt.Errorf("getting country by code %s: %w", countryCode, err)
+	}
+
+	normalised, ok := normalisePostalCode(country.ISO2Code, postalCode)
+	if !ok {
+		message := fmt.Sprintf("%q is not a valid postal code for %s", postalCode, country.ISO3Code)
+		if format, exists := postalCodeFormats[country.ISO2Code]; exists {
+			message += fmt.Sprintf(" (e.g. %s)", format.example)
+		}
+		return "", errors.NewValidationError(domainName, message)
+	}
+	return normalised, nil
+}
@@ -0,0 +1,83 @@
+package country
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGetCountryByCode_FullDataset(t *testing.T) {
+	service := NewService(NewInMemoryRepository())
+	ctx := context.Background()
+
+	countries, err := service.GetAllCountries(ctx)
+	if err != nil {
+		t.Fatalf("GetAllCountries failed: %v", err)
+	}
+	if len(countries) != 249 {
+		t.Errorf("Expected 249 ISO 3166-1 countries, got %d", len(countries))
+	}
+
+	for _, code := range []string{"JP", "JPN"} {
+		country, err := service.GetCountryByCode(ctx, code)
+		if err != nil {
+			t.Fatalf("GetCountryByCode(%s) failed: %v", code, err)
+		}
+		if country.ISO3Code != "JPN" || country.ISONumeric != "392" || country.Region != "Eastern Asia" || country.Continent != "Asia" || country.Currency != "JPY" {
+			t.Errorf("Unexpected country for %s: %+v", code, country)
+		}
+	}
+
+	ireland, err := service.GetCountryByCode(ctx, "IRL")
+	if err != nil {
+		t.Fatalf("GetCountryByCode(IRL) failed: %v", err)
+	}
+	if ireland.Currency != "EUR" || !ireland.IsEU {
+		t.Errorf("Expected IRL to be an EU member using EUR, got %+v", ireland)
+	}
+}
+
+func TestValidateSubdivision(t *testing.T) {
+	service := NewService(NewInMemoryRepository())
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		country     string
+		subdivision string
+		wantCode    string
+		wantErr     bool
+	}{
+		{name: "Local code", country: "USA", subdivision: "CA", wantCode: "US-CA"},
+		{name: "Full code", country: "US", subdivision: "us-ny", wantCode: "US-NY"},
+		{name: "Name", country: "GBR", subdivision: "Scotland", wantCode: "GB-SCT"},
+		{name: "Unknown subdivision", country: "USA", subdivision: "XX", wantErr: true},
+		{name: "Unknown province", country: "CAN", subdivision: "Cascadia", wantErr: true},
+		{name: "Unmatched region outside US and Canada", country: "GBR", subdivision: "Greater London"},
+		{name: "Unmatched city outside US and Canada", country: "GB", subdivision: "London"},
+		{name: "Unknown country", country: "XXX", subdivision: "CA", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sub, err := service.ValidateSubdivision(ctx, tt.country, tt.subdivision)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("Expected validation error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ValidateSubdivision failed: %v", err)
+			}
+			if tt.wantCode == "" {
+				if sub != nil {
+					t.Errorf("Expected no subdivision, got %s", sub.Code)
+				}
+				return
+			}
+			if sub.Code != tt.wantCode {
+				t.Errorf("Expected %s, got %s", tt.wantCode, sub.Code)
+			}
+		})
+	}
+}
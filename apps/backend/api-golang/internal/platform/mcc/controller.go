@@ -0,0 +1,87 @@
+package mcc
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"api-golang/internal/shared/errors"
+)
+
+// Controller handles HTTP requests for merchant category codes
+type Controller struct {
+	service Service
+}
+
+// NewController creates a new merchant category code controller
+func NewController(service Service) *Controller {
+	return &Controller{service: service}
+}
+
+// HandleGetAll handles GET /api/mccs?group={group}&q={search}
+func (c *Controller) HandleGetAll(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		c.writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed")
+		return
+	}
+
+	mccs, err := c.service.GetAllMCCs(r.Context(), Filter{
+		Group:  r.URL.Query().Get("group"),
+		Search: r.URL.Query().Get("q"),
+	})
+	if err != nil {
+		c.writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		return
+	}
+
+	c.writeJSON(w, http.StatusOK, mccs)
+}
+
+// HandleGetByCode handles GET /api/mccs/{code} and GET /api/mccs/groups
+func (c *Controller) HandleGetByCode(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		c.writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed")
+		return
+	}
+
+	code := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/mccs/"), "/")
+	switch {
+	case code == "":
+		c.writeError(w, http.StatusBadRequest, "MISSING_FIELD", "Merchant category code is required")
+	case code == "groups":
+		c.writeJSON(w, http.StatusOK, c.service.GetGroups(r.Context()))
+	default:
+		m, err := c.service.GetMCC(r.Context(), code)
+		if err != nil {
+			var domainErr *errors.DomainError
+			if errors.IsDomainError(err, &domainErr) && domainErr.Code == errors.ErrCodeNotFound {
+				c.writeError(w, http.StatusNotFound, "NOT_FOUND", domainErr.Message)
+				return
+			}
+			c.writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+			return
+		}
+		c.writeJSON(w, http.StatusOK, m)
+	}
+}
+
+// ErrorResponse represents an error response
+type ErrorResponse struct {
+	Error struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func (c *Controller) writeError(w http.ResponseWriter, status int, code, message string) {
+	resp := ErrorResponse{}
+	resp.Error.Code = code
+	resp.Error.Message = message
+	c.writeJSON(w, status, resp)
+}
+
+func (c *Controller) writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
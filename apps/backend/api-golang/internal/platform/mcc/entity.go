@@ -0,0 +1,64 @@
+// Package mcc handles the ISO 18245 merchant category code registry.
+package mcc
+
+// Entity represents a merchant category code (ISO 18245)
+type Entity struct {
+	Code        string `json:"code"`               // 4-digit code, e.g. "5411"
+	RangeEnd    string `json:"rangeEnd,omitempty"` // Last code of a brand-specific range (airlines, car rental, hotels)
+	Description string `json:"description"`
+	Group       string `json:"group"`     // Category group key, e.g. "retail"
+	GroupName   string `json:"groupName"` // Category group name, e.g. "Retail Outlet Services"
+}
+
+// Group represents a category group, a contiguous block of codes
+type Group struct {
+	Key   string `json:"key"`
+	Name  string `json:"name"`
+	First string `json:"first"` // First code in the group
+	Last  string `json:"last"`  // Last code in the group
+}
+
+// Contains reports whether a code falls within the group
+func (g Group) Contains(code string) bool {
+	return code >= g.First && code <= g.Last
+}
+
+// groups are the ISO 18245 category groups, in code order
+var groups = []Group{
+	{Key: "agricultural", Name: "Agricultural Services", First: "0001", Last: "1499"},
+	{Key: "contracted", Name: "Contracted Services", First: "1500", Last: "2999"},
+	{Key: "airlines", Name: "Airlines", First: "3000", Last: "3299"},
+	{Key: "carRental", Name: "Car Rental", First: "3300", Last: "3499"},
+	{Key: "lodging", Name: "Lodging", First: "3500", Last: "3999"},
+	{Key: "transportation", Name: "Transportation Services", First: "4000", Last: "4799"},
+	{Key: "utilities", Name: "Utility Services", First: "4800", Last: "4999"},
+	{Key: "retail", Name: "Retail Outlet Services", First: "5000", Last: "5599"},
+	{Key: "clothing", Name: "Clothing Stores", First: "5600", Last: "5699"},
+	{Key: "miscellaneous", Name: "Miscellaneous Stores", First: "5700", Last: "7299"},
+	{Key: "business", Name: "Business Services", First: "7300", Last: "7999"},
+	{Key: "professional", Name: "Professional Services and Membership Organisations", First: "8000", Last: "8999"},
+	{Key: "government", Name: "Government Services", First: "9000", Last: "9999"},
+}
+
+// groupFor returns the category group a code belongs to
+func groupFor(code string) (Group, bool) {
+	for _, g := range groups {
+		if g.Contains(code) {
+			return g, true
+		}
+	}
+	return Group{}, false
+}
+
+// isWellFormed reports whether a code is 4 digits
+func isWellFormed(code string) bool {
+	if len(code) != 4 {
+		return false
+	}
+	for _, c := range code {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,24 @@
+// Package mcc defines ports for the merchant category code sub-domain.
+package mcc
+
+import "context"
+
+// Repository defines the port for merchant category code data access
+type Repository interface {
+	GetAll(ctx context.Context) ([]*Entity, error)
+	GetByCode(ctx context.Context, code string) (*Entity, error)
+}
+
+// Service defines the port for merchant category code business logic
+type Service interface {
+	GetAllMCCs(ctx context.Context, filter Filter) ([]*Entity, error)
+	GetMCC(ctx context.Context, code string) (*Entity, error)
+	GetGroups(ctx context.Context) []Group
+	ValidateMCC(ctx context.Context, code string) (*Entity, error)
+}
+
+// Filter narrows the code list
+type Filter struct {
+	Group  string // Category group key
+	Search string // Case-insensitive match on code or description
+}
@@ -0,0 +1,89 @@
+package mcc
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"api-golang/internal/shared/errors"
+)
+
+const domainName = "mcc"
+
+// mccsJSON is the ISO 18245 code list. Brand-specific airline, car rental and
+// hotel codes are listed as ranges rather than individually.
+//
+//go:embed data/mccs.json
+var mccsJSON []byte
+
+// InMemoryRepository implements Repository interface
+type InMemoryRepository struct {
+	mccs   []*Entity // Sorted by code
+	byCode map[string]*Entity
+	ranges []*Entity
+}
+
+// mccDataset is the layout of the embedded dataset
+type mccDataset struct {
+	Codes  []*Entity `json:"codes"`
+	Ranges []*Entity `json:"ranges"`
+}
+
+// NewInMemoryRepository creates a new repository loaded with the embedded ISO 18245 dataset
+func NewInMemoryRepository() *InMemoryRepository {
+	var dataset mccDataset
+	if err := json.Unmarshal(mccsJSON, &dataset); err != nil {
+		panic(fmt.Sprintf("mcc: invalid embedded dataset: %v", err))
+	}
+
+	repo := &InMemoryRepository{
+		byCode: make(map[string]*Entity, len(dataset.Codes)),
+		ranges: dataset.Ranges,
+	}
+	for _, m := range append(dataset.Codes, dataset.Ranges...) {
+		group, ok := groupFor(m.Code)
+		if !isWellFormed(m.Code) || !ok {
+			panic(fmt.Sprintf("mcc: invalid code %q in embedded dataset", m.Code))
+		}
+		m.Group = group.Key
+		m.GroupName = group.Name
+		repo.mccs = append(repo.mccs, m)
+	}
+	for _, m := range dataset.Codes {
+		repo.byCode[m.Code] = m
+	}
+
+	sort.Slice(repo.mccs, func(i, j int) bool {
+		return repo.mccs[i].Code < repo.mccs[j].Code
+	})
+
+	return repo
+}
+
+// GetAll retrieves all codes and brand-specific ranges, sorted by code
+func (r *InMemoryRepository) GetAll(_ context.Context) ([]*Entity, error) {
+	mccs := make([]*Entity, len(r.mccs))
+	copy(mccs, r.mccs)
+	return mccs, nil
+}
+
+// GetByCode retrieves a code. Codes within a brand-specific range return the
+// range's description under the requested code.
+func (r *InMemoryRepository) GetByCode(_ context.Context, code string) (*Entity, error) {
+	if m, exists := r.byCode[code]; exists {
+		return m, nil
+	}
+	for _, rng := range r.ranges {
+		if code >= rng.Code && code <= rng.RangeEnd {
+			return &Entity{
+				Code:        code,
+				Description: rng.Description,
+				Group:       rng.Group,
+				GroupName:   rng.GroupName,
+			}, nil
+		}
+	}
+	return nil, errors.NewNotFoundError(domainName, fmt.Sprintf("merchant category code not found: %s", code))
+}
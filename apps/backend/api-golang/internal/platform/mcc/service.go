@@ -0,0 +1,79 @@
+package mcc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"api-golang/internal/shared/errors"
+
+	"github.com/bilo-mono/packages/common/service"
+)
+
+// DefaultService implements the Service interface
+type DefaultService struct {
+	service.BaseService[Repository]
+}
+
+// NewService creates a new merchant category code service
+func NewService(repo Repository) *DefaultService {
+	return &DefaultService{
+		BaseService: service.NewBaseService(repo),
+	}
+}
+
+// GetAllMCCs retrieves the codes matching the filter, sorted by code
+func (s *DefaultService) GetAllMCCs(ctx context.Context, filter Filter) ([]*Entity, error) {
+	mccs, err := s.Repo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting merchant category codes: %w", err)
+	}
+
+	search := strings.ToLower(strings.TrimSpace(filter.Search))
+	result := make([]*Entity, 0, len(mccs))
+	for _, m := range mccs {
+		if filter.Group != "" && !strings.EqualFold(m.Group, filter.Group) {
+			continue
+		}
+		if search != "" && !strings.HasPrefix(m.Code, search) && !strings.Contains(strings.ToLower(m.Description), search) {
+			continue
+		}
+		result = append(result, m)
+	}
+	return result, nil
+}
+
+// GetMCC retrieves a single code
+func (s *DefaultService) GetMCC(ctx context.Context, code string) (*Entity, error) {
+	m, err := s.Repo.GetByCode(ctx, strings.TrimSpace(code))
+	if err != nil {
+		return nil, fmt.Errorf("getting merchant category code %s: %w", code, err)
+	}
+	return m, nil
+}
+
+// GetGroups returns the category groups in code order
+func (s *DefaultService) GetGroups(_ context.Context) []Group {
+	result := make([]Group, len(groups))
+	copy(result, groups)
+	return result
+}
+
+// ValidateMCC checks that a code is in the registry, returning a validation
+// error for malformed or unknown codes
+func (s *DefaultService) ValidateMCC(ctx context.Context, code string) (*Entity, error) {
+	code = strings.TrimSpace(code)
+	if !isWellFormed(code) {
+		return nil, errors.NewValidationError(domainName, fmt.Sprintf("%q is not a valid merchant category code: must be 4 digits", code))
+	}
+
+	m, err := s.Repo.GetByCode(ctx, code)
+	if err != nil {
+		var domainErr *errors.DomainError
+		if errors.IsDomainError(err, &domainErr) && domainErr.Code == errors.ErrCodeNotFound {
+			return nil, errors.NewValidationError(domainName, fmt.Sprintf("unknown merchant category code: %s", code))
+		}
+		return nil, fmt.Errorf("getting merchant category code %s: %w", code, err)
+	}
+	return m, nil
+}
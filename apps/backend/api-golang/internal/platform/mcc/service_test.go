@@ -0,0 +1,72 @@
+package mcc
+
+import (
+	"context"
+	"testing"
+
+	"api-golang/internal/shared/errors"
+)
+
+func TestValidateMCC(t *testing.T) {
+	service := NewService(NewInMemoryRepository())
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		code      string
+		wantGroup string
+		wantErr   bool
+	}{
+		{name: "Grocery stores", code: "5411", wantGroup: "retail"},
+		{name: "Brand-specific airline", code: "3058", wantGroup: "airlines"},
+		{name: "Brand-specific hotel", code: "3750", wantGroup: "lodging"},
+		{name: "Unassigned code", code: "5410", wantErr: true},
+		{name: "Typo", code: "541", wantErr: true},
+		{name: "Not digits", code: "54AB", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := service.ValidateMCC(ctx, tt.code)
+			if tt.wantErr {
+				var domainErr *errors.DomainError
+				if !errors.IsDomainError(err, &domainErr) || domainErr.Code != errors.ErrCodeValidation {
+					t.Errorf("Expected validation error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ValidateMCC failed: %v", err)
+			}
+			if m.Code != tt.code || m.Group != tt.wantGroup || m.Description == "" {
+				t.Errorf("Unexpected code for %s: %+v", tt.code, m)
+			}
+		})
+	}
+}
+
+func TestGetAllMCCs_Filter(t *testing.T) {
+	service := NewService(NewInMemoryRepository())
+	ctx := context.Background()
+
+	clothing, err := service.GetAllMCCs(ctx, Filter{Group: "clothing"})
+	if err != nil {
+		t.Fatalf("GetAllMCCs failed: %v", err)
+	}
+	if len(clothing) == 0 {
+		t.Fatal("Expected clothing store codes")
+	}
+	for _, m := range clothing {
+		if m.Code < "5600" || m.Code > "5699" {
+			t.Errorf("Code %s is outside the clothing group", m.Code)
+		}
+	}
+
+	grocery, err := service.GetAllMCCs(ctx, Filter{Search: "grocery"})
+	if err != nil {
+		t.Fatalf("GetAllMCCs failed: %v", err)
+	}
+	if len(grocery) != 1 || grocery[0].Code != "5411" {
+		t.Errorf("Expected only 5411 for \"grocery\", got %d codes", len(grocery))
+	}
+}
@@ -0,0 +1,105 @@
+package privacy
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"api-golang/internal/shared/auth"
+	"api-golang/internal/shared/errors"
+)
+
+// Controller handles HTTP requests for data subject exports and erasures
+type Controller struct {
+	orchestrator *Orchestrator
+}
+
+// NewController creates a new privacy controller
+func NewController(orchestrator *Orchestrator) *Controller {
+	return &Controller{orchestrator: orchestrator}
+}
+
+// HandleCustomer handles GET /api/customers/{id}/export,
+// POST /api/customers/{id}/erase and GET /api/customers/{id}/audit
+func (c *Controller) HandleCustomer(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/customers/"), "/"), "/")
+	if len(parts) != 2 || parts[0] == "" {
+		c.writeError(w, http.StatusNotFound, "NOT_FOUND", "Resource not found")
+		return
+	}
+	id, action := parts[0], parts[1]
+
+	caller, ok := auth.PrincipalFromContext(r.Context())
+	if !ok {
+		c.writeError(w, http.StatusUnauthorized, errors.ErrCodeUnauthorized, "API key is required")
+		return
+	}
+
+	switch {
+	case action == "export" && r.Method == http.MethodGet:
+		export, err := c.orchestrator.ExportCustomer(r.Context(), caller, id)
+		if err != nil {
+			c.writeServiceError(w, err)
+			return
+		}
+		w.Header().Set("Content-Disposition", `attachment; filename="customer-`+id+`.json"`)
+		c.writeJSON(w, http.StatusOK, export)
+	case action == "erase" && r.Method == http.MethodPost:
+		entry, err := c.orchestrator.EraseCustomer(r.Context(), caller, id)
+		if err != nil {
+			c.writeServiceError(w, err)
+			return
+		}
+		c.writeJSON(w, http.StatusOK, entry)
+	case action == "audit" && r.Method == http.MethodGet:
+		entries, err := c.orchestrator.ListAuditEntries(r.Context(), caller, id)
+		if err != nil {
+			c.writeServiceError(w, err)
+			return
+		}
+		c.writeJSON(w, http.StatusOK, entries)
+	case action == "export" || action == "erase" || action == "audit":
+		c.writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed")
+	default:
+		c.writeError(w, http.StatusNotFound, "NOT_FOUND", "Resource not found")
+	}
+}
+
+// ErrorResponse represents an error response
+type ErrorResponse struct {
+	Error struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+		Field   string `json:"field,omitempty"` // Request field that failed validation
+	} `json:"error"`
+}
+
+func (c *Controller) writeServiceError(w http.ResponseWriter, err error) {
+	var domainErr *errors.DomainError
+	switch {
+	case errors.IsDomainError(err, &domainErr) && domainErr.Code == errors.ErrCodeNotFound:
+		c.writeError(w, http.StatusNotFound, "NOT_FOUND", "Customer not found")
+	case errors.IsDomainError(err, &domainErr) && domainErr.Code == errors.ErrCodeValidation:
+		c.writeFieldError(w, http.StatusBadRequest, "VALIDATION_ERROR", domainErr.Field, domainErr.Message)
+	default:
+		c.writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+	}
+}
+
+func (c *Controller) writeError(w http.ResponseWriter, status int, code, message string) {
+	c.writeFieldError(w, status, code, "", message)
+}
+
+func (c *Controller) writeFieldError(w http.ResponseWriter, status int, code, field, message string) {
+	resp := ErrorResponse{}
+	resp.Error.Code = code
+	resp.Error.Message = message
+	resp.Error.Field = field
+	c.writeJSON(w, status, resp)
+}
+
+func (c *Controller) writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
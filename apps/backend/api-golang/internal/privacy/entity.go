@@ -0,0 +1,46 @@
+// Package privacy handles data subject requests: exporting and erasing the
+// personal data held about a customer across the customer, carbon footprint,
+// quote and sales tax domains.
+package privacy
+
+import (
+	"time"
+
+	"api-golang/internal/funds/salestax"
+	carbonfootprint "api-golang/internal/impact/carbon_footprint"
+	"api-golang/internal/organisation/customer"
+	"api-golang/internal/quote"
+)
+
+// AuditAction is a data subject action recorded in the audit log
+type AuditAction string
+
+const (
+	AuditActionExport  AuditAction = "export"
+	AuditActionErasure AuditAction = "erasure"
+)
+
+// AuditEntry records a data subject action. It identifies the customer by ID
+// only, so it holds no personal data and survives erasure.
+type AuditEntry struct {
+	ID                    string      `json:"id"`
+	Action                AuditAction `json:"action"`
+	OrganisationID        string      `json:"organisationId"` // The customer's organisation
+	CustomerID            string      `json:"customerId"`
+	ActorOrganisationID   string      `json:"actorOrganisationId"` // Organisation whose credential made the request
+	ActorCredentialID     string      `json:"actorCredentialId"`
+	Footprints            int         `json:"footprints"`            // Footprints exported or erased
+	Quotes                int         `json:"quotes"`                // Quotes exported or erased
+	ExemptionCertificates int         `json:"exemptionCertificates"` // Exemption certificates exported or erased
+	CreatedAt             time.Time   `json:"createdAt"`
+}
+
+// Export is everything held about a customer
+type Export struct {
+	Customer              *customer.Entity                 `json:"customer"`
+	Footprints            []*carbonfootprint.Footprint     `json:"footprints"`
+	Quotes                []*quote.Entity                  `json:"quotes"`
+	ExemptionCertificates []*salestax.ExemptionCertificate `json:"exemptionCertificates"`
+	AuditEntries          []*AuditEntry                    `json:"auditEntries"` // Earlier exports and erasures
+	ExportedAt            time.Time                        `json:"exportedAt"`
+}
@@ -0,0 +1,170 @@
+package privacy
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"api-golang/internal/funds/salestax"
+	carbonfootprint "api-golang/internal/impact/carbon_footprint"
+	"api-golang/internal/organisation/customer"
+	"api-golang/internal/quote"
+	"api-golang/internal/shared/auth"
+	"api-golang/internal/shared/errors"
+
+	"github.com/google/uuid"
+)
+
+// Orchestrator coordinates data subject exports and erasures across the
+// customer, carbon footprint, quote and sales tax domains, recording each in
+// the audit log
+type Orchestrator struct {
+	organisations   OrganisationAccess
+	customerService customer.Service
+	carbonService   carbonfootprint.Service
+	quoteService    quote.Service
+	salesTaxService salestax.Service
+	auditRepo       AuditRepository
+}
+
+// OrchestratorDeps contains all dependencies for the orchestrator
+type OrchestratorDeps struct {
+	Organisations   OrganisationAccess
+	CustomerService customer.Service
+	CarbonService   carbonfootprint.Service
+	QuoteService    quote.Service
+	SalesTaxService salestax.Service
+	AuditRepo       AuditRepository
+}
+
+// NewOrchestrator creates a new privacy orchestrator
+func NewOrchestrator(deps OrchestratorDeps) *Orchestrator {
+	return &Orchestrator{
+		organisations:   deps.Organisations,
+		customerService: deps.CustomerService,
+		carbonService:   deps.CarbonService,
+		quoteService:    deps.QuoteService,
+		salesTaxService: deps.SalesTaxService,
+		auditRepo:       deps.AuditRepo,
+	}
+}
+
+// ExportCustomer returns everything held about a customer of the caller's
+// organisation or one of its descendants
+func (o *Orchestrator) ExportCustomer(ctx context.Context, caller *auth.Principal, customerID string) (*Export, error) {
+	cust, err := o.getAccessibleCustomer(ctx, caller, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	footprints, err := o.carbonService.ListByCustomer(ctx, carbonfootprint.CustomerFilter{CustomerID: cust.ID})
+	if err != nil {
+		return nil, fmt.Errorf("listing footprints: %w", err)
+	}
+	quotes, err := o.quoteService.ListByCustomer(ctx, quote.CustomerFilter{CustomerID: cust.ID})
+	if err != nil {
+		return nil, fmt.Errorf("listing quotes: %w", err)
+	}
+	exemptions, err := o.salesTaxService.GetExemptions(ctx, caller.OrganisationID, salestax.ExemptionHolderCustomer, cust.ID)
+	if err != nil {
+		return nil, fmt.Errorf("listing exemption certificates: %w", err)
+	}
+	auditEntries, err := o.auditRepo.ListByCustomer(ctx, cust.ID)
+	if err != nil {
+		return nil, fmt.Errorf("listing audit entries: %w", err)
+	}
+
+	export := &Export{
+		Customer:              cust,
+		Footprints:            footprints,
+		Quotes:                quotes,
+		ExemptionCertificates: exemptions,
+		AuditEntries:          auditEntries,
+		ExportedAt:            time.Now(),
+	}
+	if _, err := o.audit(ctx, caller, AuditActionExport, cust, len(footprints), len(quotes), len(exemptions)); err != nil {
+		return nil, err
+	}
+	return export, nil
+}
+
+// EraseCustomer removes a customer's personal data from the customer record,
+// their footprints, their quotes and their exemption certificates. Quote
+// amounts, tax rates, the customer's country and the jurisdiction of their
+// exemptions are kept, as they are needed for tax reporting. Erasing an already
+// erased customer is allowed and audited again.
+func (o *Orchestrator) EraseCustomer(ctx context.Context, caller *auth.Principal, customerID string) (*AuditEntry, error) {
+	cust, err := o.getAccessibleCustomer(ctx, caller, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	footprints, err := o.carbonService.ErasePersonalData(ctx, cust.ID)
+	if err != nil {
+		return nil, fmt.Errorf("erasing footprints: %w", err)
+	}
+	quotes, err := o.quoteService.ErasePersonalData(ctx, cust.ID)
+	if err != nil {
+		return nil, fmt.Errorf("erasing quotes: %w", err)
+	}
+	exemptions, err := o.salesTaxService.ErasePersonalData(ctx, cust.ID)
+	if err != nil {
+		return nil, fmt.Errorf("erasing exemption certificates: %w", err)
+	}
+	// The customer goes last so that a failed erasure can be retried
+	if _, err := o.customerService.EraseCustomer(ctx, cust.ID); err != nil {
+		return nil, fmt.Errorf("erasing customer: %w", err)
+	}
+
+	return o.audit(ctx, caller, AuditActionErasure, cust, footprints, quotes, exemptions)
+}
+
+// ListAuditEntries returns the data subject actions taken for a customer
+func (o *Orchestrator) ListAuditEntries(ctx context.Context, caller *auth.Principal, customerID string) ([]*AuditEntry, error) {
+	cust, err := o.getAccessibleCustomer(ctx, caller, customerID)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := o.auditRepo.ListByCustomer(ctx, cust.ID)
+	if err != nil {
+		return nil, fmt.Errorf("listing audit entries: %w", err)
+	}
+	return entries, nil
+}
+
+// getAccessibleCustomer retrieves a customer of the caller's organisation or
+// one of its descendants, reporting any other customer as not found
+func (o *Orchestrator) getAccessibleCustomer(ctx context.Context, caller *auth.Principal, id string) (*customer.Entity, error) {
+	cust, err := o.customerService.GetCustomer(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := o.organisations.ValidateOrganisation(ctx, caller.OrganisationID, cust.OrganisationID); err != nil {
+		var domainErr *errors.DomainError
+		if errors.IsDomainError(err, &domainErr) && domainErr.Code == errors.ErrCodeForbidden {
+			return nil, errors.NewNotFoundError(domainName, "customer not found")
+		}
+		return nil, err
+	}
+	return cust, nil
+}
+
+// audit records a data subject action
+func (o *Orchestrator) audit(ctx context.Context, caller *auth.Principal, action AuditAction, cust *customer.Entity, footprints, quotes, exemptions int) (*AuditEntry, error) {
+	entry := &AuditEntry{
+		ID:                    uuid.New().String(),
+		Action:                action,
+		OrganisationID:        cust.OrganisationID,
+		CustomerID:            cust.ID,
+		ActorOrganisationID:   caller.OrganisationID,
+		ActorCredentialID:     caller.CredentialID,
+		Footprints:            footprints,
+		Quotes:                quotes,
+		ExemptionCertificates: exemptions,
+		CreatedAt:             time.Now(),
+	}
+	if err := o.auditRepo.Create(ctx, entry); err != nil {
+		return nil, errors.NewInternalError(domainName, "recording audit entry", err)
+	}
+	return entry, nil
+}
@@ -0,0 +1,315 @@
+package privacy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	salestaxHolder "api-golang/internal/adapters/salestax/holder"
+	"api-golang/internal/finance/currency"
+	"api-golang/internal/funds/salestax"
+	carbonfootprint "api-golang/internal/impact/carbon_footprint"
+	"api-golang/internal/organisation/customer"
+	"api-golang/internal/organisation/organisation"
+	"api-golang/internal/platform/airport"
+	"api-golang/internal/platform/country"
+	"api-golang/internal/platform/mcc"
+	"api-golang/internal/quote"
+	"api-golang/internal/shared/auth"
+	"api-golang/internal/shared/errors"
+	"api-golang/internal/shared/types"
+)
+
+type fixture struct {
+	orchestrator  *Orchestrator
+	customers     *customer.DefaultService
+	footprintRepo *carbonfootprint.InMemoryFootprintRepository
+	quoteRepo     *quote.InMemoryRepository
+	exemptionRepo *salestax.InMemoryExemptionRepository
+	customer      *customer.Entity
+}
+
+// setupFixture creates an orchestrator and a customer of org-child-1 with one
+// footprint, one quote and one exemption certificate
+func setupFixture(t *testing.T) *fixture {
+	t.Helper()
+	ctx := context.Background()
+
+	countryService := country.NewService(country.NewInMemoryRepository())
+	orgService := organisation.NewService(organisation.NewInMemoryRepository(), countryService,
+		currency.NewService(currency.NewInMemoryRepository()), mcc.NewService(mcc.NewInMemoryRepository()))
+	customerService := customer.NewService(customer.NewInMemoryRepository(), countryService)
+	footprintRepo := carbonfootprint.NewInMemoryFootprintRepository()
+	carbonService := carbonfootprint.NewService(carbonfootprint.NewInMemoryFactorRepository(),
+		carbonfootprint.NewInMemoryCategoryRepository(), carbonfootprint.NewInMemoryNatureFactorRepository(), footprintRepo, airport.NewService(airport.NewInMemoryRepository()))
+	quoteRepo := quote.NewInMemoryRepository()
+	quoteService := quote.NewOrchestrator(quote.OrchestratorDeps{
+		OrganisationService: orgService,
+		CustomerService:     customerService,
+		QuoteRepo:           quoteRepo,
+	})
+	exemptionRepo := salestax.NewInMemoryExemptionRepository()
+	salesTaxService := salestax.NewService(salestax.NewInMemoryRepository(), exemptionRepo, countryService,
+		salestaxHolder.NewAccessAdapter(orgService, customerService))
+
+	cust, err := customerService.GetOrCreateCustomer(ctx, customer.CreateCustomerInput{
+		OrganisationID: "org-child-1",
+		Reference:      "cust-ref-1",
+		Email:          stringPtr("jane@example.com"),
+		Name:           stringPtr("Jane Doe"),
+		CountryCode:    "IRL",
+		PostalCode:     stringPtr("D02 X285"),
+		City:           stringPtr("Dublin"),
+	})
+	if err != nil {
+		t.Fatalf("GetOrCreateCustomer failed: %v", err)
+	}
+
+	err = footprintRepo.Create(ctx, &carbonfootprint.Footprint{
+		ID:              "fp-1",
+		CustomerID:      cust.ID,
+		OrganisationID:  "org-child-1",
+		MCC:             "4511",
+		MerchantName:    stringPtr("Aer Lingus"),
+		MerchantCity:    stringPtr("Dublin"),
+		MerchantCountry: "IRL",
+		Amount:          250,
+		Currency:        "EUR",
+		CarbonCo2eGrams: 180000,
+		Activity: &carbonfootprint.Activity{
+			Flight: &carbonfootprint.FlightActivity{Origin: "DUB", Destination: "JFK", CabinClass: "economy", Passengers: 1},
+		},
+		Items:           carbonfootprint.ItemFootprints{{ItemID: "item-1", Name: "Flight to New York", AmountEUR: 250}},
+		TransactionDate: time.Now(),
+	})
+	if err != nil {
+		t.Fatalf("creating footprint failed: %v", err)
+	}
+
+	err = quoteRepo.Create(ctx, &quote.Entity{
+		ID:                         "quote-1",
+		OrganisationID:             "org-child-1",
+		CustomerID:                 cust.ID,
+		CustomerAddress:            cust.ToQuoteCustomer(),
+		Currency:                   "EUR",
+		CarbonCreditTotal:          4.92,
+		CarbonCreditImpactSalesTax: 0.92,
+		ImpactTaxRate:              0.23,
+		OrderItems: quote.OrderItems{{
+			ItemID: "item-1", SKU: "FLT-DUB-JFK", Name: "Flight to New York", Category: "flights", Quantity: 1,
+			UnitPrice: types.Money{Amount: 250, Currency: "EUR"},
+		}},
+		Status:    quote.StatusAccepted,
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		t.Fatalf("creating quote failed: %v", err)
+	}
+
+	_, err = salesTaxService.RegisterExemption(ctx, "org-child-1", salestax.RegisterExemptionInput{
+		HolderType:        salestax.ExemptionHolderCustomer,
+		HolderID:          cust.ID,
+		Country:           "IRL",
+		Reason:            salestax.ExemptionReasonNonProfit,
+		CertificateNumber: "CHY-12345",
+	})
+	if err != nil {
+		t.Fatalf("RegisterExemption failed: %v", err)
+	}
+
+	return &fixture{
+		orchestrator: NewOrchestrator(OrchestratorDeps{
+			Organisations:   orgService,
+			CustomerService: customerService,
+			CarbonService:   carbonService,
+			QuoteService:    quoteService,
+			SalesTaxService: salesTaxService,
+			AuditRepo:       NewInMemoryAuditRepository(),
+		}),
+		customers:     customerService,
+		footprintRepo: footprintRepo,
+		quoteRepo:     quoteRepo,
+		exemptionRepo: exemptionRepo,
+		customer:      cust,
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func caller(organisationID string) *auth.Principal {
+	return &auth.Principal{OrganisationID: organisationID, CredentialType: auth.CredentialAPIKey, CredentialID: "key-1", Scopes: auth.AllScopes}
+}
+
+func TestExportCustomer(t *testing.T) {
+	f := setupFixture(t)
+	ctx := context.Background()
+
+	// The parent organisation can export its child's customers
+	export, err := f.orchestrator.ExportCustomer(ctx, caller("org-parent-1"), f.customer.ID)
+	if err != nil {
+		t.Fatalf("ExportCustomer failed: %v", err)
+	}
+	if export.Customer.ID != f.customer.ID || *export.Customer.Email != "jane@example.com" {
+		t.Errorf("Expected the customer's details, got %+v", export.Customer)
+	}
+	if len(export.Footprints) != 1 || len(export.Quotes) != 1 || len(export.AuditEntries) != 0 {
+		t.Errorf("Expected 1 footprint, 1 quote and no earlier audit entries, got %d, %d and %d",
+			len(export.Footprints), len(export.Quotes), len(export.AuditEntries))
+	}
+	if len(export.ExemptionCertificates) != 1 || export.ExemptionCertificates[0].CertificateNumber != "CHY-12345" {
+		t.Errorf("Expected the customer's exemption certificate, got %+v", export.ExemptionCertificates)
+	}
+
+	entries, err := f.orchestrator.ListAuditEntries(ctx, caller("org-child-1"), f.customer.ID)
+	if err != nil {
+		t.Fatalf("ListAuditEntries failed: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 audit entry, got %d", len(entries))
+	}
+	entry := entries[0]
+	if entry.Action != AuditActionExport || entry.OrganisationID != "org-child-1" || entry.ActorOrganisationID != "org-parent-1" ||
+		entry.ActorCredentialID != "key-1" || entry.Footprints != 1 || entry.Quotes != 1 || entry.ExemptionCertificates != 1 {
+		t.Errorf("Unexpected audit entry %+v", entry)
+	}
+}
+
+func TestEraseCustomer(t *testing.T) {
+	f := setupFixture(t)
+	ctx := context.Background()
+
+	entry, err := f.orchestrator.EraseCustomer(ctx, caller("org-child-1"), f.customer.ID)
+	if err != nil {
+		t.Fatalf("EraseCustomer failed: %v", err)
+	}
+	if entry.Action != AuditActionErasure || entry.Footprints != 1 || entry.Quotes != 1 || entry.ExemptionCertificates != 1 {
+		t.Errorf("Unexpected audit entry %+v", entry)
+	}
+
+	// The customer record is kept without personal data
+	erased, _ := f.customers.GetCustomer(ctx, f.customer.ID)
+	if erased.Reference != "" || erased.Email != nil || erased.Name != nil || erased.PostalCode != nil || erased.City != nil {
+		t.Errorf("Expected personal data to be erased, got %+v", erased)
+	}
+	if erased.CountryCode != "IRL" || erased.ErasedAt == nil {
+		t.Errorf("Expected the country to be kept and the erasure recorded, got %+v", erased)
+	}
+	if _, err := f.customers.UpdateCustomer(ctx, f.customer.ID, customer.UpdateCustomerInput{Email: stringPtr("new@example.com")}); err == nil {
+		t.Error("Expected an erased customer to reject updates")
+	}
+
+	// The client's reference is released
+	returning, err := f.customers.GetOrCreateCustomer(ctx, customer.CreateCustomerInput{
+		OrganisationID: "org-child-1", Reference: "cust-ref-1", CountryCode: "IRL",
+	})
+	if err != nil {
+		t.Fatalf("GetOrCreateCustomer failed: %v", err)
+	}
+	if returning.ID == f.customer.ID {
+		t.Error("Expected the erased reference to create a new customer")
+	}
+
+	// Footprints keep the emissions but lose merchant, route and item details
+	footprint, _ := f.footprintRepo.GetByID(ctx, "fp-1")
+	if footprint.MerchantName != nil || footprint.MerchantCity != nil || footprint.Items[0].Name != "" ||
+		footprint.Activity.Flight.Origin != "" || footprint.Activity.Flight.Destination != "" {
+		t.Errorf("Expected footprint personal data to be erased, got %+v", footprint)
+	}
+	if footprint.CarbonCo2eGrams != 180000 || footprint.Amount != 250 || footprint.MerchantCountry != "IRL" {
+		t.Errorf("Expected footprint metrics to be kept, got %+v", footprint)
+	}
+
+	// Quotes keep the financial record but lose the customer's address and item names
+	q, _ := f.quoteRepo.GetByID(ctx, "quote-1")
+	if q.CustomerAddress.Reference != "" || q.CustomerAddress.PostalCode != nil || q.CustomerAddress.City != nil ||
+		q.OrderItems[0].Name != "" || q.OrderItems[0].SKU != "" {
+		t.Errorf("Expected quote personal data to be erased, got %+v", q)
+	}
+	if q.CustomerAddress.CountryCode != "IRL" || q.CarbonCreditTotal != 4.92 || q.ImpactTaxRate != 0.23 ||
+		q.OrderItems[0].UnitPrice.Amount != 250 || q.Status != quote.StatusAccepted {
+		t.Errorf("Expected quote financial data to be kept, got %+v", q)
+	}
+
+	// Exemption certificates keep the jurisdiction but lose the certificate number
+	certificates, _ := f.exemptionRepo.GetByHolder(ctx, salestax.ExemptionHolderCustomer, f.customer.ID)
+	if len(certificates) != 1 || certificates[0].CertificateNumber != "" || certificates[0].Country != "IRL" {
+		t.Errorf("Expected the certificate number to be erased, got %+v", certificates)
+	}
+
+	// A later export shows the erasure
+	export, err := f.orchestrator.ExportCustomer(ctx, caller("org-child-1"), f.customer.ID)
+	if err != nil {
+		t.Fatalf("ExportCustomer failed: %v", err)
+	}
+	if len(export.AuditEntries) != 1 || export.AuditEntries[0].Action != AuditActionErasure {
+		t.Errorf("Expected the erasure in the export, got %+v", export.AuditEntries)
+	}
+}
+
+func TestEraseCustomer_KeepsState(t *testing.T) {
+	f := setupFixture(t)
+	ctx := context.Background()
+
+	cust, err := f.customers.GetOrCreateCustomer(ctx, customer.CreateCustomerInput{
+		OrganisationID: "org-child-1",
+		Reference:      "cust-ref-us",
+		CountryCode:    "USA",
+		State:          stringPtr("CA"),
+		PostalCode:     stringPtr("94105"),
+		City:           stringPtr("San Francisco"),
+	})
+	if err != nil {
+		t.Fatalf("GetOrCreateCustomer failed: %v", err)
+	}
+	err = f.quoteRepo.Create(ctx, &quote.Entity{
+		ID:              "quote-us",
+		OrganisationID:  "org-child-1",
+		CustomerID:      cust.ID,
+		CustomerAddress: cust.ToQuoteCustomer(),
+		Currency:        "USD",
+		ImpactTaxRate:   0.0725,
+		Status:          quote.StatusAccepted,
+		CreatedAt:       time.Now(),
+	})
+	if err != nil {
+		t.Fatalf("creating quote failed: %v", err)
+	}
+
+	if _, err := f.orchestrator.EraseCustomer(ctx, caller("org-child-1"), cust.ID); err != nil {
+		t.Fatalf("EraseCustomer failed: %v", err)
+	}
+
+	// The state is the tax jurisdiction, so it is kept with the country
+	erased, _ := f.customers.GetCustomer(ctx, cust.ID)
+	if erased.State == nil || *erased.State != "CA" || erased.PostalCode != nil || erased.City != nil {
+		t.Errorf("Expected only the state and country to be kept, got %+v", erased)
+	}
+	q, _ := f.quoteRepo.GetByID(ctx, "quote-us")
+	if q.CustomerAddress.State == nil || *q.CustomerAddress.State != "CA" || q.CustomerAddress.CountryCode != "USA" ||
+		q.CustomerAddress.PostalCode != nil || q.CustomerAddress.City != nil {
+		t.Errorf("Expected the quote to keep the customer's state, got %+v", q.CustomerAddress)
+	}
+}
+
+func TestCustomerOutsideCallerHierarchy(t *testing.T) {
+	f := setupFixture(t)
+	ctx := context.Background()
+
+	var domainErr *errors.DomainError
+	if _, err := f.orchestrator.ExportCustomer(ctx, caller("org-child-2"), f.customer.ID); !errors.IsDomainError(err, &domainErr) || domainErr.Code != errors.ErrCodeNotFound {
+		t.Errorf("Expected not found for export, got %v", err)
+	}
+	if _, err := f.orchestrator.EraseCustomer(ctx, caller("org-child-2"), f.customer.ID); !errors.IsDomainError(err, &domainErr) || domainErr.Code != errors.ErrCodeNotFound {
+		t.Errorf("Expected not found for erasure, got %v", err)
+	}
+
+	stored, _ := f.customers.GetCustomer(ctx, f.customer.ID)
+	if stored.ErasedAt != nil || stored.Reference != "cust-ref-1" {
+		t.Errorf("Expected the customer to be untouched, got %+v", stored)
+	}
+	if entries, _ := f.orchestrator.ListAuditEntries(ctx, caller("org-child-1"), f.customer.ID); len(entries) != 0 {
+		t.Errorf("Expected no audit entries, got %d", len(entries))
+	}
+}
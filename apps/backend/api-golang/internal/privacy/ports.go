@@ -0,0 +1,21 @@
+// Package privacy defines ports for data subject requests.
+package privacy
+
+import (
+	"context"
+
+	"api-golang/internal/organisation/organisation"
+)
+
+// AuditRepository defines the port for the data subject audit log (driven adapter)
+type AuditRepository interface {
+	Create(ctx context.Context, entry *AuditEntry) error
+	// ListByCustomer returns a customer's audit entries, oldest first
+	ListByCustomer(ctx context.Context, customerID string) ([]*AuditEntry, error)
+}
+
+// OrganisationAccess defines the port for checking that a caller may act for
+// an organisation (provided by the organisation domain)
+type OrganisationAccess interface {
+	ValidateOrganisation(ctx context.Context, callerOrgID, orgID string) (*organisation.Entity, error)
+}
@@ -0,0 +1,46 @@
+package privacy
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+const domainName = "privacy"
+
+// InMemoryAuditRepository implements AuditRepository with in-memory storage
+type InMemoryAuditRepository struct {
+	entries []*AuditEntry
+	mu      sync.RWMutex
+}
+
+// NewInMemoryAuditRepository creates a new audit repository
+func NewInMemoryAuditRepository() *InMemoryAuditRepository {
+	return &InMemoryAuditRepository{}
+}
+
+// Create appends an audit entry
+func (r *InMemoryAuditRepository) Create(_ context.Context, entry *AuditEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.entries = append(r.entries, entry)
+	return nil
+}
+
+// ListByCustomer retrieves a customer's audit entries, oldest first
+func (r *InMemoryAuditRepository) ListByCustomer(_ context.Context, customerID string) ([]*AuditEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entries := make([]*AuditEntry, 0)
+	for _, entry := range r.entries {
+		if entry.CustomerID == customerID {
+			entries = append(entries, entry)
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].CreatedAt.Before(entries[j].CreatedAt)
+	})
+	return entries, nil
+}
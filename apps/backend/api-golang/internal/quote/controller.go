@@ -3,7 +3,12 @@ package quote
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
+
+	"api-golang/internal/shared/auth"
+	"api-golang/internal/shared/errors"
 )
 
 // Controller handles HTTP requests for quotes
@@ -17,12 +22,20 @@ func NewController(orchestrator *Orchestrator) *Controller {
 }
 
 // HandleCreateQuote handles POST /api/quotes
+// The caller is the organisation of the API key or session token the request
+// was authenticated with.
 func (c *Controller) HandleCreateQuote(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		c.writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed")
 		return
 	}
 
+	caller, ok := auth.PrincipalFromContext(r.Context())
+	if !ok {
+		c.writeError(w, http.StatusUnauthorized, errors.ErrCodeUnauthorized, "API key or session token is required")
+		return
+	}
+
 	// Parse request body
 	var req CreateQuoteRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -33,23 +46,16 @@ func (c *Controller) HandleCreateQuote(w http.ResponseWriter, r *http.Request) {
 	// Debug: Check if there's an unexpected transactionId field
 	// This shouldn't be in the request, but if it is, we'll ignore it
 
-	// Get organisation ID from header (simulating auth)
-	headerOrgID := r.Header.Get("X-Organisation-ID")
-	if headerOrgID == "" {
-		// Default to the organisation ID in the body for demo purposes
-		headerOrgID = req.OrganisationID
-	}
-
 	// Set defaults
 	if req.Locale == "" {
 		req.Locale = "en-GB"
 	}
-
-	// Validate required fields
+	// Quote for the caller's own organisation unless a descendant is given
 	if req.OrganisationID == "" {
-		c.writeError(w, http.StatusBadRequest, "MISSING_FIELD", "organisationId is required")
-		return
+		req.OrganisationID = caller.OrganisationID
 	}
+
+	// Validate required fields
 	if req.Customer.Reference == "" {
 		c.writeError(w, http.StatusBadRequest, "MISSING_FIELD", "customer.reference is required")
 		return
@@ -68,8 +74,19 @@ func (c *Controller) HandleCreateQuote(w http.ResponseWriter, r *http.Request) {
 
 	// Create quote
 	ctx := r.Context()
-	response, err := c.orchestrator.CreateQuote(ctx, &req, headerOrgID)
+	response, err := c.orchestrator.CreateQuote(ctx, &req, caller)
 	if err != nil {
+		// Field-level validation errors point the client at the offending field
+		var domainErr *errors.DomainError
+		if errors.IsDomainError(err, &domainErr) && domainErr.Code == errors.ErrCodeValidation && domainErr.Field != "" {
+			c.writeFieldError(w, http.StatusBadRequest, "VALIDATION_ERROR", domainErr.Field, domainErr.Message)
+			return
+		}
+		if errors.IsDomainError(err, &domainErr) && domainErr.Code == errors.ErrCodeOrganisationInactive {
+			c.writeError(w, http.StatusForbidden, errors.ErrCodeOrganisationInactive, domainErr.Message)
+			return
+		}
+
 		// Check for specific error types
 		errStr := err.Error()
 		switch {
@@ -77,7 +94,7 @@ func (c *Controller) HandleCreateQuote(w http.ResponseWriter, r *http.Request) {
 			c.writeError(w, http.StatusForbidden, "FORBIDDEN", err.Error())
 		case strings.Contains(errStr, "not found"):
 			c.writeError(w, http.StatusNotFound, "NOT_FOUND", err.Error())
-		case strings.Contains(errStr, "validation"):
+		case strings.Contains(errStr, "validation") || strings.Contains(errStr, "VALIDATION_ERROR"):
 			c.writeError(w, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
 		default:
 			c.writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
@@ -104,8 +121,14 @@ func (c *Controller) HandleGetQuote(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	caller, ok := auth.PrincipalFromContext(r.Context())
+	if !ok {
+		c.writeError(w, http.StatusUnauthorized, errors.ErrCodeUnauthorized, "API key or session token is required")
+		return
+	}
+
 	ctx := r.Context()
-	quote, err := c.orchestrator.GetQuote(ctx, id)
+	quote, err := c.orchestrator.GetQuote(ctx, caller.OrganisationID, id)
 	if err != nil {
 		if strings.Contains(err.Error(), "not found") {
 			c.writeError(w, http.StatusNotFound, "NOT_FOUND", "Quote not found")
@@ -118,18 +141,139 @@ func (c *Controller) HandleGetQuote(w http.ResponseWriter, r *http.Request) {
 	c.writeJSON(w, http.StatusOK, quote)
 }
 
+// HandleListQuotes handles GET /api/quotes?status={status}&customerId={id}&currency={code}&product={product}&from={date}&to={date}&organisationId={id}&cursor={cursor}&limit={n}
+// status takes a comma-separated list. Dates are RFC 3339 timestamps or
+// YYYY-MM-DD days; a day in to includes the whole day.
+func (c *Controller) HandleListQuotes(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		c.writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed")
+		return
+	}
+
+	caller, ok := auth.PrincipalFromContext(r.Context())
+	if !ok {
+		c.writeError(w, http.StatusUnauthorized, errors.ErrCodeUnauthorized, "API key is required")
+		return
+	}
+
+	query := r.URL.Query()
+	req := &ListQuotesRequest{
+		OrganisationID: query.Get("organisationId"),
+		CustomerID:     query.Get("customerId"),
+		Currency:       query.Get("currency"),
+		Product:        query.Get("product"),
+		Cursor:         query.Get("cursor"),
+	}
+	if status := query.Get("status"); status != "" {
+		for _, s := range strings.Split(status, ",") {
+			req.Statuses = append(req.Statuses, Status(strings.TrimSpace(s)))
+		}
+	}
+	var err error
+	if req.From, err = parseDate(query.Get("from"), false); err != nil {
+		c.writeFieldError(w, http.StatusBadRequest, "VALIDATION_ERROR", "from", "from must be an RFC 3339 timestamp or a YYYY-MM-DD date")
+		return
+	}
+	if req.To, err = parseDate(query.Get("to"), true); err != nil {
+		c.writeFieldError(w, http.StatusBadRequest, "VALIDATION_ERROR", "to", "to must be an RFC 3339 timestamp or a YYYY-MM-DD date")
+		return
+	}
+	if limit := query.Get("limit"); limit != "" {
+		if req.Limit, err = strconv.Atoi(limit); err != nil || req.Limit < 1 {
+			c.writeFieldError(w, http.StatusBadRequest, "VALIDATION_ERROR", "limit", "limit must be a positive integer")
+			return
+		}
+	}
+
+	response, err := c.orchestrator.ListQuotes(r.Context(), caller, req)
+	if err != nil {
+		var domainErr *errors.DomainError
+		switch {
+		case errors.IsDomainError(err, &domainErr) && domainErr.Code == errors.ErrCodeValidation:
+			c.writeFieldError(w, http.StatusBadRequest, "VALIDATION_ERROR", domainErr.Field, domainErr.Message)
+		case errors.IsDomainError(err, &domainErr) && domainErr.Code == errors.ErrCodeForbidden:
+			c.writeError(w, http.StatusForbidden, "FORBIDDEN", domainErr.Message)
+		case errors.IsDomainError(err, &domainErr) && domainErr.Code == errors.ErrCodeNotFound:
+			c.writeError(w, http.StatusNotFound, "NOT_FOUND", domainErr.Message)
+		default:
+			c.writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		}
+		return
+	}
+
+	c.writeJSON(w, http.StatusOK, response)
+}
+
+// parseDate parses an optional RFC 3339 timestamp or YYYY-MM-DD day. As the
+// end of a range, a day is moved to the start of the next day so that it is
+// included.
+func parseDate(value string, endOfRange bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if day, err := time.Parse(time.DateOnly, value); err == nil {
+		if endOfRange {
+			return day.AddDate(0, 0, 1), nil
+		}
+		return day, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+// HandleAcceptQuote handles POST /api/quotes/{id}/accept
+func (c *Controller) HandleAcceptQuote(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		c.writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed")
+		return
+	}
+
+	id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/quotes/"), "/accept")
+	if id == "" || strings.Contains(id, "/") {
+		c.writeError(w, http.StatusBadRequest, "MISSING_FIELD", "Quote ID is required")
+		return
+	}
+
+	caller, ok := auth.PrincipalFromContext(r.Context())
+	if !ok {
+		c.writeError(w, http.StatusUnauthorized, errors.ErrCodeUnauthorized, "API key or session token is required")
+		return
+	}
+
+	quote, err := c.orchestrator.AcceptQuote(r.Context(), caller, id)
+	if err != nil {
+		var domainErr *errors.DomainError
+		switch {
+		case errors.IsDomainError(err, &domainErr) && domainErr.Code == errors.ErrCodeNotFound:
+			c.writeError(w, http.StatusNotFound, "NOT_FOUND", "Quote not found")
+		case errors.IsDomainError(err, &domainErr) && domainErr.Code == errors.ErrCodeValidation:
+			c.writeError(w, http.StatusBadRequest, "VALIDATION_ERROR", domainErr.Message)
+		default:
+			c.writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		}
+		return
+	}
+
+	c.writeJSON(w, http.StatusOK, quote)
+}
+
 // ErrorResponse represents an error response
 type ErrorResponse struct {
 	Error struct {
 		Code    string `json:"code"`
 		Message string `json:"message"`
+		Field   string `json:"field,omitempty"` // Request field that failed validation
 	} `json:"error"`
 }
 
 func (c *Controller) writeError(w http.ResponseWriter, status int, code, message string) {
+	c.writeFieldError(w, status, code, "", message)
+}
+
+func (c *Controller) writeFieldError(w http.ResponseWriter, status int, code, field, message string) {
 	resp := ErrorResponse{}
 	resp.Error.Code = code
 	resp.Error.Message = message
+	resp.Error.Field = field
 	c.writeJSON(w, status, resp)
 }
 
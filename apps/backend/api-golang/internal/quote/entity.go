@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"time"
 
+	"api-golang/internal/organisation/customer"
 	"api-golang/internal/shared/types"
 )
 
@@ -29,8 +30,9 @@ type Entity struct {
 	CalculationReference string `json:"calculationReference"` // Links to calculation
 
 	// Organisation and customer
-	OrganisationID string `json:"organisationId"`
-	CustomerID     string `json:"customerId"`
+	OrganisationID  string                 `json:"organisationId"`
+	CustomerID      string                 `json:"customerId"`
+	CustomerAddress customer.QuoteCustomer `json:"customerAddress"` // Customer address at quote time (stored as JSON blob)
 
 	// Currency
 	Currency string `json:"currency"` // ISO-3 currency code
@@ -55,7 +57,8 @@ type Entity struct {
 	ContributionDetails ContributionDetails `json:"contributionDetails"`
 
 	// Tax liability
-	IsMerchantTaxLiable bool `json:"isMerchantTaxLiable"` // YES/NO
+	IsMerchantTaxLiable       bool   `json:"isMerchantTaxLiable"`                 // YES/NO
+	TaxExemptionCertificateID string `json:"taxExemptionCertificateId,omitempty"` // Set when the quote was zero-rated by an exemption
 
 	// Filters and options (stored from request)
 	CustomerLocationFilter bool `json:"customerLocationFilter"`
@@ -78,6 +81,26 @@ type Entity struct {
 	UpdatedAt time.Time `json:"updatedAt"`
 }
 
+// WithoutPersonalData returns a copy of the quote with the customer's
+// reference, city, postal code and order item names removed. Amounts, tax
+// rates and the customer's country and state, the tax jurisdiction, are kept
+// as financial records.
+func (e *Entity) WithoutPersonalData() *Entity {
+	erased := *e
+	erased.CustomerAddress = customer.QuoteCustomer{
+		State:       e.CustomerAddress.State,
+		CountryCode: e.CustomerAddress.CountryCode,
+	}
+	if len(e.OrderItems) > 0 {
+		erased.OrderItems = make(OrderItems, len(e.OrderItems))
+		for i, item := range e.OrderItems {
+			item.Name, item.SKU = "", ""
+			erased.OrderItems[i] = item
+		}
+	}
+	return &erased
+}
+
 // ContributionDetails represents contribution breakdown (stored as JSON)
 type ContributionDetails struct {
 	ImpactPercentage             float64                     `json:"impactPercentage"`
@@ -206,12 +229,62 @@ type QuoteFiltersRequest struct {
 // CreateQuoteRequest represents the request body for creating a carbon quote
 type CreateQuoteRequest struct {
 	Locale                      string               `json:"locale,omitempty"`     // API only, not needed for sessions
-	OrganisationID              string               `json:"organisationId"`       // Required
+	OrganisationID              string               `json:"organisationId"`       // Defaults to the caller's organisation
 	Customer                    CustomerRequest      `json:"customer"`             // Required
 	Merchant                    *MerchantRequest     `json:"merchant,omitempty"`   // Optional if provided during org onboarding
 	OrderItems                  []OrderItemRequest   `json:"orderItems,omitempty"` // Optional order objects, stored in DW
 	IncludeImpactPartnerDetails bool                 `json:"includeImpactPartnerDetails,omitempty"`
-	Filters                     *QuoteFiltersRequest `json:"filters,omitempty"` // Advanced options
+	Filters                     *QuoteFiltersRequest `json:"filters,omitempty"`           // Advanced options
+	CalculationMethod           string               `json:"calculationMethod,omitempty"` // spend (default), flight, fuel or electricity
+	CalculationTypes            []string             `json:"calculationTypes,omitempty"`  // carbon, nature; defaults to those enabled for the organisation
+	Activity                    *ActivityRequest     `json:"activity,omitempty"`          // Activity data for activity-based methods
+}
+
+// ActivityRequest represents activity data for activity-based footprint methods
+type ActivityRequest struct {
+	Flight      *FlightActivityRequest      `json:"flight,omitempty"`
+	Fuel        *FuelActivityRequest        `json:"fuel,omitempty"`
+	Electricity *ElectricityActivityRequest `json:"electricity,omitempty"`
+}
+
+// FlightActivityRequest represents a flight booking
+type FlightActivityRequest struct {
+	Origin      string `json:"origin"`               // IATA airport code
+	Destination string `json:"destination"`          // IATA airport code
+	CabinClass  string `json:"cabinClass,omitempty"` // economy (default), premiumEconomy, business or first
+	Passengers  int    `json:"passengers,omitempty"` // Defaults to 1
+	Return      bool   `json:"return,omitempty"`
+}
+
+// FuelActivityRequest represents a fuel purchase
+type FuelActivityRequest struct {
+	FuelType string  `json:"fuelType"` // petrol, diesel, lpg, biodiesel, bioethanol or kerosene
+	Litres   float64 `json:"litres"`
+}
+
+// ElectricityActivityRequest represents electricity consumption
+type ElectricityActivityRequest struct {
+	KWh     float64 `json:"kWh"`
+	Country string  `json:"country,omitempty"` // ISO 3166-1 alpha-3, defaults to the merchant country
+}
+
+// ListQuotesRequest represents the query of a quote listing
+type ListQuotesRequest struct {
+	OrganisationID string    // Optional, defaults to the caller's organisation and its descendants
+	CustomerID     string    // Optional
+	Statuses       []Status  // Optional, any of these statuses
+	Currency       string    // Optional ISO-3 currency code
+	Product        string    // Optional Ekko product, e.g. API or checkout-sdk
+	From           time.Time // Optional inclusive creation date
+	To             time.Time // Optional exclusive creation date
+	Cursor         string    // nextCursor of the previous page
+	Limit          int       // Defaults to DefaultListLimit, at most MaxListLimit
+}
+
+// ListQuotesResponse represents a page of quotes, newest first
+type ListQuotesResponse struct {
+	Quotes     []*Entity `json:"quotes"`
+	NextCursor string    `json:"nextCursor,omitempty"` // Set when there are more quotes
 }
 
 // CreateQuoteResponse represents the response from creating a carbon quote
@@ -225,9 +298,32 @@ type CreateQuoteResponse struct {
 
 // FootprintResponse represents the carbon footprint in the quote response
 type FootprintResponse struct {
-	Co2eGrams   float64              `json:"co2eGrams"`
-	Co2eOunces  float64              `json:"co2eOunces"`
-	Equivalents []EquivalentResponse `json:"equivalents"`
+	CalculationMethod string                   `json:"calculationMethod"`
+	Co2eGrams         float64                  `json:"co2eGrams"`
+	Co2eOunces        float64                  `json:"co2eOunces"`
+	Co2eGramsLow      float64                  `json:"co2eGramsLow"`  // Low end of the uncertainty range
+	Co2eGramsHigh     float64                  `json:"co2eGramsHigh"` // High end of the uncertainty range
+	DataQuality       string                   `json:"dataQuality"`   // exact, mccOnly, default or activity
+	Equivalents       []EquivalentResponse     `json:"equivalents"`
+	Items             []FootprintItemResponse  `json:"items,omitempty"`  // Per order item breakdown
+	Nature            *NatureFootprintResponse `json:"nature,omitempty"` // Only for organisations with the nature calculation type
+}
+
+// NatureFootprintResponse represents the nature footprint in the quote response
+type NatureFootprintResponse struct {
+	TotalMsa       float64            `json:"totalMsa"`       // MSA.m²
+	PressurePoints map[string]float64 `json:"pressurePoints"` // MSA.m² by landUse, waterUse, pollution, climateStress
+}
+
+// FootprintItemResponse represents the carbon footprint of a single order item
+type FootprintItemResponse struct {
+	ItemID        string  `json:"itemId"`
+	Category      string  `json:"category,omitempty"`
+	Co2eGrams     float64 `json:"co2eGrams"`
+	Co2eOunces    float64 `json:"co2eOunces"`
+	Co2eGramsLow  float64 `json:"co2eGramsLow"`
+	Co2eGramsHigh float64 `json:"co2eGramsHigh"`
+	DataQuality   string  `json:"dataQuality"`
 }
 
 // EquivalentResponse represents a localised equivalent in the quote response
@@ -235,6 +331,7 @@ type EquivalentResponse struct {
 	Key      string  `json:"key"`
 	Value    float64 `json:"value"`
 	Template string  `json:"template"` // Language based on locale
+	Unit     string  `json:"unit,omitempty"`
 }
 
 // CreditsResponse represents the credits section in the quote response
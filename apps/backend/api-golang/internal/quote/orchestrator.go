@@ -2,18 +2,25 @@ package quote
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
 	"math"
+	"slices"
+	"strings"
 	"time"
 
 	"api-golang/internal/finance/currency"
 	"api-golang/internal/funds/salestax"
 	carbonfootprint "api-golang/internal/impact/carbon_footprint"
+	"api-golang/internal/impact/equivalent"
 	"api-golang/internal/impact/fee"
 	"api-golang/internal/impact_partner/impact_partner"
 	"api-golang/internal/organisation/customer"
 	"api-golang/internal/organisation/organisation"
 	"api-golang/internal/platform/country"
+	"api-golang/internal/platform/mcc"
+	"api-golang/internal/shared/auth"
+	"api-golang/internal/shared/errors"
 	"api-golang/internal/shared/types"
 
 	"github.com/google/uuid"
@@ -38,6 +45,36 @@ func convertOrderItems(items []OrderItemRequest) OrderItems {
 	return result
 }
 
+// convertActivity converts ActivityRequest to carbon footprint activity data
+func convertActivity(activity *ActivityRequest) *carbonfootprint.Activity {
+	if activity == nil {
+		return nil
+	}
+	result := &carbonfootprint.Activity{}
+	if activity.Flight != nil {
+		result.Flight = &carbonfootprint.FlightActivity{
+			Origin:      activity.Flight.Origin,
+			Destination: activity.Flight.Destination,
+			CabinClass:  activity.Flight.CabinClass,
+			Passengers:  activity.Flight.Passengers,
+			Return:      activity.Flight.Return,
+		}
+	}
+	if activity.Fuel != nil {
+		result.Fuel = &carbonfootprint.FuelActivity{
+			FuelType: activity.Fuel.FuelType,
+			Litres:   activity.Fuel.Litres,
+		}
+	}
+	if activity.Electricity != nil {
+		result.Electricity = &carbonfootprint.ElectricityActivity{
+			KWh:       activity.Electricity.KWh,
+			CountryID: activity.Electricity.Country,
+		}
+	}
+	return result
+}
+
 // Orchestrator coordinates the quote creation flow across multiple domains.
 // This implements the Vertical Slice Architecture pattern - handling the entire
 // request flow from validation through to quote creation.
@@ -48,13 +85,15 @@ type Orchestrator struct {
 
 	// Platform domain
 	countryService country.Service
+	mccService     mcc.Service
 
 	// Finance domain
 	currencyService currency.Service
 
 	// Impact domain
-	carbonService carbonfootprint.Service
-	feeService    fee.Service
+	carbonService     carbonfootprint.Service
+	equivalentService equivalent.Service
+	feeService        fee.Service
 
 	// Impact Partner domain
 	blendedPriceCalc     *impact_partner.BlendedPriceCalculator
@@ -72,8 +111,10 @@ type OrchestratorDeps struct {
 	OrganisationService  organisation.Service
 	CustomerService      customer.Service
 	CountryService       country.Service
+	MCCService           mcc.Service
 	CurrencyService      currency.Service
 	CarbonService        carbonfootprint.Service
+	EquivalentService    equivalent.Service
 	FeeService           fee.Service
 	BlendedPriceCalc     *impact_partner.BlendedPriceCalculator
 	ImpactPartnerService impact_partner.Service
@@ -87,8 +128,10 @@ func NewOrchestrator(deps OrchestratorDeps) *Orchestrator {
 		organisationService:  deps.OrganisationService,
 		customerService:      deps.CustomerService,
 		countryService:       deps.CountryService,
+		mccService:           deps.MCCService,
 		currencyService:      deps.CurrencyService,
 		carbonService:        deps.CarbonService,
+		equivalentService:    deps.EquivalentService,
 		feeService:           deps.FeeService,
 		blendedPriceCalc:     deps.BlendedPriceCalc,
 		impactPartnerService: deps.ImpactPartnerService,
@@ -107,15 +150,43 @@ func NewOrchestrator(deps OrchestratorDeps) *Orchestrator {
 // 7. Calculate Service Fee
 // 8. Calculate Sales Tax
 // 9. Write Quote
-func (o *Orchestrator) CreateQuote(ctx context.Context, req *CreateQuoteRequest, headerOrgID string) (*CreateQuoteResponse, error) {
+func (o *Orchestrator) CreateQuote(ctx context.Context, req *CreateQuoteRequest, caller *auth.Principal) (*CreateQuoteResponse, error) {
 	// ============================================
 	// Step 1: Validate Organisation
 	// ============================================
-	org, err := o.organisationService.ValidateOrganisation(ctx, headerOrgID, req.OrganisationID)
+	// Session tokens can only quote for their own organisation and customer
+	if err := validateSessionAccess(caller, req.OrganisationID, req.Customer.Reference); err != nil {
+		return nil, fmt.Errorf("step 1 - validate session: %w", err)
+	}
+
+	org, err := o.organisationService.ValidateOrganisation(ctx, caller.OrganisationID, req.OrganisationID)
 	if err != nil {
 		return nil, fmt.Errorf("step 1 - validate organisation: %w", err)
 	}
 
+	// 1.1: Reject inactive organisations, including those with an inactive ancestor
+	if err := o.organisationService.ValidateStatus(ctx, org); err != nil {
+		return nil, fmt.Errorf("step 1.1 - validate organisation status: %w", err)
+	}
+
+	// 1.2: Check the requested calculation types are enabled for the organisation
+	calculationTypes, err := resolveCalculationTypes(org, req.CalculationTypes)
+	if err != nil {
+		return nil, fmt.Errorf("step 1.2 - validate calculation types: %w", err)
+	}
+
+	// 1.3: Validate customer and merchant states and postal codes
+	if err := o.validateLocations(ctx, req, org); err != nil {
+		return nil, fmt.Errorf("step 1.3 - validate locations: %w", err)
+	}
+
+	// 1.4: Reject unknown merchant category codes rather than falling back to the default factor
+	if req.Merchant != nil && req.Merchant.MCC != "" {
+		if _, err := o.mccService.ValidateMCC(ctx, req.Merchant.MCC); err != nil {
+			return nil, fmt.Errorf("step 1.4 - validate merchant category code: %w", fieldError("merchant.mcc", err))
+		}
+	}
+
 	// ============================================
 	// Step 2: Get or Create Customer
 	// ============================================
@@ -127,6 +198,7 @@ func (o *Orchestrator) CreateQuote(ctx context.Context, req *CreateQuoteRequest,
 		State:          req.Customer.State,
 		PostalCode:     req.Customer.PostalCode,
 		City:           req.Customer.City,
+		UpdatePolicy:   customer.UpdatePolicyOnChange, // Returning customers may have moved
 	})
 	if err != nil {
 		return nil, fmt.Errorf("step 2 - get/create customer: %w", err)
@@ -184,7 +256,30 @@ func (o *Orchestrator) CreateQuote(ctx context.Context, req *CreateQuoteRequest,
 		amountEUR = transactionAmount
 	}
 
-	// 3.5: Calculate carbon footprint using MCC and country
+	// 3.5: Convert order item line totals to EUR for item-level calculation
+	carbonItems := make([]carbonfootprint.ItemInput, 0, len(req.OrderItems))
+	for _, item := range req.OrderItems {
+		lineAmount := item.UnitPrice.Value * float64(item.Quantity)
+		itemCurrency := item.UnitPrice.CurrencyCode
+		if itemCurrency == "" {
+			itemCurrency = transactionCurrency
+		}
+		if itemCurrency != "EUR" {
+			conversionResult, err := o.currencyService.ConvertToEUR(ctx, lineAmount, itemCurrency)
+			if err != nil {
+				return nil, fmt.Errorf("step 3.5 - convert order item currency: %w", err)
+			}
+			lineAmount = conversionResult.ConvertedAmount
+		}
+		carbonItems = append(carbonItems, carbonfootprint.ItemInput{
+			ItemID:    item.ItemID,
+			Name:      item.Name,
+			Category:  item.Category,
+			AmountEUR: lineAmount,
+		})
+	}
+
+	// 3.6: Calculate carbon footprint per item category, falling back to MCC and country
 	transactionID := uuid.New().String()
 	footprint, err := o.carbonService.Calculate(ctx, carbonfootprint.CalculateInput{
 		TransactionID:  transactionID,
@@ -193,9 +288,26 @@ func (o *Orchestrator) CreateQuote(ctx context.Context, req *CreateQuoteRequest,
 		AmountEUR:      amountEUR,
 		MCC:            merchantMCC,
 		CountryID:      merchantCountry.ID,
+		Items:          carbonItems,
+		Method:         carbonfootprint.MethodType(req.CalculationMethod),
+		Activity:       convertActivity(req.Activity),
+		IncludeNature:  slices.Contains(calculationTypes, organisation.CalculationTypeNature),
 	})
 	if err != nil {
-		return nil, fmt.Errorf("step 3.5 - calculate carbon footprint: %w", err)
+		return nil, fmt.Errorf("step 3.6 - calculate carbon footprint: %w", err)
+	}
+
+	// 3.7: Render localised equivalents and store them with the footprint
+	equivalents, err := o.equivalentService.Calculate(ctx, footprint.CarbonKg(), req.Locale)
+	if err != nil {
+		return nil, fmt.Errorf("step 3.7 - calculate equivalents: %w", err)
+	}
+	carbonEquivalents := make(carbonfootprint.Equivalents, len(equivalents))
+	for i, e := range equivalents {
+		carbonEquivalents[i] = carbonfootprint.Equivalent{Key: e.Key, Value: e.Value, Template: e.Template, Unit: e.Unit}
+	}
+	if err := o.carbonService.SetCarbonEquivalents(ctx, footprint.ID, carbonEquivalents); err != nil {
+		return nil, fmt.Errorf("step 3.7 - store equivalents: %w", err)
 	}
 
 	// ============================================
@@ -285,6 +397,10 @@ func (o *Orchestrator) CreateQuote(ctx context.Context, req *CreateQuoteRequest,
 		merchantState = *merchantAddress.State
 	}
 	merchantPostalCode = merchantAddress.PostalCode
+	var merchantTaxNumber string
+	if org.Billing != nil && org.Billing.TaxNumber != nil {
+		merchantTaxNumber = *org.Billing.TaxNumber
+	}
 
 	// Calculate tax on impact amount
 	impactTaxResult, err := o.salesTaxService.CalculateSalesTax(ctx, salestax.TaxCalculationInput{
@@ -294,6 +410,9 @@ func (o *Orchestrator) CreateQuote(ctx context.Context, req *CreateQuoteRequest,
 		CustomerCountry:    req.Customer.Country,
 		CustomerState:      customerState,
 		CustomerPostalCode: customerPostalCode,
+		MerchantTaxNumber:  merchantTaxNumber,
+		OrganisationID:     org.OrganisationID,
+		CustomerID:         cust.ID,
 		Amount:             impactAmount,
 	})
 	if err != nil {
@@ -301,6 +420,11 @@ func (o *Orchestrator) CreateQuote(ctx context.Context, req *CreateQuoteRequest,
 	}
 	impactSalesTaxAmount := impactTaxResult.TaxAmount
 	impactTaxRate := impactTaxResult.TaxRate
+	// Liability depends on the jurisdiction, the merchant's tax registration and
+	// any exemption, never on the amount, so the service fee result below always
+	// agrees and one flag is stored for the quote
+	isMerchantTaxLiable := impactTaxResult.IsMerchantTaxLiable
+	taxExemptionCertificateID := impactTaxResult.ExemptionCertificateID
 
 	// Calculate tax on service fee
 	serviceFeeTaxResult, err := o.salesTaxService.CalculateSalesTax(ctx, salestax.TaxCalculationInput{
@@ -310,6 +434,9 @@ func (o *Orchestrator) CreateQuote(ctx context.Context, req *CreateQuoteRequest,
 		CustomerCountry:    req.Customer.Country,
 		CustomerState:      customerState,
 		CustomerPostalCode: customerPostalCode,
+		MerchantTaxNumber:  merchantTaxNumber,
+		OrganisationID:     org.OrganisationID,
+		CustomerID:         cust.ID,
 		Amount:             serviceFeeAmount,
 	})
 	if err != nil {
@@ -423,6 +550,7 @@ func (o *Orchestrator) CreateQuote(ctx context.Context, req *CreateQuoteRequest,
 		CalculationReference: footprint.ID,
 		OrganisationID:       org.OrganisationID,
 		CustomerID:           cust.ID,
+		CustomerAddress:      cust.ToQuoteCustomer(),
 		Currency:             quoteCurrency,
 
 		CarbonCreditTotal:              totalAmount,
@@ -443,11 +571,14 @@ func (o *Orchestrator) CreateQuote(ctx context.Context, req *CreateQuoteRequest,
 			ImpactPartners:               make([]ContributionImpactPartner, len(contributionImpactPartners)),
 		},
 
+		IsMerchantTaxLiable:       isMerchantTaxLiable,
+		TaxExemptionCertificateID: taxExemptionCertificateID,
+
 		CustomerLocationFilter: filterByLocation,
 		IncludePartnerDetail:   req.IncludeImpactPartnerDetails,
 		IncludeProjectDetail:   false, // Project details never available in quote response
 
-		EkkoProduct:     "API", // Track through endpoint
+		EkkoProduct:     productOf(caller),
 		ServiceFeeShare: feeResult.FeePercentage,
 
 		OrderItems: convertOrderItems(req.OrderItems),
@@ -485,23 +616,52 @@ func (o *Orchestrator) CreateQuote(ctx context.Context, req *CreateQuoteRequest,
 	co2eGrams := footprint.CarbonCo2eGrams
 	co2eOunces := footprint.CarbonCo2eOunces
 
-	// Build equivalents (simplified for demo)
-	carbonKg := footprint.CarbonKg()
-	equivalents := []EquivalentResponse{
-		{
-			Key:      "tree",
-			Value:    carbonKg / 40.0, // Roughly 40kg CO2 per tree
-			Template: fmt.Sprintf("That's like planting %.1f trees", carbonKg/40.0),
-		},
+	equivalentResponses := make([]EquivalentResponse, len(carbonEquivalents))
+	for i, e := range carbonEquivalents {
+		equivalentResponses[i] = EquivalentResponse{Key: e.Key, Value: e.Value, Template: e.Template, Unit: e.Unit}
+	}
+
+	var itemFootprints []FootprintItemResponse
+	for _, item := range footprint.Items {
+		itemFootprints = append(itemFootprints, FootprintItemResponse{
+			ItemID:        item.ItemID,
+			Category:      item.Category,
+			Co2eGrams:     item.CarbonCo2eGrams,
+			Co2eOunces:    item.CarbonCo2eGrams / 1000.0 * 35.274,
+			Co2eGramsLow:  item.CarbonCo2eGramsLow,
+			Co2eGramsHigh: item.CarbonCo2eGramsHigh,
+			DataQuality:   string(item.DataQuality),
+		})
+	}
+
+	var nature *NatureFootprintResponse
+	if footprint.NatureTotalMsa != nil {
+		nature = &NatureFootprintResponse{
+			TotalMsa:       *footprint.NatureTotalMsa,
+			PressurePoints: map[string]float64{},
+		}
+		if footprint.PressurePoints != nil {
+			for pressure, value := range footprint.PressurePoints.Data {
+				if msa, ok := value.(float64); ok {
+					nature.PressurePoints[pressure] = msa
+				}
+			}
+		}
 	}
 
 	response := &CreateQuoteResponse{
 		ID:             quote.ID,
 		QuoteReference: quoteReference,
 		Footprint: FootprintResponse{
-			Co2eGrams:   co2eGrams,
-			Co2eOunces:  co2eOunces,
-			Equivalents: equivalents,
+			CalculationMethod: footprint.CalculationMethod,
+			Co2eGrams:         co2eGrams,
+			Co2eOunces:        co2eOunces,
+			Co2eGramsLow:      footprint.CarbonCo2eGramsLow,
+			Co2eGramsHigh:     footprint.CarbonCo2eGramsHigh,
+			DataQuality:       string(footprint.DataQuality),
+			Equivalents:       equivalentResponses,
+			Items:             itemFootprints,
+			Nature:            nature,
 		},
 		Credits: CreditsResponse{
 			TotalAmount:              totalAmount,
@@ -525,7 +685,297 @@ func (o *Orchestrator) CreateQuote(ctx context.Context, req *CreateQuoteRequest,
 	return response, nil
 }
 
-// GetQuote retrieves a quote by ID
-func (o *Orchestrator) GetQuote(ctx context.Context, id string) (*Entity, error) {
-	return o.quoteRepo.GetByID(ctx, id)
+// validateLocations checks the customer and merchant state values against the
+// subdivisions of their country, and replaces states with their local code and
+// postal codes with their canonical form so that customer records and tax
+// lookups see a single representation. A merchant address without a country
+// is in the organisation's country, as for the footprint and tax.
+func (o *Orchestrator) validateLocations(ctx context.Context, req *CreateQuoteRequest, org *organisation.Entity) error {
+	if req.Customer.State != nil && *req.Customer.State != "" {
+		sub, err := o.countryService.ValidateSubdivision(ctx, req.Customer.Country, *req.Customer.State)
+		if err != nil {
+			return fieldError("customer.state", err)
+		}
+		if sub != nil {
+			state := sub.LocalCode()
+			req.Customer.State = &state
+		}
+	}
+	if req.Customer.PostalCode != nil && *req.Customer.PostalCode != "" {
+		postalCode, err := o.countryService.NormalisePostalCode(ctx, req.Customer.Country, *req.Customer.PostalCode)
+		if err != nil {
+			return fieldError("customer.postalCode", err)
+		}
+		req.Customer.PostalCode = &postalCode
+	}
+
+	if req.Merchant == nil {
+		return nil
+	}
+	merchantCountryCode := req.Merchant.Address.Country
+	if merchantCountryCode == "" {
+		merchantCountryCode = org.Address.CountryCode
+	}
+	if req.Merchant.Address.State != nil && *req.Merchant.Address.State != "" {
+		sub, err := o.countryService.ValidateSubdivision(ctx, merchantCountryCode, *req.Merchant.Address.State)
+		if err != nil {
+			return fieldError("merchant.address.state", err)
+		}
+		if sub != nil {
+			state := sub.LocalCode()
+			req.Merchant.Address.State = &state
+		}
+	}
+	if req.Merchant.Address.PostalCode != "" {
+		postalCode, err := o.countryService.NormalisePostalCode(ctx, merchantCountryCode, req.Merchant.Address.PostalCode)
+		if err != nil {
+			return fieldError("merchant.address.postalCode", err)
+		}
+		req.Merchant.Address.PostalCode = postalCode
+	}
+	return nil
+}
+
+// resolveCalculationTypes returns the calculation types for a quote: those
+// requested, or all the organisation has enabled. Every quote includes carbon,
+// and each type must be enabled for the organisation.
+func resolveCalculationTypes(org *organisation.Entity, requested []string) ([]string, error) {
+	calculationTypes := requested
+	if len(calculationTypes) == 0 {
+		calculationTypes = org.CalculationTypes
+	}
+	if !slices.Contains(calculationTypes, organisation.CalculationTypeCarbon) {
+		calculationTypes = append([]string{organisation.CalculationTypeCarbon}, calculationTypes...)
+	}
+
+	for _, t := range calculationTypes {
+		if !org.HasCalculationType(t) {
+			return nil, errors.NewFieldValidationError(domainName, "calculationTypes",
+				fmt.Sprintf("calculation type %q is not enabled for organisation %s", t, org.OrganisationID))
+		}
+	}
+	return calculationTypes, nil
+}
+
+// fieldError attaches the request field to a validation error from another domain
+func fieldError(field string, err error) error {
+	var domainErr *errors.DomainError
+	if errors.IsDomainError(err, &domainErr) && domainErr.Code == errors.ErrCodeValidation {
+		return errors.NewFieldValidationError(domainName, field, domainErr.Message)
+	}
+	return fmt.Errorf("%s: %w", field, err)
+}
+
+// GetQuote retrieves a quote belonging to the caller's organisation or one of its descendants
+func (o *Orchestrator) GetQuote(ctx context.Context, callerOrgID, id string) (*Entity, error) {
+	quote, err := o.quoteRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Quotes outside the caller's hierarchy are reported as not found
+	if _, err := o.organisationService.ValidateOrganisation(ctx, callerOrgID, quote.OrganisationID); err != nil {
+		var domainErr *errors.DomainError
+		if errors.IsDomainError(err, &domainErr) && domainErr.Code == errors.ErrCodeForbidden {
+			return nil, errors.NewNotFoundError(domainName, "quote not found")
+		}
+		return nil, err
+	}
+	return quote, nil
+}
+
+// Quote listing page sizes
+const (
+	DefaultListLimit = 50
+	MaxListLimit     = 100
+)
+
+// ListQuotes lists quotes of the caller's organisation and its descendants,
+// newest first, one page at a time. OrganisationID narrows the listing to a
+// single organisation the caller may act for.
+func (o *Orchestrator) ListQuotes(ctx context.Context, caller *auth.Principal, req *ListQuotesRequest) (*ListQuotesResponse, error) {
+	filter := ListFilter{
+		CustomerID: req.CustomerID,
+		Statuses:   req.Statuses,
+		Currency:   strings.ToUpper(req.Currency),
+		Product:    req.Product,
+		From:       req.From,
+		To:         req.To,
+		Limit:      req.Limit,
+	}
+
+	switch {
+	case filter.Limit == 0:
+		filter.Limit = DefaultListLimit
+	case filter.Limit < 0 || filter.Limit > MaxListLimit:
+		return nil, errors.NewFieldValidationError(domainName, "limit", fmt.Sprintf("limit must be between 1 and %d", MaxListLimit))
+	}
+	for _, status := range filter.Statuses {
+		if !slices.Contains(statuses, status) {
+			return nil, errors.NewFieldValidationError(domainName, "status", fmt.Sprintf("unknown status %q", status))
+		}
+	}
+	if filter.Product != "" && filter.Product != auth.ProductAPI && !slices.Contains(auth.SessionProducts, filter.Product) {
+		return nil, errors.NewFieldValidationError(domainName, "product", fmt.Sprintf("unknown product %q", filter.Product))
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		return nil, errors.NewFieldValidationError(domainName, "to", "to must be after from")
+	}
+	if req.Cursor != "" {
+		cursor, err := decodeCursor(req.Cursor)
+		if err != nil {
+			return nil, errors.NewFieldValidationError(domainName, "cursor", "invalid cursor")
+		}
+		filter.After = cursor
+	}
+
+	if req.OrganisationID != "" {
+		if _, err := o.organisationService.ValidateOrganisation(ctx, caller.OrganisationID, req.OrganisationID); err != nil {
+			return nil, err
+		}
+		filter.OrganisationIDs = []string{req.OrganisationID}
+	} else {
+		ids, err := o.organisationService.ListAccessibleOrganisationIDs(ctx, caller.OrganisationID)
+		if err != nil {
+			return nil, fmt.Errorf("listing accessible organisations: %w", err)
+		}
+		filter.OrganisationIDs = ids
+	}
+
+	// Ask for one more quote than the page holds to know whether there is a next page
+	limit := filter.Limit
+	filter.Limit++
+	quotes, err := o.quoteRepo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("listing quotes: %w", err)
+	}
+
+	response := &ListQuotesResponse{Quotes: quotes}
+	if len(quotes) > limit {
+		response.Quotes = quotes[:limit]
+		last := response.Quotes[limit-1]
+		response.NextCursor = encodeCursor(&ListCursor{CreatedAt: last.CreatedAt, ID: last.ID})
+	}
+	return response, nil
+}
+
+// statuses lists every quote status
+var statuses = []Status{StatusPending, StatusAccepted, StatusRejected, StatusExpired, StatusCompleted}
+
+// encodeCursor returns an opaque page cursor
+func encodeCursor(cursor *ListCursor) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(cursor.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + cursor.ID))
+}
+
+// decodeCursor parses a cursor returned by encodeCursor
+func decodeCursor(value string) (*ListCursor, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	createdAt, id, ok := strings.Cut(string(decoded), "|")
+	if !ok || id == "" {
+		return nil, fmt.Errorf("malformed cursor")
+	}
+	t, err := time.Parse(time.RFC3339Nano, createdAt)
+	if err != nil {
+		return nil, err
+	}
+	return &ListCursor{CreatedAt: t, ID: id}, nil
+}
+
+// AcceptQuote marks a pending quote as accepted. Sessions can only accept
+// quotes for their own organisation and customer; other quotes are reported
+// as not found.
+func (o *Orchestrator) AcceptQuote(ctx context.Context, caller *auth.Principal, id string) (*Entity, error) {
+	quote, err := o.GetQuote(ctx, caller.OrganisationID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if caller.IsSession() {
+		cust, err := o.customerService.GetCustomer(ctx, quote.CustomerID)
+		if err != nil {
+			return nil, fmt.Errorf("getting quote customer: %w", err)
+		}
+		if validateSessionAccess(caller, quote.OrganisationID, cust.Reference) != nil {
+			return nil, errors.NewNotFoundError(domainName, "quote not found")
+		}
+	}
+
+	// The organisation, or one of its ancestors, may have been deactivated
+	// since the quote or the session token was issued
+	org, err := o.organisationService.GetOrganisation(ctx, quote.OrganisationID)
+	if err != nil {
+		return nil, fmt.Errorf("getting quote organisation: %w", err)
+	}
+	if err := o.organisationService.ValidateStatus(ctx, org); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	switch {
+	case quote.Status != StatusPending:
+		return nil, errors.NewValidationError(domainName, fmt.Sprintf("quote is %s, only pending quotes can be accepted", quote.Status))
+	case !now.Before(quote.ExpiresAt):
+		return nil, errors.NewValidationError(domainName, "quote has expired")
+	}
+
+	accepted := *quote
+	accepted.Status = StatusAccepted
+	accepted.UpdatedAt = now
+	if err := o.quoteRepo.Update(ctx, &accepted); err != nil {
+		return nil, fmt.Errorf("accepting quote: %w", err)
+	}
+	return &accepted, nil
+}
+
+// ListByCustomer retrieves a customer's quotes, oldest first. Callers check
+// that the customer belongs to an organisation they may act for.
+func (o *Orchestrator) ListByCustomer(ctx context.Context, filter CustomerFilter) ([]*Entity, error) {
+	quotes, err := o.quoteRepo.ListByCustomer(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("listing quotes: %w", err)
+	}
+	return quotes, nil
+}
+
+// ErasePersonalData removes a customer's personal data from their quotes and
+// returns how many quotes it erased. See Entity.WithoutPersonalData for what
+// is kept.
+func (o *Orchestrator) ErasePersonalData(ctx context.Context, customerID string) (int, error) {
+	quotes, err := o.quoteRepo.ListByCustomer(ctx, CustomerFilter{CustomerID: customerID})
+	if err != nil {
+		return 0, fmt.Errorf("listing quotes: %w", err)
+	}
+
+	for _, quote := range quotes {
+		if err := o.quoteRepo.Update(ctx, quote.WithoutPersonalData()); err != nil {
+			return 0, fmt.Errorf("updating quote %s: %w", quote.ID, err)
+		}
+	}
+	return len(quotes), nil
+}
+
+// validateSessionAccess checks that a session token is used for the
+// organisation and customer it was issued for. API keys are not restricted.
+func validateSessionAccess(caller *auth.Principal, organisationID, customerReference string) error {
+	if !caller.IsSession() {
+		return nil
+	}
+	if organisationID != caller.OrganisationID {
+		return errors.NewForbiddenError(domainName, fmt.Sprintf("session is restricted to organisation %s", caller.OrganisationID))
+	}
+	if customerReference != caller.CustomerReference {
+		return errors.NewForbiddenError(domainName, "session is restricted to another customer")
+	}
+	return nil
+}
+
+// productOf returns the Ekko product a request came from
+func productOf(caller *auth.Principal) string {
+	if caller.Product == "" {
+		return auth.ProductAPI
+	}
+	return caller.Product
 }
@@ -2,39 +2,53 @@ package quote
 
 import (
 	"context"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
+	salestaxHolder "api-golang/internal/adapters/salestax/holder"
 	"api-golang/internal/finance/currency"
 	"api-golang/internal/funds/salestax"
 	carbonfootprint "api-golang/internal/impact/carbon_footprint"
+	"api-golang/internal/impact/equivalent"
 	"api-golang/internal/impact/fee"
 	"api-golang/internal/impact_partner/impact_partner"
 	"api-golang/internal/organisation/customer"
 	"api-golang/internal/organisation/organisation"
+	"api-golang/internal/platform/airport"
 	"api-golang/internal/platform/country"
+	"api-golang/internal/platform/mcc"
+	"api-golang/internal/shared/auth"
+	"api-golang/internal/shared/errors"
+	"api-golang/internal/shared/types"
 )
 
 // setupOrchestrator creates an orchestrator with all dependencies for testing
 func setupOrchestrator() *Orchestrator {
-	// Organisation domain
-	orgRepo := organisation.NewInMemoryRepository()
-	orgService := organisation.NewService(orgRepo)
-
-	customerRepo := customer.NewInMemoryRepository()
-	customerService := customer.NewService(customerRepo)
-
 	// Platform domain
 	countryRepo := country.NewInMemoryRepository()
 	countryService := country.NewService(countryRepo)
+	mccService := mcc.NewService(mcc.NewInMemoryRepository())
 
 	// Finance domain
 	currencyRepo := currency.NewInMemoryRepository()
 	currencyService := currency.NewService(currencyRepo)
 
+	// Organisation domain
+	orgRepo := organisation.NewInMemoryRepository()
+	orgService := organisation.NewService(orgRepo, countryService, currencyService, mccService)
+
+	customerRepo := customer.NewInMemoryRepository()
+	customerService := customer.NewService(customerRepo, countryService)
+
 	// Impact domain
 	carbonFactorRepo := carbonfootprint.NewInMemoryFactorRepository()
+	carbonCategoryRepo := carbonfootprint.NewInMemoryCategoryRepository()
+	natureFactorRepo := carbonfootprint.NewInMemoryNatureFactorRepository()
 	carbonFootprintRepo := carbonfootprint.NewInMemoryFootprintRepository()
-	carbonService := carbonfootprint.NewService(carbonFactorRepo, carbonFootprintRepo)
+	carbonService := carbonfootprint.NewService(carbonFactorRepo, carbonCategoryRepo, natureFactorRepo, carbonFootprintRepo, airport.NewService(airport.NewInMemoryRepository()))
+	equivalentService := equivalent.NewService(equivalent.NewInMemoryRepository())
 
 	feeRepo := fee.NewInMemoryRepository()
 	feeService := fee.NewService(feeRepo)
@@ -46,7 +60,9 @@ func setupOrchestrator() *Orchestrator {
 
 	// Funds domain
 	salesTaxRepo := salestax.NewInMemoryRepository()
-	salesTaxService := salestax.NewService(salesTaxRepo)
+	salesTaxExemptionRepo := salestax.NewInMemoryExemptionRepository()
+	salesTaxService := salestax.NewService(salesTaxRepo, salesTaxExemptionRepo, countryService,
+		salestaxHolder.NewAccessAdapter(orgService, customerService))
 
 	// Quote domain
 	quoteRepo := NewInMemoryRepository()
@@ -55,8 +71,10 @@ func setupOrchestrator() *Orchestrator {
 		OrganisationService:  orgService,
 		CustomerService:      customerService,
 		CountryService:       countryService,
+		MCCService:           mccService,
 		CurrencyService:      currencyService,
 		CarbonService:        carbonService,
+		EquivalentService:    equivalentService,
 		FeeService:           feeService,
 		BlendedPriceCalc:     blendedPriceCalc,
 		ImpactPartnerService: partnerService,
@@ -65,6 +83,27 @@ func setupOrchestrator() *Orchestrator {
 	})
 }
 
+// apiKeyCaller returns a caller authenticated with an API key for the organisation
+func apiKeyCaller(organisationID string) *auth.Principal {
+	return &auth.Principal{
+		OrganisationID: organisationID,
+		CredentialType: auth.CredentialAPIKey,
+		Scopes:         auth.AllScopes,
+		Product:        auth.ProductAPI,
+	}
+}
+
+// sessionCaller returns a caller authenticated with a checkout SDK session token
+func sessionCaller(organisationID, customerReference string) *auth.Principal {
+	return &auth.Principal{
+		OrganisationID:    organisationID,
+		CredentialType:    auth.CredentialSession,
+		Scopes:            []string{auth.ScopeQuotesCreate, auth.ScopeQuotesAccept},
+		CustomerReference: customerReference,
+		Product:           auth.ProductCheckoutSDK,
+	}
+}
+
 func TestCreateQuote_Success(t *testing.T) {
 	orchestrator := setupOrchestrator()
 	ctx := context.Background()
@@ -90,7 +129,7 @@ func TestCreateQuote_Success(t *testing.T) {
 		},
 	}
 
-	response, err := orchestrator.CreateQuote(ctx, req, "org-parent-1")
+	response, err := orchestrator.CreateQuote(ctx, req, apiKeyCaller("org-parent-1"))
 	if err != nil {
 		t.Fatalf("CreateQuote failed: %v", err)
 	}
@@ -166,7 +205,7 @@ func TestCreateQuote_WithChildOrganisation(t *testing.T) {
 		},
 	}
 
-	response, err := orchestrator.CreateQuote(ctx, req, "org-parent-1")
+	response, err := orchestrator.CreateQuote(ctx, req, apiKeyCaller("org-parent-1"))
 	if err != nil {
 		t.Fatalf("CreateQuote for child org failed: %v", err)
 	}
@@ -204,7 +243,7 @@ func TestCreateQuote_UnauthorisedOrganisation(t *testing.T) {
 		},
 	}
 
-	_, err := orchestrator.CreateQuote(ctx, req, "org-child-1")
+	_, err := orchestrator.CreateQuote(ctx, req, apiKeyCaller("org-child-1"))
 	if err == nil {
 		t.Fatal("Expected error for unauthorised organisation access")
 	}
@@ -238,7 +277,7 @@ func TestCreateQuote_WithCurrencyConversion(t *testing.T) {
 		},
 	}
 
-	response, err := orchestrator.CreateQuote(ctx, req, "org-parent-1")
+	response, err := orchestrator.CreateQuote(ctx, req, apiKeyCaller("org-parent-1"))
 	if err != nil {
 		t.Fatalf("CreateQuote with USD failed: %v", err)
 	}
@@ -252,6 +291,354 @@ func TestCreateQuote_WithCurrencyConversion(t *testing.T) {
 		100.00, response.Credits.TotalAmount)
 }
 
+func TestCreateQuote_InvalidCustomerState(t *testing.T) {
+	orchestrator := setupOrchestrator()
+	ctx := context.Background()
+
+	req := &CreateQuoteRequest{
+		Locale:         "en-US",
+		OrganisationID: "org-parent-1",
+		Customer: CustomerRequest{
+			Reference: "cust-ref-invalid-state",
+			Country:   "USA",
+			State:     stringPtr("ZZ"),
+		},
+	}
+
+	_, err := orchestrator.CreateQuote(ctx, req, apiKeyCaller("org-parent-1"))
+	if err == nil {
+		t.Fatal("Expected validation error for unknown customer state")
+	}
+
+	t.Logf("Correctly rejected invalid state: %v", err)
+}
+
+func TestCreateQuote_InvalidCustomerPostalCode(t *testing.T) {
+	orchestrator := setupOrchestrator()
+	ctx := context.Background()
+
+	req := &CreateQuoteRequest{
+		Locale:         "en-GB",
+		OrganisationID: "org-parent-1",
+		Customer: CustomerRequest{
+			Reference:  "cust-ref-invalid-postcode",
+			Country:    "GBR",
+			PostalCode: stringPtr("NOT A POSTCODE"),
+		},
+	}
+
+	_, err := orchestrator.CreateQuote(ctx, req, apiKeyCaller("org-parent-1"))
+	var domainErr *errors.DomainError
+	if !errors.IsDomainError(err, &domainErr) {
+		t.Fatalf("Expected domain validation error, got %v", err)
+	}
+	if domainErr.Field != "customer.postalCode" {
+		t.Errorf("Expected field customer.postalCode, got %q", domainErr.Field)
+	}
+}
+
+func TestCreateQuote_UnknownMerchantMCC(t *testing.T) {
+	orchestrator := setupOrchestrator()
+	ctx := context.Background()
+
+	req := &CreateQuoteRequest{
+		Locale:         "en-GB",
+		OrganisationID: "org-parent-1",
+		Customer: CustomerRequest{
+			Reference: "cust-ref-unknown-mcc",
+			Country:   "GBR",
+		},
+		Merchant: &MerchantRequest{
+			MCC:     "5141", // Typo for 5411
+			Name:    "Corner Shop",
+			Address: MerchantAddressRequest{Country: "GBR"},
+		},
+	}
+
+	_, err := orchestrator.CreateQuote(ctx, req, apiKeyCaller("org-parent-1"))
+	var domainErr *errors.DomainError
+	if !errors.IsDomainError(err, &domainErr) || domainErr.Code != errors.ErrCodeValidation {
+		t.Fatalf("Expected domain validation error, got %v", err)
+	}
+	if domainErr.Field != "merchant.mcc" {
+		t.Errorf("Expected field merchant.mcc, got %q", domainErr.Field)
+	}
+}
+
+func TestCreateQuote_NormalisesCustomerPostalCode(t *testing.T) {
+	orchestrator := setupOrchestrator()
+	ctx := context.Background()
+
+	req := &CreateQuoteRequest{
+		Locale:         "en-GB",
+		OrganisationID: "org-parent-1",
+		Customer: CustomerRequest{
+			Reference:  "cust-ref-postcode",
+			Country:    "GBR",
+			PostalCode: stringPtr("ec1a1bb"),
+		},
+	}
+
+	if _, err := orchestrator.CreateQuote(ctx, req, apiKeyCaller("org-parent-1")); err != nil {
+		t.Fatalf("CreateQuote failed: %v", err)
+	}
+	if *req.Customer.PostalCode != "EC1A 1BB" {
+		t.Errorf("Expected normalised postal code EC1A 1BB, got %q", *req.Customer.PostalCode)
+	}
+}
+
+func TestCreateQuote_NormalisesCustomerState(t *testing.T) {
+	orchestrator := setupOrchestrator()
+	ctx := context.Background()
+
+	for _, state := range []string{"CA", "California", "US-CA"} {
+		t.Run(state, func(t *testing.T) {
+			req := &CreateQuoteRequest{
+				Locale:         "en-US",
+				OrganisationID: "org-parent-1",
+				Customer: CustomerRequest{
+					Reference: "cust-ref-state-" + state,
+					Country:   "USA",
+					State:     stringPtr(state),
+				},
+			}
+
+			response, err := orchestrator.CreateQuote(ctx, req, apiKeyCaller("org-parent-1"))
+			if err != nil {
+				t.Fatalf("CreateQuote failed: %v", err)
+			}
+			if *req.Customer.State != "CA" {
+				t.Errorf("Expected normalised state CA, got %q", *req.Customer.State)
+			}
+
+			// The normalised state finds the California rate rather than the country default
+			quote, err := orchestrator.GetQuote(ctx, "org-parent-1", response.ID)
+			if err != nil {
+				t.Fatalf("GetQuote failed: %v", err)
+			}
+			if quote.ImpactTaxRate != 0.0725 {
+				t.Errorf("Expected California tax rate 0.0725, got %v", quote.ImpactTaxRate)
+			}
+		})
+	}
+}
+
+func TestCreateQuote_FlightActivity(t *testing.T) {
+	orchestrator := setupOrchestrator()
+	ctx := context.Background()
+
+	req := &CreateQuoteRequest{
+		Locale:         "en-GB",
+		OrganisationID: "org-parent-1",
+		Customer: CustomerRequest{
+			Reference: "cust-ref-flight",
+			Country:   "GBR",
+		},
+		CalculationMethod: "flight",
+		Activity: &ActivityRequest{
+			Flight: &FlightActivityRequest{Origin: "LHR", Destination: "EDI", Return: true},
+		},
+	}
+
+	resp, err := orchestrator.CreateQuote(ctx, req, apiKeyCaller("org-parent-1"))
+	if err != nil {
+		t.Fatalf("CreateQuote failed: %v", err)
+	}
+	if resp.Footprint.CalculationMethod != "Flight distance-based calculation" {
+		t.Errorf("Expected flight calculation method, got %q", resp.Footprint.CalculationMethod)
+	}
+	if resp.Footprint.Co2eGrams <= 0 {
+		t.Error("Expected a positive flight footprint")
+	}
+}
+
+func TestCreateQuote_InvalidCalculationMethod(t *testing.T) {
+	orchestrator := setupOrchestrator()
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		method   string
+		activity *ActivityRequest
+	}{
+		{name: "Unsupported method", method: "teleport"},
+		{
+			name:     "Spend method with activity data",
+			method:   "spend",
+			activity: &ActivityRequest{Flight: &FlightActivityRequest{Origin: "LHR", Destination: "EDI"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &CreateQuoteRequest{
+				Locale:         "en-GB",
+				OrganisationID: "org-parent-1",
+				Customer: CustomerRequest{
+					Reference: "cust-ref-method",
+					Country:   "GBR",
+				},
+				CalculationMethod: tt.method,
+				Activity:          tt.activity,
+			}
+
+			_, err := orchestrator.CreateQuote(ctx, req, apiKeyCaller("org-parent-1"))
+			var domainErr *errors.DomainError
+			if !errors.IsDomainError(err, &domainErr) || domainErr.Code != errors.ErrCodeValidation {
+				t.Fatalf("Expected domain validation error, got %v", err)
+			}
+			if domainErr.Field != "calculationMethod" {
+				t.Errorf("Expected field calculationMethod, got %q", domainErr.Field)
+			}
+		})
+	}
+}
+
+func TestCreateQuote_NatureFootprintByCalculationType(t *testing.T) {
+	orchestrator := setupOrchestrator()
+	ctx := context.Background()
+
+	tests := []struct {
+		organisationID string
+		wantNature     bool
+	}{
+		{organisationID: "org-parent-1", wantNature: true}, // carbon and nature
+		{organisationID: "org-child-1", wantNature: false}, // carbon only
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.organisationID, func(t *testing.T) {
+			req := &CreateQuoteRequest{
+				Locale:         "en-GB",
+				OrganisationID: tt.organisationID,
+				Customer: CustomerRequest{
+					Reference: "cust-ref-nature-" + tt.organisationID,
+					Country:   "GBR",
+				},
+			}
+
+			resp, err := orchestrator.CreateQuote(ctx, req, apiKeyCaller("org-parent-1"))
+			if err != nil {
+				t.Fatalf("CreateQuote failed: %v", err)
+			}
+			if got := resp.Footprint.Nature != nil; got != tt.wantNature {
+				t.Fatalf("Expected nature footprint %v, got %v", tt.wantNature, got)
+			}
+			if tt.wantNature && len(resp.Footprint.Nature.PressurePoints) != 4 {
+				t.Errorf("Expected 4 pressure points, got %v", resp.Footprint.Nature.PressurePoints)
+			}
+		})
+	}
+}
+
+func TestCreateQuote_InactiveOrganisation(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name         string
+		deactivateID string
+		organisation string
+		wantMessage  string
+	}{
+		{name: "Inactive organisation", deactivateID: "org-child-1", organisation: "org-child-1", wantMessage: "organisation org-child-1 is inactive: Suspended pending review"},
+		{name: "Inactive ancestor", deactivateID: "org-parent-1", organisation: "org-child-1", wantMessage: "parent organisation org-parent-1 is inactive: Suspended pending review"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orchestrator := setupOrchestrator()
+			message := "Suspended pending review"
+			// Top-level organisations can't be deactivated through the service
+			orgService := orchestrator.organisationService.(*organisation.DefaultService)
+			org, err := orgService.GetOrganisation(ctx, tt.deactivateID)
+			if err != nil {
+				t.Fatalf("GetOrganisation failed: %v", err)
+			}
+			org.Status = types.OrganisationStatus{Value: organisation.StatusInactive, Message: &message}
+			if err := orgService.Repo.Update(ctx, org); err != nil {
+				t.Fatalf("Deactivating organisation failed: %v", err)
+			}
+
+			req := &CreateQuoteRequest{
+				Locale:         "en-GB",
+				OrganisationID: tt.organisation,
+				Customer: CustomerRequest{
+					Reference: "cust-ref-inactive",
+					Country:   "GBR",
+				},
+			}
+
+			_, err = orchestrator.CreateQuote(ctx, req, apiKeyCaller(tt.organisation))
+			var domainErr *errors.DomainError
+			if !errors.IsDomainError(err, &domainErr) || domainErr.Code != errors.ErrCodeOrganisationInactive {
+				t.Fatalf("Expected organisation inactive error, got %v", err)
+			}
+			if domainErr.Message != tt.wantMessage {
+				t.Errorf("Expected message %q, got %q", tt.wantMessage, domainErr.Message)
+			}
+		})
+	}
+}
+
+func TestCreateQuote_CalculationTypeNotEnabled(t *testing.T) {
+	orchestrator := setupOrchestrator()
+	ctx := context.Background()
+
+	req := &CreateQuoteRequest{
+		Locale:         "en-GB",
+		OrganisationID: "org-child-1", // carbon only
+		Customer: CustomerRequest{
+			Reference: "cust-ref-calculation-types",
+			Country:   "GBR",
+		},
+		CalculationTypes: []string{"carbon", "nature"},
+	}
+
+	_, err := orchestrator.CreateQuote(ctx, req, apiKeyCaller("org-parent-1"))
+	var domainErr *errors.DomainError
+	if !errors.IsDomainError(err, &domainErr) || domainErr.Field != "calculationTypes" {
+		t.Fatalf("Expected calculationTypes validation error, got %v", err)
+	}
+
+	// The parent has both types enabled, but only asked for carbon
+	req.OrganisationID = "org-parent-1"
+	req.CalculationTypes = []string{"carbon"}
+	resp, err := orchestrator.CreateQuote(ctx, req, apiKeyCaller("org-parent-1"))
+	if err != nil {
+		t.Fatalf("CreateQuote failed: %v", err)
+	}
+	if resp.Footprint.Nature != nil {
+		t.Error("Expected no nature footprint when only carbon is requested")
+	}
+}
+
+func TestCreateQuote_LocalisedEquivalents(t *testing.T) {
+	orchestrator := setupOrchestrator()
+	ctx := context.Background()
+
+	req := &CreateQuoteRequest{
+		Locale:         "de-DE",
+		OrganisationID: "org-parent-1",
+		Customer: CustomerRequest{
+			Reference: "cust-ref-equivalents",
+			Country:   "DEU",
+		},
+	}
+
+	resp, err := orchestrator.CreateQuote(ctx, req, apiKeyCaller("org-parent-1"))
+	if err != nil {
+		t.Fatalf("CreateQuote failed: %v", err)
+	}
+	if len(resp.Footprint.Equivalents) == 0 {
+		t.Fatal("Expected equivalents")
+	}
+	for _, e := range resp.Footprint.Equivalents {
+		if !strings.HasPrefix(e.Template, "Das entspricht") {
+			t.Errorf("Expected German template for %s, got %q", e.Key, e.Template)
+		}
+	}
+}
+
 func TestCreateQuote_WithMerchantDetails(t *testing.T) {
 	orchestrator := setupOrchestrator()
 	ctx := context.Background()
@@ -287,7 +674,7 @@ func TestCreateQuote_WithMerchantDetails(t *testing.T) {
 		},
 	}
 
-	response, err := orchestrator.CreateQuote(ctx, req, "org-parent-1")
+	response, err := orchestrator.CreateQuote(ctx, req, apiKeyCaller("org-parent-1"))
 	if err != nil {
 		t.Fatalf("CreateQuote with merchant details failed: %v", err)
 	}
@@ -304,6 +691,37 @@ func TestCreateQuote_WithMerchantDetails(t *testing.T) {
 		response.Footprint.Co2eGrams/1000.0)
 }
 
+func TestCreateQuote_MerchantAddressWithoutCountry(t *testing.T) {
+	orchestrator := setupOrchestrator()
+	ctx := context.Background()
+
+	// org-parent-1 is in GBR, so the merchant address is validated as British
+	req := &CreateQuoteRequest{
+		Locale:         "en-GB",
+		OrganisationID: "org-parent-1",
+		Customer: CustomerRequest{
+			Reference: "cust-ref-merchant-country",
+			Country:   "GBR",
+		},
+		Merchant: &MerchantRequest{
+			Name: "Test Restaurant",
+			MCC:  "5812",
+			Address: MerchantAddressRequest{
+				Address1:   "123 High Street",
+				City:       "London",
+				PostalCode: "sw1a1aa",
+			},
+		},
+	}
+
+	if _, err := orchestrator.CreateQuote(ctx, req, apiKeyCaller("org-parent-1")); err != nil {
+		t.Fatalf("CreateQuote failed: %v", err)
+	}
+	if req.Merchant.Address.PostalCode != "SW1A 1AA" {
+		t.Errorf("Expected normalised postal code SW1A 1AA, got %q", req.Merchant.Address.PostalCode)
+	}
+}
+
 func TestCreateQuote_WithFilterByLocation(t *testing.T) {
 	orchestrator := setupOrchestrator()
 	ctx := context.Background()
@@ -332,7 +750,7 @@ func TestCreateQuote_WithFilterByLocation(t *testing.T) {
 		},
 	}
 
-	response, err := orchestrator.CreateQuote(ctx, req, "org-parent-1")
+	response, err := orchestrator.CreateQuote(ctx, req, apiKeyCaller("org-parent-1"))
 	if err != nil {
 		t.Fatalf("CreateQuote with location filter failed: %v", err)
 	}
@@ -372,7 +790,7 @@ func TestCreateQuote_WithImpactPartnerDetails(t *testing.T) {
 		},
 	}
 
-	response, err := orchestrator.CreateQuote(ctx, req, "org-parent-1")
+	response, err := orchestrator.CreateQuote(ctx, req, apiKeyCaller("org-parent-1"))
 	if err != nil {
 		t.Fatalf("CreateQuote with partner details failed: %v", err)
 	}
@@ -414,13 +832,13 @@ func TestGetQuote(t *testing.T) {
 		},
 	}
 
-	createResponse, err := orchestrator.CreateQuote(ctx, req, "org-parent-1")
+	createResponse, err := orchestrator.CreateQuote(ctx, req, apiKeyCaller("org-parent-1"))
 	if err != nil {
 		t.Fatalf("CreateQuote failed: %v", err)
 	}
 
 	// Now retrieve it
-	quote, err := orchestrator.GetQuote(ctx, createResponse.ID)
+	quote, err := orchestrator.GetQuote(ctx, "org-parent-1", createResponse.ID)
 	if err != nil {
 		t.Fatalf("GetQuote failed: %v", err)
 	}
@@ -445,11 +863,66 @@ func TestGetQuote(t *testing.T) {
 		quote.ID, quote.QuoteReference, quote.Status)
 }
 
+func TestGetQuote_MerchantTaxLiability(t *testing.T) {
+	orchestrator := setupOrchestrator()
+	ctx := context.Background()
+
+	tests := []struct {
+		name            string
+		organisationID  string
+		customerCountry string
+		want            bool
+	}{
+		// org-parent-1 is VAT registered in GBR
+		{name: "Registered merchant, domestic customer", organisationID: "org-parent-1", customerCountry: "GBR", want: true},
+		// org-child-1 has no billing tax number
+		{name: "Unregistered merchant", organisationID: "org-child-1", customerCountry: "IRL", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &CreateQuoteRequest{
+				Locale:         "en-GB",
+				OrganisationID: tt.organisationID,
+				Customer: CustomerRequest{
+					Reference: "cust-ref-tax-" + tt.organisationID,
+					Country:   tt.customerCountry,
+				},
+				OrderItems: []OrderItemRequest{
+					{
+						ItemID:   "item-tax",
+						Name:     "Test Product",
+						Category: "general",
+						Quantity: 1,
+						UnitPrice: OrderItemPrice{
+							Value:        100.00,
+							CurrencyCode: "EUR",
+						},
+					},
+				},
+			}
+
+			createResponse, err := orchestrator.CreateQuote(ctx, req, apiKeyCaller("org-parent-1"))
+			if err != nil {
+				t.Fatalf("CreateQuote failed: %v", err)
+			}
+
+			quote, err := orchestrator.GetQuote(ctx, "org-parent-1", createResponse.ID)
+			if err != nil {
+				t.Fatalf("GetQuote failed: %v", err)
+			}
+			if quote.IsMerchantTaxLiable != tt.want {
+				t.Errorf("Expected isMerchantTaxLiable %v, got %v", tt.want, quote.IsMerchantTaxLiable)
+			}
+		})
+	}
+}
+
 func TestGetQuote_NotFound(t *testing.T) {
 	orchestrator := setupOrchestrator()
 	ctx := context.Background()
 
-	_, err := orchestrator.GetQuote(ctx, "non-existent-id")
+	_, err := orchestrator.GetQuote(ctx, "org-parent-1", "non-existent-id")
 	if err == nil {
 		t.Fatal("Expected error for non-existent quote")
 	}
@@ -457,6 +930,31 @@ func TestGetQuote_NotFound(t *testing.T) {
 	t.Logf("Correctly returned error for non-existent quote: %v", err)
 }
 
+func TestGetQuote_OutsideCallerHierarchy(t *testing.T) {
+	orchestrator := setupOrchestrator()
+	ctx := context.Background()
+
+	req := &CreateQuoteRequest{
+		Locale:         "en-GB",
+		OrganisationID: "org-parent-1",
+		Customer: CustomerRequest{
+			Reference: "cust-ref-scope",
+			Country:   "GBR",
+		},
+	}
+	createResponse, err := orchestrator.CreateQuote(ctx, req, apiKeyCaller("org-parent-1"))
+	if err != nil {
+		t.Fatalf("CreateQuote failed: %v", err)
+	}
+
+	// A child organisation cannot read its parent's quotes
+	_, err = orchestrator.GetQuote(ctx, "org-child-1", createResponse.ID)
+	var domainErr *errors.DomainError
+	if !errors.IsDomainError(err, &domainErr) || domainErr.Code != errors.ErrCodeNotFound {
+		t.Fatalf("Expected not found error, got %v", err)
+	}
+}
+
 func TestCreateQuote_WithEmptyCustomerReference(t *testing.T) {
 	orchestrator := setupOrchestrator()
 	ctx := context.Background()
@@ -486,7 +984,7 @@ func TestCreateQuote_WithEmptyCustomerReference(t *testing.T) {
 		},
 	}
 
-	response, err := orchestrator.CreateQuote(ctx, req, "org-parent-1")
+	response, err := orchestrator.CreateQuote(ctx, req, apiKeyCaller("org-parent-1"))
 	if err != nil {
 		t.Fatalf("CreateQuote with empty reference should succeed at orchestrator level: %v", err)
 	}
@@ -535,9 +1033,247 @@ func BenchmarkCreateQuote(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		req.Customer.Reference = "bench-cust-" + string(rune(i))
 		req.OrderItems[0].ItemID = "bench-item-" + string(rune(i))
-		_, err := orchestrator.CreateQuote(ctx, req, "org-parent-1")
+		_, err := orchestrator.CreateQuote(ctx, req, apiKeyCaller("org-parent-1"))
 		if err != nil {
 			b.Fatalf("CreateQuote failed: %v", err)
 		}
 	}
 }
+
+func TestCreateQuote_SessionRestrictions(t *testing.T) {
+	orchestrator := setupOrchestrator()
+	ctx := context.Background()
+
+	tests := []struct {
+		name           string
+		organisationID string
+		customerRef    string
+		wantErr        bool
+	}{
+		{name: "Own organisation and customer", organisationID: "org-child-1", customerRef: "cust-session", wantErr: false},
+		{name: "Another customer", organisationID: "org-child-1", customerRef: "cust-other", wantErr: true},
+		// Unlike API keys, sessions cannot act for other organisations in the hierarchy
+		{name: "Another organisation", organisationID: "org-child-2", customerRef: "cust-session", wantErr: true},
+	}
+
+	caller := sessionCaller("org-child-1", "cust-session")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &CreateQuoteRequest{
+				Locale:         "en-GB",
+				OrganisationID: tt.organisationID,
+				Customer:       CustomerRequest{Reference: tt.customerRef, Country: "IRL"},
+			}
+			resp, err := orchestrator.CreateQuote(ctx, req, caller)
+			if tt.wantErr {
+				var domainErr *errors.DomainError
+				if !errors.IsDomainError(err, &domainErr) || domainErr.Code != errors.ErrCodeForbidden {
+					t.Fatalf("Expected forbidden error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CreateQuote failed: %v", err)
+			}
+
+			quote, err := orchestrator.GetQuote(ctx, "org-child-1", resp.ID)
+			if err != nil {
+				t.Fatalf("GetQuote failed: %v", err)
+			}
+			if quote.EkkoProduct != auth.ProductCheckoutSDK {
+				t.Errorf("Expected ekkoProduct %s, got %s", auth.ProductCheckoutSDK, quote.EkkoProduct)
+			}
+		})
+	}
+}
+
+func TestAcceptQuote(t *testing.T) {
+	orchestrator := setupOrchestrator()
+	ctx := context.Background()
+
+	req := &CreateQuoteRequest{
+		Locale:         "en-GB",
+		OrganisationID: "org-child-1",
+		Customer:       CustomerRequest{Reference: "cust-accept", Country: "IRL"},
+	}
+	resp, err := orchestrator.CreateQuote(ctx, req, apiKeyCaller("org-parent-1"))
+	if err != nil {
+		t.Fatalf("CreateQuote failed: %v", err)
+	}
+
+	// A session for another customer cannot see the quote
+	_, err = orchestrator.AcceptQuote(ctx, sessionCaller("org-child-1", "cust-other"), resp.ID)
+	var domainErr *errors.DomainError
+	if !errors.IsDomainError(err, &domainErr) || domainErr.Code != errors.ErrCodeNotFound {
+		t.Fatalf("Expected not found error, got %v", err)
+	}
+
+	quote, err := orchestrator.AcceptQuote(ctx, sessionCaller("org-child-1", "cust-accept"), resp.ID)
+	if err != nil {
+		t.Fatalf("AcceptQuote failed: %v", err)
+	}
+	if quote.Status != StatusAccepted {
+		t.Errorf("Expected status %s, got %s", StatusAccepted, quote.Status)
+	}
+
+	// Only pending quotes can be accepted
+	_, err = orchestrator.AcceptQuote(ctx, apiKeyCaller("org-child-1"), resp.ID)
+	if !errors.IsDomainError(err, &domainErr) || domainErr.Code != errors.ErrCodeValidation {
+		t.Fatalf("Expected validation error, got %v", err)
+	}
+
+	// A session issued before its organisation was deactivated cannot accept quotes
+	resp, err = orchestrator.CreateQuote(ctx, req, apiKeyCaller("org-parent-1"))
+	if err != nil {
+		t.Fatalf("CreateQuote failed: %v", err)
+	}
+	if _, err := orchestrator.organisationService.DeactivateOrganisation(ctx, "org-parent-1", "org-child-1", nil); err != nil {
+		t.Fatalf("DeactivateOrganisation failed: %v", err)
+	}
+	_, err = orchestrator.AcceptQuote(ctx, sessionCaller("org-child-1", "cust-accept"), resp.ID)
+	if !errors.IsDomainError(err, &domainErr) || domainErr.Code != errors.ErrCodeOrganisationInactive {
+		t.Fatalf("Expected organisation inactive error, got %v", err)
+	}
+}
+
+func TestCreateQuote_ReturningCustomerMoves(t *testing.T) {
+	orchestrator := setupOrchestrator()
+	ctx := context.Background()
+
+	first, err := orchestrator.CreateQuote(ctx, &CreateQuoteRequest{
+		Locale:         "en-GB",
+		OrganisationID: "org-parent-1",
+		Customer:       CustomerRequest{Reference: "cust-moving", Country: "GBR"},
+	}, apiKeyCaller("org-parent-1"))
+	if err != nil {
+		t.Fatalf("CreateQuote failed: %v", err)
+	}
+	second, err := orchestrator.CreateQuote(ctx, &CreateQuoteRequest{
+		Locale:         "en-GB",
+		OrganisationID: "org-parent-1",
+		Customer:       CustomerRequest{Reference: "cust-moving", Country: "FRA"},
+	}, apiKeyCaller("org-parent-1"))
+	if err != nil {
+		t.Fatalf("CreateQuote failed: %v", err)
+	}
+
+	// The stored customer moves, while each quote keeps the address at quote time
+	firstQuote, _ := orchestrator.GetQuote(ctx, "org-parent-1", first.ID)
+	secondQuote, _ := orchestrator.GetQuote(ctx, "org-parent-1", second.ID)
+	if firstQuote.CustomerID != secondQuote.CustomerID {
+		t.Fatal("Expected both quotes for the same customer")
+	}
+	if firstQuote.CustomerAddress.CountryCode != "GBR" || secondQuote.CustomerAddress.CountryCode != "FRA" {
+		t.Errorf("Expected quote addresses GBR then FRA, got %s then %s",
+			firstQuote.CustomerAddress.CountryCode, secondQuote.CustomerAddress.CountryCode)
+	}
+	cust, err := orchestrator.customerService.GetCustomer(ctx, firstQuote.CustomerID)
+	if err != nil {
+		t.Fatalf("GetCustomer failed: %v", err)
+	}
+	if cust.CountryCode != "FRA" {
+		t.Errorf("Expected stored customer in FRA, got %s", cust.CountryCode)
+	}
+}
+
+func TestListQuotes(t *testing.T) {
+	orchestrator := setupOrchestrator()
+	ctx := context.Background()
+
+	base := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+	for _, q := range []*Entity{
+		{ID: "q-1", OrganisationID: "org-parent-1", CustomerID: "cust-a", Status: StatusAccepted, Currency: "EUR", EkkoProduct: auth.ProductAPI, CreatedAt: base},
+		{ID: "q-2", OrganisationID: "org-child-1", CustomerID: "cust-b", Status: StatusPending, Currency: "GBP", EkkoProduct: auth.ProductCheckoutSDK, CreatedAt: base.Add(time.Hour)},
+		{ID: "q-3", OrganisationID: "org-child-1", CustomerID: "cust-b", Status: StatusAccepted, Currency: "EUR", EkkoProduct: auth.ProductAPI, CreatedAt: base.Add(2 * time.Hour)},
+		{ID: "q-4", OrganisationID: "org-child-1", CustomerID: "cust-c", Status: StatusCompleted, Currency: "EUR", EkkoProduct: auth.ProductAPI, CreatedAt: base.Add(2 * time.Hour)},
+		{ID: "q-5", OrganisationID: "org-child-2", CustomerID: "cust-d", Status: StatusPending, Currency: "USD", EkkoProduct: auth.ProductAPI, CreatedAt: base.AddDate(0, 1, 0)},
+	} {
+		if err := orchestrator.quoteRepo.Create(ctx, q); err != nil {
+			t.Fatalf("creating quote failed: %v", err)
+		}
+	}
+
+	ids := func(quotes []*Entity) []string {
+		result := make([]string, len(quotes))
+		for i, q := range quotes {
+			result[i] = q.ID
+		}
+		return result
+	}
+
+	tests := []struct {
+		name   string
+		caller string
+		req    ListQuotesRequest
+		want   []string
+	}{
+		{name: "Caller and descendants, newest first", caller: "org-parent-1", want: []string{"q-5", "q-4", "q-3", "q-2", "q-1"}},
+		{name: "Child sees only its own", caller: "org-child-1", want: []string{"q-4", "q-3", "q-2"}},
+		{name: "Single organisation", caller: "org-parent-1", req: ListQuotesRequest{OrganisationID: "org-parent-1"}, want: []string{"q-1"}},
+		{name: "Statuses", caller: "org-parent-1", req: ListQuotesRequest{Statuses: []Status{StatusAccepted, StatusCompleted}}, want: []string{"q-4", "q-3", "q-1"}},
+		{name: "Customer", caller: "org-parent-1", req: ListQuotesRequest{CustomerID: "cust-b"}, want: []string{"q-3", "q-2"}},
+		{name: "Currency", caller: "org-parent-1", req: ListQuotesRequest{Currency: "gbp"}, want: []string{"q-2"}},
+		{name: "Product", caller: "org-parent-1", req: ListQuotesRequest{Product: auth.ProductCheckoutSDK}, want: []string{"q-2"}},
+		{name: "Date range", caller: "org-parent-1", req: ListQuotesRequest{From: base.Add(time.Hour), To: base.AddDate(0, 1, 0)}, want: []string{"q-4", "q-3", "q-2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := orchestrator.ListQuotes(ctx, apiKeyCaller(tt.caller), &tt.req)
+			if err != nil {
+				t.Fatalf("ListQuotes failed: %v", err)
+			}
+			if got := ids(response.Quotes); !slices.Equal(got, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+			if response.NextCursor != "" {
+				t.Errorf("Expected a single page, got cursor %q", response.NextCursor)
+			}
+		})
+	}
+
+	// Pages follow each other without gaps, including quotes created at the same time
+	var pages [][]string
+	req := &ListQuotesRequest{Limit: 2}
+	for {
+		response, err := orchestrator.ListQuotes(ctx, apiKeyCaller("org-parent-1"), req)
+		if err != nil {
+			t.Fatalf("ListQuotes failed: %v", err)
+		}
+		pages = append(pages, ids(response.Quotes))
+		if response.NextCursor == "" {
+			break
+		}
+		req.Cursor = response.NextCursor
+	}
+	if len(pages) != 3 || !slices.Equal(slices.Concat(pages...), []string{"q-5", "q-4", "q-3", "q-2", "q-1"}) {
+		t.Errorf("Expected pages of 2, 2 and 1 quotes, got %v", pages)
+	}
+}
+
+func TestListQuotes_Validation(t *testing.T) {
+	orchestrator := setupOrchestrator()
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		req       ListQuotesRequest
+		wantField string
+		wantCode  string
+	}{
+		{name: "Unknown status", req: ListQuotesRequest{Statuses: []Status{"lost"}}, wantField: "status", wantCode: errors.ErrCodeValidation},
+		{name: "Unknown product", req: ListQuotesRequest{Product: "kiosk"}, wantField: "product", wantCode: errors.ErrCodeValidation},
+		{name: "Limit too high", req: ListQuotesRequest{Limit: MaxListLimit + 1}, wantField: "limit", wantCode: errors.ErrCodeValidation},
+		{name: "Invalid cursor", req: ListQuotesRequest{Cursor: "not-a-cursor"}, wantField: "cursor", wantCode: errors.ErrCodeValidation},
+		{name: "Empty date range", req: ListQuotesRequest{From: time.Now(), To: time.Now().Add(-time.Hour)}, wantField: "to", wantCode: errors.ErrCodeValidation},
+		{name: "Organisation outside hierarchy", req: ListQuotesRequest{OrganisationID: "org-child-2"}, wantCode: errors.ErrCodeForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := orchestrator.ListQuotes(ctx, apiKeyCaller("org-child-1"), &tt.req)
+			var domainErr *errors.DomainError
+			if !errors.IsDomainError(err, &domainErr) || domainErr.Code != tt.wantCode || domainErr.Field != tt.wantField {
+				t.Errorf("Expected %s error for %q, got %v", tt.wantCode, tt.wantField, err)
+			}
+		})
+	}
+}
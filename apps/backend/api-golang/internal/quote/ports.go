@@ -3,17 +3,59 @@ package quote
 
 import (
 	"context"
+	"time"
+
+	"api-golang/internal/shared/auth"
 )
 
+// CustomerFilter narrows a customer's quotes. Zero times leave that end of
+// the creation date range open.
+type CustomerFilter struct {
+	CustomerID     string    // Required
+	OrganisationID string    // Optional, only quotes for this organisation
+	Statuses       []Status  // Optional, any of these statuses
+	From           time.Time // Inclusive
+	To             time.Time // Exclusive
+}
+
+// ListFilter narrows a quote listing. Zero values leave a filter unset.
+type ListFilter struct {
+	OrganisationIDs []string  // Required, quotes for any of these organisations
+	CustomerID      string    // Optional
+	Statuses        []Status  // Optional, any of these statuses
+	Currency        string    // Optional ISO-3 currency code
+	Product         string    // Optional Ekko product
+	From            time.Time // Inclusive creation date
+	To              time.Time // Exclusive creation date
+	After           *ListCursor
+	Limit           int // Maximum number of quotes to return
+}
+
+// ListCursor is the position of the last quote of a page, in the listing's
+// newest-first order
+type ListCursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
 // Repository defines the port for quote data access
 type Repository interface {
 	Create(ctx context.Context, quote *Entity) error
 	GetByID(ctx context.Context, id string) (*Entity, error)
 	Update(ctx context.Context, quote *Entity) error
+	// ListByCustomer returns a customer's quotes, oldest first
+	ListByCustomer(ctx context.Context, filter CustomerFilter) ([]*Entity, error)
+	// List returns quotes matching the filter, newest first, starting after
+	// the cursor
+	List(ctx context.Context, filter ListFilter) ([]*Entity, error)
 }
 
 // Service defines the port for quote business logic
 type Service interface {
-	CreateQuote(ctx context.Context, req *CreateQuoteRequest) (*CreateQuoteResponse, error)
-	GetQuote(ctx context.Context, id string) (*Entity, error)
+	CreateQuote(ctx context.Context, req *CreateQuoteRequest, caller *auth.Principal) (*CreateQuoteResponse, error)
+	GetQuote(ctx context.Context, callerOrgID, id string) (*Entity, error)
+	ListQuotes(ctx context.Context, caller *auth.Principal, req *ListQuotesRequest) (*ListQuotesResponse, error)
+	AcceptQuote(ctx context.Context, caller *auth.Principal, id string) (*Entity, error)
+	ListByCustomer(ctx context.Context, filter CustomerFilter) ([]*Entity, error)
+	ErasePersonalData(ctx context.Context, customerID string) (int, error)
 }
@@ -2,6 +2,8 @@ package quote
 
 import (
 	"context"
+	"slices"
+	"sort"
 	"sync"
 
 	"api-golang/internal/shared/errors"
@@ -59,3 +61,86 @@ func (r *InMemoryRepository) Update(_ context.Context, quote *Entity) error {
 	r.quotes[quote.ID] = quote
 	return nil
 }
+
+// ListByCustomer retrieves a customer's quotes, oldest first
+func (r *InMemoryRepository) ListByCustomer(_ context.Context, filter CustomerFilter) ([]*Entity, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	quotes := make([]*Entity, 0)
+	for _, quote := range r.quotes {
+		if quote.CustomerID != filter.CustomerID {
+			continue
+		}
+		if filter.OrganisationID != "" && quote.OrganisationID != filter.OrganisationID {
+			continue
+		}
+		if len(filter.Statuses) > 0 && !slices.Contains(filter.Statuses, quote.Status) {
+			continue
+		}
+		if !filter.From.IsZero() && quote.CreatedAt.Before(filter.From) {
+			continue
+		}
+		if !filter.To.IsZero() && !quote.CreatedAt.Before(filter.To) {
+			continue
+		}
+		quotes = append(quotes, quote)
+	}
+	sort.Slice(quotes, func(i, j int) bool {
+		if !quotes[i].CreatedAt.Equal(quotes[j].CreatedAt) {
+			return quotes[i].CreatedAt.Before(quotes[j].CreatedAt)
+		}
+		return quotes[i].ID < quotes[j].ID
+	})
+	return quotes, nil
+}
+
+// List retrieves quotes matching the filter, newest first
+func (r *InMemoryRepository) List(_ context.Context, filter ListFilter) ([]*Entity, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	quotes := make([]*Entity, 0)
+	for _, quote := range r.quotes {
+		if !slices.Contains(filter.OrganisationIDs, quote.OrganisationID) {
+			continue
+		}
+		if filter.CustomerID != "" && quote.CustomerID != filter.CustomerID {
+			continue
+		}
+		if len(filter.Statuses) > 0 && !slices.Contains(filter.Statuses, quote.Status) {
+			continue
+		}
+		if filter.Currency != "" && quote.Currency != filter.Currency {
+			continue
+		}
+		if filter.Product != "" && quote.EkkoProduct != filter.Product {
+			continue
+		}
+		if !filter.From.IsZero() && quote.CreatedAt.Before(filter.From) {
+			continue
+		}
+		if !filter.To.IsZero() && !quote.CreatedAt.Before(filter.To) {
+			continue
+		}
+		if filter.After != nil && !isAfter(quote, filter.After) {
+			continue
+		}
+		quotes = append(quotes, quote)
+	}
+	sort.Slice(quotes, func(i, j int) bool {
+		return isAfter(quotes[j], &ListCursor{CreatedAt: quotes[i].CreatedAt, ID: quotes[i].ID})
+	})
+	if filter.Limit > 0 && len(quotes) > filter.Limit {
+		quotes = quotes[:filter.Limit]
+	}
+	return quotes, nil
+}
+
+// isAfter reports whether a quote comes after the cursor in newest-first order
+func isAfter(quote *Entity, cursor *ListCursor) bool {
+	if !quote.CreatedAt.Equal(cursor.CreatedAt) {
+		return quote.CreatedAt.Before(cursor.CreatedAt)
+	}
+	return quote.ID < cursor.ID
+}
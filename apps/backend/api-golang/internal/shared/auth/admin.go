@@ -0,0 +1,46 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"fmt"
+
+	"api-golang/internal/shared/errors"
+)
+
+// AdminKeyAuthenticator authenticates the operator key that guards the admin
+// endpoints. Its principal holds AdminScopes, which organisation API keys can
+// never be granted.
+type AdminKeyAuthenticator struct {
+	hash [sha256.Size]byte
+	set  bool
+}
+
+// NewAdminKeyAuthenticator creates an authenticator for an operator key of at
+// least 32 bytes. An empty key disables the admin endpoints.
+func NewAdminKeyAuthenticator(key string) (*AdminKeyAuthenticator, error) {
+	if key == "" {
+		return &AdminKeyAuthenticator{}, nil
+	}
+	if len(key) < 32 {
+		return nil, fmt.Errorf("admin API key must be at least 32 bytes, got %d", len(key))
+	}
+	return &AdminKeyAuthenticator{hash: sha256.Sum256([]byte(key)), set: true}, nil
+}
+
+// Authenticate checks a presented key against the operator key in constant time
+func (a *AdminKeyAuthenticator) Authenticate(_ context.Context, credential string) (*Principal, error) {
+	if !a.set {
+		return nil, errors.NewUnauthorizedError("auth", "admin endpoints are disabled")
+	}
+	hash := sha256.Sum256([]byte(credential))
+	if subtle.ConstantTimeCompare(hash[:], a.hash[:]) != 1 {
+		return nil, errors.NewUnauthorizedError("auth", "invalid admin API key")
+	}
+	return &Principal{
+		CredentialType: CredentialAdmin,
+		CredentialID:   "admin",
+		Scopes:         AdminScopes,
+	}, nil
+}
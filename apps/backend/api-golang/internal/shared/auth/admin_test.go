@@ -0,0 +1,53 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"api-golang/internal/shared/errors"
+)
+
+func TestAdminKeyAuthenticator(t *testing.T) {
+	const key = "admin-0123456789abcdef0123456789"
+	if _, err := NewAdminKeyAuthenticator("too-short"); err == nil {
+		t.Error("Expected an error for a short admin key")
+	}
+
+	admin, err := NewAdminKeyAuthenticator(key)
+	if err != nil {
+		t.Fatalf("NewAdminKeyAuthenticator failed: %v", err)
+	}
+	principal, err := admin.Authenticate(context.Background(), key)
+	if err != nil {
+		t.Fatalf("Authenticate failed: %v", err)
+	}
+	if !principal.HasScope(ScopeCarbonFactorsAdmin) || principal.OrganisationID != "" {
+		t.Errorf("Unexpected principal %+v", principal)
+	}
+
+	disabled, _ := NewAdminKeyAuthenticator("")
+	for _, tt := range []struct {
+		name          string
+		authenticator *AdminKeyAuthenticator
+		credential    string
+	}{
+		{name: "Wrong key", authenticator: admin, credential: key + "x"},
+		{name: "Empty key", authenticator: admin, credential: ""},
+		{name: "Disabled", authenticator: disabled, credential: ""},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.authenticator.Authenticate(context.Background(), tt.credential)
+			var domainErr *errors.DomainError
+			if !errors.IsDomainError(err, &domainErr) || domainErr.Code != errors.ErrCodeUnauthorized {
+				t.Errorf("Expected unauthorized error, got %v", err)
+			}
+		})
+	}
+
+	// Organisation API keys can never be granted the admin scopes
+	for _, scope := range AdminScopes {
+		if IsValidScope(scope) {
+			t.Errorf("Admin scope %s must not be grantable", scope)
+		}
+	}
+}
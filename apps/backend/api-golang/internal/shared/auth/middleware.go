@@ -0,0 +1,101 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"api-golang/internal/shared/errors"
+)
+
+// Authenticator resolves a credential presented by a caller
+type Authenticator interface {
+	Authenticate(ctx context.Context, credential string) (*Principal, error)
+}
+
+// Middleware authenticates requests and checks their scopes
+type Middleware struct {
+	apiKeys  Authenticator
+	sessions Authenticator
+}
+
+// NewMiddleware creates a new authentication middleware. sessions may be nil,
+// in which case only API keys are accepted.
+func NewMiddleware(apiKeys, sessions Authenticator) *Middleware {
+	return &Middleware{apiKeys: apiKeys, sessions: sessions}
+}
+
+// Require wraps a handler so that it only runs for callers holding the scope.
+// The credential, an API key or a session token, is read from
+// "Authorization: Bearer <credential>" or the X-API-Key header, and the
+// resolved Principal is added to the request context.
+func (m *Middleware) Require(scope string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		credential := credentialFromRequest(r)
+		if credential == "" {
+			writeError(w, http.StatusUnauthorized, errors.ErrCodeUnauthorized, "API key or session token is required")
+			return
+		}
+
+		authenticator := m.apiKeys
+		if IsSessionToken(credential) {
+			if m.sessions == nil {
+				writeError(w, http.StatusUnauthorized, errors.ErrCodeUnauthorized, "session tokens are not accepted")
+				return
+			}
+			authenticator = m.sessions
+		}
+
+		principal, err := authenticator.Authenticate(r.Context(), credential)
+		if err != nil {
+			var domainErr *errors.DomainError
+			if errors.IsDomainError(err, &domainErr) && domainErr.Code == errors.ErrCodeUnauthorized {
+				writeError(w, http.StatusUnauthorized, errors.ErrCodeUnauthorized, domainErr.Message)
+				return
+			}
+			if errors.IsDomainError(err, &domainErr) && domainErr.Code == errors.ErrCodeOrganisationInactive {
+				writeError(w, http.StatusForbidden, errors.ErrCodeOrganisationInactive, domainErr.Message)
+				return
+			}
+			writeError(w, http.StatusInternalServerError, errors.ErrCodeInternalError, err.Error())
+			return
+		}
+		if !principal.HasScope(scope) {
+			writeError(w, http.StatusForbidden, errors.ErrCodeForbidden, "credential is missing the "+scope+" scope")
+			return
+		}
+
+		next(w, r.WithContext(WithPrincipal(r.Context(), principal)))
+	}
+}
+
+func credentialFromRequest(r *http.Request) string {
+	if header := r.Header.Get("Authorization"); header != "" {
+		if scheme, credential, found := strings.Cut(header, " "); found && strings.EqualFold(scheme, "Bearer") {
+			return strings.TrimSpace(credential)
+		}
+		return ""
+	}
+	return strings.TrimSpace(r.Header.Get("X-API-Key"))
+}
+
+// ErrorResponse represents an error response
+type ErrorResponse struct {
+	Error struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	resp := ErrorResponse{}
+	resp.Error.Code = code
+	resp.Error.Message = message
+	w.Header().Set("Content-Type", "application/json")
+	if status == http.StatusUnauthorized {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+	}
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,71 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"api-golang/internal/shared/errors"
+)
+
+type stubAuthenticator map[string]*Principal
+
+func (s stubAuthenticator) Authenticate(_ context.Context, credential string) (*Principal, error) {
+	if credential == "inactive-key" {
+		return nil, errors.NewOrganisationInactiveError("auth", "organisation org-2 is inactive")
+	}
+	if principal, ok := s[credential]; ok {
+		return principal, nil
+	}
+	return nil, errors.NewUnauthorizedError("auth", "invalid API key")
+}
+
+func TestMiddleware_Require(t *testing.T) {
+	middleware := NewMiddleware(stubAuthenticator{
+		"quotes-key": {OrganisationID: "org-1", Scopes: []string{ScopeQuotesCreate}},
+	}, stubAuthenticator{
+		"header.claims.signature": {OrganisationID: "org-1", CredentialType: CredentialSession, Scopes: []string{ScopeQuotesCreate}},
+	})
+	handler := middleware.Require(ScopeQuotesCreate, func(w http.ResponseWriter, r *http.Request) {
+		principal, ok := PrincipalFromContext(r.Context())
+		if !ok || principal.OrganisationID != "org-1" {
+			t.Errorf("Expected principal for org-1 in context, got %+v", principal)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	readHandler := middleware.Require(ScopeQuotesRead, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		header  string
+		value   string
+		want    int
+	}{
+		{name: "Bearer key", handler: handler, header: "Authorization", value: "Bearer quotes-key", want: http.StatusNoContent},
+		{name: "X-API-Key header", handler: handler, header: "X-API-Key", value: "quotes-key", want: http.StatusNoContent},
+		{name: "Session token", handler: handler, header: "Authorization", value: "Bearer header.claims.signature", want: http.StatusNoContent},
+		{name: "No credential", handler: handler, want: http.StatusUnauthorized},
+		{name: "Unknown key", handler: handler, header: "Authorization", value: "Bearer other", want: http.StatusUnauthorized},
+		{name: "Organisation header is not a credential", handler: handler, header: "X-Organisation-ID", value: "org-1", want: http.StatusUnauthorized},
+		{name: "Inactive organisation", handler: handler, header: "Authorization", value: "Bearer inactive-key", want: http.StatusForbidden},
+		{name: "Missing scope", handler: readHandler, header: "Authorization", value: "Bearer quotes-key", want: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/quotes", nil)
+			if tt.header != "" {
+				req.Header.Set(tt.header, tt.value)
+			}
+			rec := httptest.NewRecorder()
+			tt.handler(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("Expected status %d, got %d: %s", tt.want, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+// Package auth contains the authenticated caller and the HTTP middleware that resolves it.
+package auth
+
+import (
+	"context"
+	"slices"
+)
+
+// Scopes granted to credentials
+const (
+	ScopeQuotesCreate        = "quotes:create"
+	ScopeQuotesRead          = "quotes:read"
+	ScopeQuotesAccept        = "quotes:accept"
+	ScopeCustomersManage     = "customers:manage"
+	ScopeCustomersPrivacy    = "customers:privacy" // Export and erase customers' personal data
+	ScopeOrganisationsManage = "organisations:manage"
+	ScopeAPIKeysManage       = "api-keys:manage"
+	ScopeTaxExemptionsManage = "tax-exemptions:manage" // Register and list sales tax exemptions
+
+	ScopeCarbonFactorsAdmin = "admin:carbon-factors" // Operator only: list and import carbon factors
+)
+
+// AllScopes lists every scope an organisation API key can be granted
+var AllScopes = []string{
+	ScopeQuotesCreate,
+	ScopeQuotesRead,
+	ScopeQuotesAccept,
+	ScopeCustomersManage,
+	ScopeCustomersPrivacy,
+	ScopeOrganisationsManage,
+	ScopeAPIKeysManage,
+	ScopeTaxExemptionsManage,
+}
+
+// AdminScopes lists the scopes of the operator key. They are not in AllScopes,
+// so they can never be granted to an organisation's API keys.
+var AdminScopes = []string{
+	ScopeCarbonFactorsAdmin,
+}
+
+// Credential types
+const (
+	CredentialAPIKey  = "apiKey"  // Secret key held by a merchant backend
+	CredentialSession = "session" // Short-lived token held by a browser SDK
+	CredentialAdmin   = "admin"   // Operator key for the admin endpoints
+)
+
+// Ekko products a request can come from
+const (
+	ProductAPI         = "API"
+	ProductCheckoutSDK = "checkout-sdk"
+	ProductEmbeddedSDK = "embedded-sdk"
+	ProductTakeoverSDK = "takeover-sdk"
+	ProductImpactPay   = "impact-pay"
+)
+
+// SessionProducts lists the products session tokens can be issued for
+var SessionProducts = []string{
+	ProductCheckoutSDK,
+	ProductEmbeddedSDK,
+	ProductTakeoverSDK,
+	ProductImpactPay,
+}
+
+// IsValidScope reports whether a scope can be granted to an organisation API key
+func IsValidScope(scope string) bool {
+	return slices.Contains(AllScopes, scope)
+}
+
+// Principal is the authenticated caller of a request
+type Principal struct {
+	OrganisationID    string   // Organisation the credential was issued to
+	CredentialType    string   // apiKey or session
+	CredentialID      string   // ID of the API key or session used
+	Scopes            []string // Scopes granted to the credential
+	CustomerReference string   // Customer a session is restricted to; empty for API keys
+	Product           string   // Ekko product the request comes from
+}
+
+// IsSession reports whether the caller authenticated with a session token
+func (p *Principal) IsSession() bool {
+	return p.CredentialType == CredentialSession
+}
+
+// HasScope reports whether the caller was granted a scope
+func (p *Principal) HasScope(scope string) bool {
+	return slices.Contains(p.Scopes, scope)
+}
+
+type principalKey struct{}
+
+// WithPrincipal returns a context carrying the authenticated caller
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext returns the authenticated caller, if any
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(*Principal)
+	return principal, ok && principal != nil
+}
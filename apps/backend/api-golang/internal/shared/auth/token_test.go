@@ -0,0 +1,61 @@
+package auth
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTokenSigner(t *testing.T) {
+	signer, err := NewTokenSigner([]byte("0123456789abcdef0123456789abcdef"))
+	if err != nil {
+		t.Fatalf("NewTokenSigner failed: %v", err)
+	}
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	signer.now = func() time.Time { return now }
+
+	token, err := signer.Sign(SessionClaims{
+		ID:                "session-1",
+		OrganisationID:    "org-1",
+		CustomerReference: "cust-1",
+		Product:           ProductCheckoutSDK,
+		Scopes:            []string{ScopeQuotesCreate},
+		IssuedAt:          now.Unix(),
+		ExpiresAt:         now.Add(15 * time.Minute).Unix(),
+	})
+	if err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+	if !IsSessionToken(token) {
+		t.Fatalf("Expected %q to look like a session token", token)
+	}
+
+	principal, err := signer.Authenticate(context.Background(), token)
+	if err != nil {
+		t.Fatalf("Authenticate failed: %v", err)
+	}
+	if !principal.IsSession() || principal.OrganisationID != "org-1" || principal.CustomerReference != "cust-1" || principal.Product != ProductCheckoutSDK {
+		t.Errorf("Unexpected principal %+v", principal)
+	}
+
+	// Tampered claims fail the signature check
+	parts := strings.Split(token, ".")
+	other, _ := signer.Sign(SessionClaims{OrganisationID: "org-2", ExpiresAt: now.Add(time.Hour).Unix()})
+	tampered := parts[0] + "." + strings.Split(other, ".")[1] + "." + parts[2]
+	if _, err := signer.Authenticate(context.Background(), tampered); err == nil {
+		t.Error("Expected tampered token to be rejected")
+	}
+
+	// Tokens signed with another secret are rejected
+	otherSigner, _ := NewTokenSigner([]byte("fedcba9876543210fedcba9876543210"))
+	forged, _ := otherSigner.Sign(SessionClaims{OrganisationID: "org-1", ExpiresAt: now.Add(time.Hour).Unix()})
+	if _, err := signer.Authenticate(context.Background(), forged); err == nil {
+		t.Error("Expected token signed with another secret to be rejected")
+	}
+
+	signer.now = func() time.Time { return now.Add(15 * time.Minute) }
+	if _, err := signer.Authenticate(context.Background(), token); err == nil {
+		t.Error("Expected expired token to be rejected")
+	}
+}
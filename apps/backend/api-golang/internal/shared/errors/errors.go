@@ -2,6 +2,7 @@
 package errors
 
 import (
+	"errors"
 	"fmt"
 )
 
@@ -10,6 +11,7 @@ type DomainError struct {
 	Code    string `json:"code"`
 	Message string `json:"message"`
 	Domain  string `json:"domain"`
+	Field   string `json:"field,omitempty"` // Request field that failed validation, if any
 	Cause   error  `json:"-"`
 }
 
@@ -32,6 +34,8 @@ const (
 	ErrCodeForbidden      = "FORBIDDEN"
 	ErrCodeInternalError  = "INTERNAL_ERROR"
 	ErrCodeExternalAPI    = "EXTERNAL_API_ERROR"
+
+	ErrCodeOrganisationInactive = "ORGANISATION_INACTIVE"
 )
 
 // NewNotFoundError creates a not found error
@@ -52,6 +56,16 @@ func NewValidationError(domain, message string) *DomainError {
 	}
 }
 
+// NewFieldValidationError creates a validation error for a specific request field
+func NewFieldValidationError(domain, field, message string) *DomainError {
+	return &DomainError{
+		Code:    ErrCodeValidation,
+		Message: message,
+		Domain:  domain,
+		Field:   field,
+	}
+}
+
 // NewUnauthorizedError creates an unauthorized error
 func NewUnauthorizedError(domain, message string) *DomainError {
 	return &DomainError{
@@ -70,6 +84,16 @@ func NewForbiddenError(domain, message string) *DomainError {
 	}
 }
 
+// NewOrganisationInactiveError creates an error for an inactive organisation,
+// with the organisation's status message as the message
+func NewOrganisationInactiveError(domain, message string) *DomainError {
+	return &DomainError{
+		Code:    ErrCodeOrganisationInactive,
+		Message: message,
+		Domain:  domain,
+	}
+}
+
 // NewInternalError creates an internal error
 func NewInternalError(domain, message string, cause error) *DomainError {
 	return &DomainError{
@@ -90,14 +114,10 @@ func Wrap(domain string, err error, message string) *DomainError {
 	}
 }
 
-// IsDomainError checks if an error is a DomainError and assigns it to target
+// IsDomainError checks if an error is, or wraps, a DomainError and assigns it to target
 func IsDomainError(err error, target **DomainError) bool {
 	if err == nil {
 		return false
 	}
-	if de, ok := err.(*DomainError); ok {
-		*target = de
-		return true
-	}
-	return false
+	return errors.As(err, target)
 }